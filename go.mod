@@ -18,6 +18,7 @@ require (
 	k8s.io/klog/v2 v2.130.1
 	k8s.io/release v0.17.12
 	sigs.k8s.io/boskos v0.0.0-20241205030959-9f79a9e4406a
+	sigs.k8s.io/yaml v1.4.0
 )
 
 require (
@@ -286,5 +287,4 @@ require (
 	sigs.k8s.io/release-sdk v0.12.1 // indirect
 	sigs.k8s.io/release-utils v0.8.4 // indirect
 	sigs.k8s.io/structured-merge-diff/v4 v4.4.1 // indirect
-	sigs.k8s.io/yaml v1.4.0 // indirect
 )
@@ -76,23 +76,30 @@ func (d *Deployer) Initialize() error {
 			d.boskos = boskosClient
 			d.boskosHeartbeatClose = make(chan struct{})
 
+			var resourceTypes []string
 			for i := 0; i < len(d.BoskosProjectsRequested); i++ {
 				for j := 0; j < d.BoskosProjectsRequested[i]; j++ {
-					resource, err := boskos.Acquire(
-						d.boskos,
-						d.BoskosResourceType[i],
-						time.Duration(d.BoskosAcquireTimeoutSeconds)*time.Second,
-						time.Duration(d.BoskosHeartbeatIntervalSeconds)*time.Second,
-						d.boskosHeartbeatClose,
-					)
-
-					if err != nil {
-						return fmt.Errorf("init failed to get project from boskos: %w", err)
-					}
-					d.Projects = append(d.Projects, resource.Name)
-					klog.V(1).Infof("Got project %s from boskos", resource.Name)
+					resourceTypes = append(resourceTypes, d.BoskosResourceType[i])
 				}
 			}
+			// the projects acquired before a failed acquisition are released
+			// by AcquireAll, so they are not leaked when init fails
+			resources, err := boskos.AcquireAll(
+				d.boskos,
+				resourceTypes,
+				boskos.DefaultAcquireState,
+				boskos.DefaultReleaseState,
+				time.Duration(d.BoskosAcquireTimeoutSeconds)*time.Second,
+				time.Duration(d.BoskosHeartbeatIntervalSeconds)*time.Second,
+				d.boskosHeartbeatClose,
+			)
+			if err != nil {
+				return fmt.Errorf("init failed to get project from boskos: %w", err)
+			}
+			for _, resource := range resources {
+				d.Projects = append(d.Projects, resource.Name)
+				klog.V(1).Infof("Got project %s from boskos", resource.Name)
+			}
 		}
 	}
 
@@ -18,8 +18,12 @@ package boskos
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
+	"net"
 	"os"
+	"regexp"
 	"time"
 
 	"k8s.io/klog/v2"
@@ -30,6 +34,22 @@ import (
 // const (for the run) owner string for consistency between up and down
 var boskosOwner = os.Getenv("JOB_NAME") + "-kubetest2"
 
+const (
+	// DefaultAcquireState is the state resources are acquired from by default.
+	DefaultAcquireState = "free"
+	// DefaultReleaseState is the state resources are released to by default.
+	DefaultReleaseState = "dirty"
+	// busyState is the state acquired resources are held in.
+	busyState = "busy"
+)
+
+// Acquirer is the subset of the boskos client needed to acquire resources
+// and keep them reserved.
+type Acquirer interface {
+	AcquireWait(ctx context.Context, rtype, state, dest string) (*common.Resource, error)
+	UpdateOne(name, state string, userData *common.UserData) error
+}
+
 // NewClient creates a boskos client for kubetest2 deployers.
 func NewClient(boskosLocation string) (*client.Client, error) {
 	boskos, err := client.NewClient(
@@ -46,11 +66,36 @@ func NewClient(boskosLocation string) (*client.Client, error) {
 }
 
 // Acquire acquires a resource for the given type and starts a heartbeat goroutine to keep the resource reserved.
-func Acquire(boskosClient *client.Client, resourceType string, timeout, heartbeatInterval time.Duration, heartbeatClose chan struct{}) (*common.Resource, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
+func Acquire(boskosClient Acquirer, resourceType string, timeout, heartbeatInterval time.Duration, heartbeatClose chan struct{}) (*common.Resource, error) {
+	return AcquireFromState(boskosClient, resourceType, DefaultAcquireState, timeout, heartbeatInterval, heartbeatClose)
+}
+
+// AcquireFromState is like Acquire but acquires a resource currently in the given state
+// instead of DefaultAcquireState.
+func AcquireFromState(boskosClient Acquirer, resourceType, state string, timeout, heartbeatInterval time.Duration, heartbeatClose chan struct{}) (*common.Resource, error) {
+	return AcquireFromStateWithRetry(boskosClient, resourceType, state, timeout, heartbeatInterval, heartbeatClose, 0, 0, HeartbeatOptions{})
+}
 
-	boskosResource, err := boskosClient.AcquireWait(ctx, resourceType, "free", "busy")
+// AcquireFromStateWithRetry is like AcquireFromState but retries the acquisition
+// up to retries times when it fails with a transient error, e.g. a 5xx response
+// or a reset connection while boskos restarts. The wait between attempts starts
+// at backoff, doubles after every failure and is jittered. Each attempt waits
+// up to timeout for a resource, running out of resources is not retried. The
+// heartbeat of the acquired resource is configured by heartbeat.
+func AcquireFromStateWithRetry(boskosClient Acquirer, resourceType, state string, timeout, heartbeatInterval time.Duration, heartbeatClose chan struct{}, retries int, backoff time.Duration, heartbeat HeartbeatOptions) (*common.Resource, error) {
+	var boskosResource *common.Resource
+	var err error
+	wait := backoff
+	for attempt := 0; ; attempt++ {
+		boskosResource, err = acquireWait(boskosClient, resourceType, state, timeout)
+		if err == nil || attempt >= retries || !isTransientError(err) {
+			break
+		}
+		delay := jitter(wait)
+		klog.V(1).Infof("[Boskos] acquiring a %q failed (retry %d/%d in %s): %v", resourceType, attempt+1, retries, delay, err)
+		time.Sleep(delay)
+		wait *= 2
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get a %q from boskos: %s", resourceType, err)
 	}
@@ -64,19 +109,93 @@ func Acquire(boskosClient *client.Client, resourceType string, timeout, heartbea
 			boskosResource,
 			heartbeatInterval,
 			heartbeatClose,
+			heartbeat,
 		)
 	}
 
 	return boskosResource, nil
 }
 
+// acquireWait waits up to timeout to acquire a resource of the given type
+func acquireWait(boskosClient Acquirer, resourceType, state string, timeout time.Duration) (*common.Resource, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return boskosClient.AcquireWait(ctx, resourceType, state, busyState)
+}
+
+// transientErrorRegex matches the errors of boskos requests that failed because
+// of the network or the server rather than the request. The client retries those
+// requests itself a few times and then returns the errors aggregated, e.g.
+//
+//	[status 503 Service Unavailable, status code 503, ...]
+var transientErrorRegex = regexp.MustCompile(`status code 5\d\d|connection reset|connection refused|unexpected EOF|i/o timeout`)
+
+// isTransientError reports whether an acquisition that failed with err may succeed when retried
+func isTransientError(err error) bool {
+	if err == nil || errors.Is(err, client.ErrNotFound) || errors.Is(err, client.ErrAlreadyInUse) ||
+		errors.Is(err, client.ErrTypeNotFound) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return transientErrorRegex.MatchString(err.Error())
+}
+
+// jitter returns wait plus up to half of it at random, so that the jobs that
+// failed during the same boskos restart do not retry all at once
+func jitter(wait time.Duration) time.Duration {
+	if wait <= 0 {
+		return 0
+	}
+	return wait + time.Duration(rand.Int63n(int64(wait)/2+1))
+}
+
+// AcquireReleaser is the subset of the boskos client needed to acquire
+// several resources and release them if not all could be acquired.
+type AcquireReleaser interface {
+	Acquirer
+	Releaser
+}
+
+// AcquireAll acquires a resource of each of the given types from the given
+// state and starts a heartbeat goroutine to keep them reserved. If any of the
+// acquisitions fails, the resources acquired so far are released to
+// releaseState before returning the error, so that they are not leaked.
+func AcquireAll(boskosClient AcquireReleaser, resourceTypes []string, state, releaseState string, timeout, heartbeatInterval time.Duration, heartbeatClose chan struct{}) ([]*common.Resource, error) {
+	var resources []*common.Resource
+	for _, resourceType := range resourceTypes {
+		resource, err := AcquireFromState(boskosClient, resourceType, state, timeout, heartbeatInterval, heartbeatClose)
+		if err != nil {
+			if len(resources) == 0 {
+				return nil, err
+			}
+			var names []string
+			for _, acquired := range resources {
+				names = append(names, acquired.Name)
+			}
+			klog.Warningf("[Boskos] acquired %d of %d resources, releasing %v", len(resources), len(resourceTypes), names)
+			if releaseErr := ReleaseWithRetry(boskosClient, names, releaseState, heartbeatClose, 1, 0); releaseErr != nil {
+				return nil, fmt.Errorf("%s, and failed to release the resources acquired so far: %s", err, releaseErr)
+			}
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
 // startBoskosHeartbeat starts a goroutine that sends periodic updates to boskos
 // about the provided resource until the channel is closed. This prevents
 // reaper from taking the resource from the deployer while it is still in use.
-func startBoskosHeartbeat(boskosClient *client.Client, resource *common.Resource, interval time.Duration, heartbeatClose chan struct{}) {
-	go func(c *client.Client, resource *common.Resource) {
+// The updates carry the user data configured by options, which also
+// configures how repeated update failures are reported.
+func startBoskosHeartbeat(boskosClient Acquirer, resource *common.Resource, interval time.Duration, heartbeatClose chan struct{}, options HeartbeatOptions) {
+	go func(c Acquirer, resource *common.Resource) {
 		klog.V(2).Info("boskos hearbeat starting")
 
+		failures := 0
 		for {
 			select {
 			case <-heartbeatClose:
@@ -84,20 +203,56 @@ func startBoskosHeartbeat(boskosClient *client.Client, resource *common.Resource
 				return
 			case <-time.NewTicker(interval).C:
 				klog.V(2).Info("Sending heartbeat to Boskos")
-				if err := c.UpdateOne(resource.Name, "busy", nil); err != nil {
+				err := c.UpdateOne(resource.Name, busyState, options.userData(time.Now()))
+				if err != nil {
 					klog.Warningf("[Boskos] Update of %s failed with %v", resource.Name, err)
 				}
+				failures = options.heartbeatFailed(resource.Name, failures, err)
 			}
 		}
 	}(boskosClient, resource)
 }
 
+// Releaser is the subset of the boskos client needed to release resources.
+type Releaser interface {
+	Release(name, dest string) error
+}
+
 // Release releases a resource.
-func Release(client *client.Client, resourceNames []string, heartbeatClose chan struct{}) error {
+func Release(client Releaser, resourceNames []string, heartbeatClose chan struct{}) error {
+	return ReleaseWithRetry(client, resourceNames, DefaultReleaseState, heartbeatClose, 1, 0)
+}
+
+// ReleaseWithRetry releases the resources to the given state, retrying each
+// failed release up to attempts times in total. The wait between attempts
+// starts at backoff and doubles after every failure. A resource that fails to
+// be released does not stop the others from being released, the errors of all
+// of them are returned. The heartbeat is only stopped once all of the
+// resources have been released.
+func ReleaseWithRetry(client Releaser, resourceNames []string, state string, heartbeatClose chan struct{}, attempts int, backoff time.Duration) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+	var errs []error
 	for _, name := range resourceNames {
-		if err := client.Release(name, "dirty"); err != nil {
-			return fmt.Errorf("failed to release %s: %s", name, err)
+		var err error
+		wait := backoff
+		for attempt := 1; attempt <= attempts; attempt++ {
+			if err = client.Release(name, state); err == nil {
+				break
+			}
+			if attempt < attempts {
+				klog.Warningf("[Boskos] release of %s failed (attempt %d/%d), retrying in %s: %v", name, attempt, attempts, wait, err)
+				time.Sleep(wait)
+				wait *= 2
+			}
 		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to release %s: %s", name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
 	}
 	close(heartbeatClose)
 	return nil
@@ -0,0 +1,356 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boskos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/boskos/client"
+	"sigs.k8s.io/boskos/common"
+)
+
+// fakeClient fails the first failures calls to Release and records
+// every acquire and release call. Acquiring fails with acquireErrs in
+// order, then once acquireLimit resources have been acquired, if set,
+// or always with noResources. The user data of each heartbeat is sent
+// to updates when it is being received, and each heartbeat fails with
+// the error of updateErr if set.
+type fakeClient struct {
+	failures        int
+	acquireLimit    int
+	noResources     bool
+	acquireErrs     []error
+	acquireAttempts int
+	acquired        []string
+	released        []string
+	attempts        int
+	updates         chan *common.UserData
+	updateErr       func() error
+}
+
+func (f *fakeClient) AcquireWait(ctx context.Context, rtype, state, dest string) (*common.Resource, error) {
+	f.acquireAttempts++
+	if f.acquireAttempts <= len(f.acquireErrs) {
+		return nil, f.acquireErrs[f.acquireAttempts-1]
+	}
+	if f.noResources || (f.acquireLimit > 0 && len(f.acquired) >= f.acquireLimit) {
+		return nil, fmt.Errorf("no %s available", rtype)
+	}
+	f.acquired = append(f.acquired, rtype+":"+state+"->"+dest)
+	name := "project"
+	if n := len(f.acquired); n > 1 {
+		name = fmt.Sprintf("project-%d", n)
+	}
+	return &common.Resource{Name: name, Type: rtype, State: dest}, nil
+}
+
+func (f *fakeClient) UpdateOne(name, state string, userData *common.UserData) error {
+	// the heartbeat is not held up by a test that is no longer receiving
+	select {
+	case f.updates <- userData:
+	default:
+	}
+	if f.updateErr != nil {
+		return f.updateErr()
+	}
+	return nil
+}
+
+func (f *fakeClient) Release(name, dest string) error {
+	f.attempts++
+	if f.attempts <= f.failures {
+		return fmt.Errorf("transient error releasing %s", name)
+	}
+	f.released = append(f.released, name+":"+dest)
+	return nil
+}
+
+func isClosed(c chan struct{}) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestReleaseWithRetry(t *testing.T) {
+	testCases := []struct {
+		name             string
+		names            []string
+		failures         int
+		attempts         int
+		expectErr        bool
+		expectedAttempts int
+		expectedReleased []string
+	}{
+		{
+			name:             "succeeds first time",
+			attempts:         3,
+			expectedAttempts: 1,
+			expectedReleased: []string{"project:dirty"},
+		},
+		{
+			name:             "fails twice then succeeds",
+			failures:         2,
+			attempts:         3,
+			expectedAttempts: 3,
+			expectedReleased: []string{"project:dirty"},
+		},
+		{
+			name:             "fails more than the attempt count",
+			failures:         2,
+			attempts:         2,
+			expectErr:        true,
+			expectedAttempts: 2,
+		},
+		{
+			name:             "non-positive attempts still tries once",
+			failures:         1,
+			attempts:         0,
+			expectErr:        true,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "a failed release does not stop the next one",
+			names:            []string{"project", "project-2"},
+			failures:         1,
+			attempts:         1,
+			expectErr:        true,
+			expectedAttempts: 2,
+			expectedReleased: []string{"project-2:dirty"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			client := &fakeClient{failures: tc.failures}
+			heartbeatClose := make(chan struct{})
+			names := tc.names
+			if names == nil {
+				names = []string{"project"}
+			}
+			err := ReleaseWithRetry(client, names, DefaultReleaseState, heartbeatClose, tc.attempts, 0)
+			if tc.expectErr && err == nil {
+				t.Errorf("expected an error but got none")
+			}
+			if !tc.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if client.attempts != tc.expectedAttempts {
+				t.Errorf("expected %d release attempts, but got %d", tc.expectedAttempts, client.attempts)
+			}
+			if !reflect.DeepEqual(client.released, tc.expectedReleased) {
+				t.Errorf("expected released resources %v, but got %v", tc.expectedReleased, client.released)
+			}
+			if closed := isClosed(heartbeatClose); closed == tc.expectErr {
+				t.Errorf("expected heartbeat closed to be %v, but got %v", !tc.expectErr, closed)
+			}
+		})
+	}
+}
+
+func TestStates(t *testing.T) {
+	testCases := []struct {
+		name             string
+		acquireState     string
+		releaseState     string
+		expectedAcquired []string
+		expectedReleased []string
+	}{
+		{
+			name:             "default states",
+			acquireState:     DefaultAcquireState,
+			releaseState:     DefaultReleaseState,
+			expectedAcquired: []string{"gce-project:free->busy"},
+			expectedReleased: []string{"project:dirty"},
+		},
+		{
+			name:             "custom states",
+			acquireState:     "cleaned",
+			releaseState:     "tainted",
+			expectedAcquired: []string{"gce-project:cleaned->busy"},
+			expectedReleased: []string{"project:tainted"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			client := &fakeClient{}
+			heartbeatClose := make(chan struct{})
+			resource, err := AcquireFromState(client, "gce-project", tc.acquireState, time.Minute, 0, heartbeatClose)
+			if err != nil {
+				t.Fatalf("unexpected error acquiring: %v", err)
+			}
+			if err := ReleaseWithRetry(client, []string{resource.Name}, tc.releaseState, heartbeatClose, 1, 0); err != nil {
+				t.Fatalf("unexpected error releasing: %v", err)
+			}
+			if !reflect.DeepEqual(client.acquired, tc.expectedAcquired) {
+				t.Errorf("expected acquire calls %v, but got %v", tc.expectedAcquired, client.acquired)
+			}
+			if !reflect.DeepEqual(client.released, tc.expectedReleased) {
+				t.Errorf("expected release calls %v, but got %v", tc.expectedReleased, client.released)
+			}
+		})
+	}
+}
+
+func TestAcquireAll(t *testing.T) {
+	testCases := []struct {
+		name              string
+		acquireLimit      int
+		noResources       bool
+		expectErr         bool
+		expectedResources []string
+		expectedReleased  []string
+	}{
+		{
+			name:              "all acquired",
+			expectedResources: []string{"project", "project-2", "project-3"},
+		},
+		{
+			name:             "acquired ones are released when one fails",
+			acquireLimit:     1,
+			expectErr:        true,
+			expectedReleased: []string{"project:dirty"},
+		},
+		{
+			name:        "nothing to release when the first fails",
+			noResources: true,
+			expectErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			client := &fakeClient{acquireLimit: tc.acquireLimit, noResources: tc.noResources}
+			heartbeatClose := make(chan struct{})
+			resources, err := AcquireAll(client, []string{"gke-project", "gke-project", "gce-project"}, DefaultAcquireState, DefaultReleaseState, time.Minute, 0, heartbeatClose)
+			if tc.expectErr && err == nil {
+				t.Errorf("expected an error but got none")
+			}
+			if !tc.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			var names []string
+			for _, resource := range resources {
+				names = append(names, resource.Name)
+			}
+			if !reflect.DeepEqual(names, tc.expectedResources) {
+				t.Errorf("expected resources %v, but got %v", tc.expectedResources, names)
+			}
+			if !reflect.DeepEqual(client.released, tc.expectedReleased) {
+				t.Errorf("expected released resources %v, but got %v", tc.expectedReleased, client.released)
+			}
+			if closed := isClosed(heartbeatClose); closed != (len(tc.expectedReleased) > 0) {
+				t.Errorf("expected the heartbeat to be stopped only if resources were released, but got closed=%v", closed)
+			}
+		})
+	}
+}
+
+func TestAcquireFromStateWithRetry(t *testing.T) {
+	serverError := errors.New("status 503 Service Unavailable, status code 503")
+	connectionReset := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+	testCases := []struct {
+		name             string
+		acquireErrs      []error
+		retries          int
+		expectedAttempts int
+		expectedErr      string
+	}{
+		{
+			name:             "server error retried",
+			acquireErrs:      []error{serverError},
+			retries:          3,
+			expectedAttempts: 2,
+		},
+		{
+			name:             "connection reset retried",
+			acquireErrs:      []error{connectionReset, serverError},
+			retries:          3,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "retries exhausted",
+			acquireErrs:      []error{serverError, serverError, serverError},
+			retries:          2,
+			expectedAttempts: 3,
+			expectedErr:      "status code 503",
+		},
+		{
+			name:             "no resources not retried",
+			acquireErrs:      []error{client.ErrNotFound},
+			retries:          3,
+			expectedAttempts: 1,
+			expectedErr:      client.ErrNotFound.Error(),
+		},
+		{
+			name:             "acquire timeout not retried",
+			acquireErrs:      []error{context.DeadlineExceeded},
+			retries:          3,
+			expectedAttempts: 1,
+			expectedErr:      context.DeadlineExceeded.Error(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			boskosClient := &fakeClient{acquireErrs: tc.acquireErrs}
+			resource, err := AcquireFromStateWithRetry(boskosClient, "gce-project", DefaultAcquireState, time.Minute, 0, make(chan struct{}), tc.retries, 0, HeartbeatOptions{})
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if resource.Name != "project" {
+					t.Errorf("expected to acquire project, but got %s", resource.Name)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+				t.Errorf("expected an error containing %q, but got %v", tc.expectedErr, err)
+			}
+			if boskosClient.acquireAttempts != tc.expectedAttempts {
+				t.Errorf("expected %d acquire attempts, but got %d", tc.expectedAttempts, boskosClient.acquireAttempts)
+			}
+		})
+	}
+}
+
+func TestJitter(t *testing.T) {
+	if actual := jitter(0); actual != 0 {
+		t.Errorf("expected no wait without a backoff, but got %s", actual)
+	}
+	for i := 0; i < 100; i++ {
+		if actual := jitter(time.Second); actual < time.Second || actual > 1500*time.Millisecond {
+			t.Fatalf("expected a wait between 1s and 1.5s, but got %s", actual)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boskos
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/boskos/client"
+)
+
+const (
+	// redactedValue replaces the value of sensitive headers in logs.
+	redactedValue = "<redacted>"
+	// headerProxyUser is the basic auth user the client authenticates to the
+	// header proxy with, its password is a random token.
+	headerProxyUser = "kubetest2"
+)
+
+// sensitiveHeaderWords are the words in a header name that mark its value as
+// a credential to keep out of the logs.
+var sensitiveHeaderWords = []string{"auth", "cookie", "key", "password", "secret", "token"}
+
+// ParseHeaders parses headers in the "Key: Value" format.
+func ParseHeaders(values []string) (http.Header, error) {
+	headers := http.Header{}
+	for _, value := range values {
+		key, val, ok := strings.Cut(value, ":")
+		key, val = strings.TrimSpace(key), strings.TrimSpace(val)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("header %q is not of the form Key: Value", value)
+		}
+		headers.Add(key, val)
+	}
+	return headers, nil
+}
+
+// RedactHeaders formats headers for logging, with the values of the headers
+// that may hold credentials redacted.
+func RedactHeaders(headers http.Header) string {
+	var keys []string
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var formatted []string
+	for _, key := range keys {
+		for _, value := range headers[key] {
+			if isSensitiveHeader(key) {
+				value = redactedValue
+			}
+			formatted = append(formatted, key+": "+value)
+		}
+	}
+	return strings.Join(formatted, ", ")
+}
+
+func isSensitiveHeader(key string) bool {
+	lower := strings.ToLower(key)
+	for _, word := range sensitiveHeaderWords {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// headerTransport sets headers on every request before sending it with base.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers http.Header
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for key, values := range t.headers {
+		req.Header[key] = values
+	}
+	return t.base.RoundTrip(req)
+}
+
+// NewClientWithHeaders creates a boskos client for kubetest2 deployers that
+// sends headers with every request, e.g. for a boskos behind an auth proxy.
+// The boskos client does not expose its transport, so the requests are sent
+// through a local reverse proxy that adds the headers. The proxy only serves
+// the client, which authenticates with a random token. The returned function
+// stops the proxy, it is meant to be called once the resources are released.
+func NewClientWithHeaders(boskosLocation string, headers http.Header) (*client.Client, func(), error) {
+	if len(headers) == 0 {
+		boskos, err := NewClient(boskosLocation)
+		return boskos, func() {}, err
+	}
+	proxy, err := startHeaderProxy(boskosLocation, headers)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create boskos client: %s", err)
+	}
+	boskos, err := client.NewClientWithPasswordGetter(boskosOwner, proxy.location, headerProxyUser, func() []byte {
+		return []byte(proxy.token)
+	})
+	if err != nil {
+		proxy.stop()
+		return nil, nil, fmt.Errorf("failed to create boskos client: %s", err)
+	}
+	klog.V(1).Infof("[Boskos] sending headers %s to %s", RedactHeaders(headers), boskosLocation)
+	return boskos, proxy.stop, nil
+}
+
+// headerProxy is a local reverse proxy to boskos adding headers to the requests.
+type headerProxy struct {
+	// location is the url of the proxy
+	location string
+	// token is the basic auth password of headerProxyUser the requests to
+	// the proxy must have
+	token  string
+	server *http.Server
+}
+
+// stop closes the proxy and its connections.
+func (p *headerProxy) stop() {
+	if err := p.server.Close(); err != nil {
+		klog.Warningf("[Boskos] failed to stop the header proxy: %s", err)
+	}
+}
+
+// startHeaderProxy serves a reverse proxy to boskosLocation on a local port
+// that adds headers to the proxied requests. Requests without the basic auth
+// credentials of the proxy are rejected, so the headers are not added for
+// other local processes.
+func startHeaderProxy(boskosLocation string, headers http.Header) (*headerProxy, error) {
+	target, err := url.Parse(boskosLocation)
+	if err != nil {
+		return nil, fmt.Errorf("invalid boskos location %q: %s", boskosLocation, err)
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate the boskos header proxy token: %s", err)
+	}
+	token := hex.EncodeToString(secret)
+
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	proxy.Transport = &headerTransport{base: http.DefaultTransport, headers: headers}
+	director := proxy.Director
+	proxy.Director = func(req *http.Request) {
+		director(req)
+		// the proxy may need the target host to route the request
+		req.Host = target.Host
+		// the credentials of the proxy are not for boskos
+		req.Header.Del("Authorization")
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		user, password, ok := req.BasicAuth()
+		if !ok || user != headerProxyUser || subtle.ConstantTimeCompare([]byte(password), []byte(token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		proxy.ServeHTTP(w, req)
+	})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for the boskos header proxy: %s", err)
+	}
+	server := &http.Server{Handler: handler}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			klog.Errorf("[Boskos] header proxy stopped: %s", err)
+		}
+	}()
+	return &headerProxy{
+		location: "http://" + listener.Addr().String(),
+		token:    token,
+		server:   server,
+	}, nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boskos
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParseHeaders(t *testing.T) {
+	testCases := []struct {
+		name      string
+		values    []string
+		expected  http.Header
+		expectErr bool
+	}{
+		{
+			name:     "headers",
+			values:   []string{"Authorization: Bearer abc:def", "x-proxy-team:  node ", "X-Proxy-Team: infra"},
+			expected: http.Header{"Authorization": {"Bearer abc:def"}, "X-Proxy-Team": {"node", "infra"}},
+		},
+		{
+			name:     "empty value",
+			values:   []string{"X-Empty:"},
+			expected: http.Header{"X-Empty": {""}},
+		},
+		{
+			name:      "missing colon",
+			values:    []string{"Authorization Bearer abc"},
+			expectErr: true,
+		},
+		{
+			name:      "missing key",
+			values:    []string{": abc"},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			headers, err := ParseHeaders(tc.values)
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error for %q but got none", tc.values)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(headers, tc.expected) {
+				t.Errorf("expected headers %v, but got %v", tc.expected, headers)
+			}
+		})
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	headers := http.Header{
+		"Authorization": {"Bearer abc"},
+		"X-Api-Key":     {"secret"},
+		"X-Proxy-Team":  {"node"},
+	}
+	expected := "Authorization: <redacted>, X-Api-Key: <redacted>, X-Proxy-Team: node"
+	if actual := RedactHeaders(headers); actual != expected {
+		t.Errorf("expected %q, but got %q", expected, actual)
+	}
+}
+
+func TestHeaderProxy(t *testing.T) {
+	requests := make(chan *http.Request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	headers := http.Header{"Authorization": {"Bearer abc"}, "X-Proxy-Team": {"node"}}
+	proxy, err := startHeaderProxy(server.URL, headers)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer proxy.stop()
+	values := url.Values{"type": {"gce-project"}, "state": {"free"}, "dest": {"busy"}, "owner": {"job"}}
+	post := func(user, password string) (int, error) {
+		req, err := http.NewRequest(http.MethodPost, proxy.location+"/acquire?"+values.Encode(), nil)
+		if err != nil {
+			return 0, err
+		}
+		if user != "" {
+			req.SetBasicAuth(user, password)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	for _, credentials := range [][2]string{{"", ""}, {headerProxyUser, "wrong"}} {
+		code, err := post(credentials[0], credentials[1])
+		if err != nil {
+			t.Fatalf("failed to send the request: %v", err)
+		}
+		if code != http.StatusUnauthorized {
+			t.Errorf("expected a request with credentials %q to be rejected, but got status %d", credentials, code)
+		}
+	}
+	select {
+	case r := <-requests:
+		t.Fatalf("expected unauthenticated requests not to reach the boskos server, but got %s", r.URL)
+	default:
+	}
+
+	if _, err := post(headerProxyUser, proxy.token); err != nil {
+		t.Fatalf("failed to send the request: %v", err)
+	}
+
+	var received *http.Request
+	select {
+	case received = <-requests:
+	default:
+		t.Fatal("expected the request to reach the boskos server")
+	}
+	if received.URL.Path != "/acquire" || !reflect.DeepEqual(received.URL.Query(), values) {
+		t.Errorf("expected the request to be forwarded to /acquire?%s, but got %s", values.Encode(), received.URL)
+	}
+	for key := range headers {
+		if actual := received.Header.Get(key); actual != headers.Get(key) {
+			t.Errorf("expected header %s: %q, but got %q", key, headers.Get(key), actual)
+		}
+	}
+
+	proxy.stop()
+	if _, err := post(headerProxyUser, proxy.token); err == nil {
+		t.Error("expected the stopped proxy to refuse requests")
+	}
+}
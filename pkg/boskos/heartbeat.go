@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boskos
+
+import (
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/boskos/common"
+)
+
+const (
+	// OwnerUserDataKey is the user data key the owner is re-asserted under on each heartbeat.
+	OwnerUserDataKey = "kubetest2-owner"
+	// LeaseExpiryUserDataKey is the user data key the lease expiry, in RFC 3339
+	// format, is extended under on each heartbeat.
+	LeaseExpiryUserDataKey = "kubetest2-lease-expiry"
+)
+
+// HeartbeatOptions configures what each heartbeat sends to boskos besides
+// keeping the resource busy, for boskos deployments that expire owners.
+type HeartbeatOptions struct {
+	// RefreshOwner re-asserts the owner of the client on each heartbeat.
+	RefreshOwner bool
+	// LeaseExtension, if set, extends the lease expiry to this long after each heartbeat.
+	LeaseExtension time.Duration
+	// MaxFailures, if set, is how many heartbeats in a row may fail before
+	// the failure is reported on Failed. The heartbeat keeps going after it.
+	MaxFailures int
+	// Failed receives the error of the heartbeat that reached MaxFailures
+	// failures in a row. The send does not block, so it should be buffered.
+	Failed chan<- error
+}
+
+// heartbeatFailed records the outcome err of a heartbeat of resource after
+// failures heartbeats in a row failed, reporting on Failed once MaxFailures is
+// reached. It returns the number of heartbeats in a row that have failed.
+func (o HeartbeatOptions) heartbeatFailed(resource string, failures int, err error) int {
+	if err == nil {
+		return 0
+	}
+	failures++
+	if o.MaxFailures > 0 && failures == o.MaxFailures && o.Failed != nil {
+		select {
+		case o.Failed <- fmt.Errorf("%d heartbeats in a row for %s failed, the last with: %w", failures, resource, err):
+		default:
+		}
+	}
+	return failures
+}
+
+// userData returns the user data of a heartbeat sent at now, nil if the
+// heartbeat only keeps the resource busy
+func (o HeartbeatOptions) userData(now time.Time) *common.UserData {
+	data := common.UserDataMap{}
+	if o.RefreshOwner {
+		data[OwnerUserDataKey] = boskosOwner
+	}
+	if o.LeaseExtension > 0 {
+		data[LeaseExpiryUserDataKey] = now.Add(o.LeaseExtension).UTC().Format(time.RFC3339)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return common.UserDataFromMap(data)
+}
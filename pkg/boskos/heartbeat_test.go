@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boskos
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/boskos/common"
+)
+
+func TestHeartbeatUserData(t *testing.T) {
+	testCases := []struct {
+		name             string
+		options          HeartbeatOptions
+		expectOwner      bool
+		expectedDuration time.Duration
+	}{
+		{
+			name: "plain heartbeat",
+		},
+		{
+			name:        "owner refreshed",
+			options:     HeartbeatOptions{RefreshOwner: true},
+			expectOwner: true,
+		},
+		{
+			name:             "lease extended",
+			options:          HeartbeatOptions{LeaseExtension: 30 * time.Minute},
+			expectedDuration: 30 * time.Minute,
+		},
+		{
+			name:             "owner refreshed and lease extended",
+			options:          HeartbeatOptions{RefreshOwner: true, LeaseExtension: time.Hour},
+			expectOwner:      true,
+			expectedDuration: time.Hour,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			client := &fakeClient{updates: make(chan *common.UserData)}
+			heartbeatClose := make(chan struct{})
+			defer close(heartbeatClose)
+			before := time.Now().UTC().Truncate(time.Second)
+			startBoskosHeartbeat(client, &common.Resource{Name: "project"}, time.Millisecond, heartbeatClose, tc.options)
+
+			userData := (<-client.updates).ToMap()
+			after := time.Now().UTC()
+			if !tc.expectOwner && tc.expectedDuration == 0 {
+				if userData != nil {
+					t.Errorf("expected the heartbeat to carry no user data, but got %v", userData)
+				}
+				return
+			}
+			owner, ok := userData[OwnerUserDataKey]
+			if ok != tc.expectOwner || (ok && owner != boskosOwner) {
+				t.Errorf("expected owner %q to be re-asserted=%v, but got user data %v", boskosOwner, tc.expectOwner, userData)
+			}
+			expiry, ok := userData[LeaseExpiryUserDataKey]
+			if ok != (tc.expectedDuration > 0) {
+				t.Fatalf("expected the lease to be extended=%v, but got user data %v", tc.expectedDuration > 0, userData)
+			}
+			if ok {
+				extended, err := time.Parse(time.RFC3339, expiry)
+				if err != nil {
+					t.Fatalf("failed to parse the lease expiry %q: %v", expiry, err)
+				}
+				if extended.Before(before.Add(tc.expectedDuration)) || extended.After(after.Add(tc.expectedDuration)) {
+					t.Errorf("expected the lease to be extended by %s from %s, but got %s", tc.expectedDuration, before, extended)
+				}
+			}
+		})
+	}
+}
+
+func TestHeartbeatUserDataKeys(t *testing.T) {
+	userData := HeartbeatOptions{RefreshOwner: true, LeaseExtension: time.Minute}.userData(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)).ToMap()
+	expected := common.UserDataMap{
+		OwnerUserDataKey:       boskosOwner,
+		LeaseExpiryUserDataKey: "2026-01-02T03:05:05Z",
+	}
+	if !reflect.DeepEqual(userData, expected) {
+		t.Errorf("expected user data %v, but got %v", expected, userData)
+	}
+}
+
+func TestHeartbeatFailures(t *testing.T) {
+	// the heartbeats fail twice, recover, then keep failing
+	outcomes := []bool{false, false, true, false, false, false}
+	var calls int32
+	client := &fakeClient{updateErr: func() error {
+		n := int(atomic.AddInt32(&calls, 1))
+		if n <= len(outcomes) && outcomes[n-1] {
+			return nil
+		}
+		return errors.New("boskos unavailable")
+	}}
+	failed := make(chan error, 1)
+	heartbeatClose := make(chan struct{})
+	defer close(heartbeatClose)
+	startBoskosHeartbeat(client, &common.Resource{Name: "project"}, time.Millisecond, heartbeatClose, HeartbeatOptions{MaxFailures: 3, Failed: failed})
+
+	select {
+	case err := <-failed:
+		if n := atomic.LoadInt32(&calls); n < int32(len(outcomes)) {
+			t.Errorf("expected the failure to be reported after %d heartbeats, but it was after %d", len(outcomes), n)
+		}
+		if !strings.Contains(err.Error(), "3 heartbeats in a row for project failed") || !strings.Contains(err.Error(), "boskos unavailable") {
+			t.Errorf("unexpected heartbeat failure: %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected the heartbeat failures to be reported")
+	}
+}
+
+func TestHeartbeatFailed(t *testing.T) {
+	failed := make(chan error, 1)
+	options := HeartbeatOptions{MaxFailures: 2, Failed: failed}
+	failures := 0
+	for _, err := range []error{errors.New("a"), nil, errors.New("b"), errors.New("c"), errors.New("d")} {
+		failures = options.heartbeatFailed("project", failures, err)
+	}
+	if failures != 3 {
+		t.Errorf("expected 3 heartbeats in a row to have failed, but got %d", failures)
+	}
+	select {
+	case err := <-failed:
+		if !strings.Contains(err.Error(), "the last with: c") {
+			t.Errorf("expected the failure of the second heartbeat in a row to be reported, but got %v", err)
+		}
+	default:
+		t.Fatal("expected the heartbeat failures to be reported")
+	}
+	select {
+	case err := <-failed:
+		t.Errorf("expected the failures to be reported once, but got %v", err)
+	default:
+	}
+
+	// without a limit nothing is reported
+	HeartbeatOptions{Failed: failed}.heartbeatFailed("project", 10, errors.New("e"))
+	if len(failed) != 0 {
+		t.Errorf("expected no report without MaxFailures")
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boskos
+
+import (
+	"net"
+	"strings"
+)
+
+// DefaultLocation is the in-cluster address of the boskos service of prow.
+const DefaultLocation = "http://boskos.test-pods.svc.cluster.local."
+
+const (
+	// hostEnv overrides the boskos location when the location was left at
+	// its default, as a host, host:port or URL.
+	hostEnv = "BOSKOS_HOST"
+	// serviceHostEnv and servicePortEnv are set by Kubernetes in the pods of
+	// the namespace of a service named boskos.
+	serviceHostEnv = "BOSKOS_SERVICE_HOST"
+	servicePortEnv = "BOSKOS_SERVICE_PORT"
+)
+
+// DiscoverLocation returns the boskos location to use. A location other than
+// DefaultLocation was set explicitly and is returned as is. Otherwise the
+// location is discovered from the BOSKOS_HOST environment variable, then from
+// the service environment variables Kubernetes sets for a boskos service in
+// the namespace of the pod, falling back to DefaultLocation.
+func DiscoverLocation(location string, getenv func(string) string) string {
+	if location != DefaultLocation {
+		return location
+	}
+	if host := strings.TrimSpace(getenv(hostEnv)); host != "" {
+		if !strings.Contains(host, "://") {
+			host = "http://" + host
+		}
+		return host
+	}
+	if host := getenv(serviceHostEnv); host != "" {
+		if port := getenv(servicePortEnv); port != "" {
+			return "http://" + net.JoinHostPort(host, port)
+		}
+		return "http://" + host
+	}
+	return DefaultLocation
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boskos
+
+import "testing"
+
+func TestDiscoverLocation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		location string
+		env      map[string]string
+		expected string
+	}{
+		{
+			name:     "default",
+			location: DefaultLocation,
+			expected: DefaultLocation,
+		},
+		{
+			name:     "explicit location wins",
+			location: "http://boskos.example.com",
+			env:      map[string]string{"BOSKOS_HOST": "boskos.other", "BOSKOS_SERVICE_HOST": "10.0.0.1"},
+			expected: "http://boskos.example.com",
+		},
+		{
+			name:     "BOSKOS_HOST wins over the service",
+			location: DefaultLocation,
+			env:      map[string]string{"BOSKOS_HOST": "boskos.ci.svc.cluster.local", "BOSKOS_SERVICE_HOST": "10.0.0.1", "BOSKOS_SERVICE_PORT": "80"},
+			expected: "http://boskos.ci.svc.cluster.local",
+		},
+		{
+			name:     "BOSKOS_HOST as a URL",
+			location: DefaultLocation,
+			env:      map[string]string{"BOSKOS_HOST": "https://boskos.example.com:8443"},
+			expected: "https://boskos.example.com:8443",
+		},
+		{
+			name:     "service in the namespace of the pod",
+			location: DefaultLocation,
+			env:      map[string]string{"BOSKOS_SERVICE_HOST": "10.0.0.1", "BOSKOS_SERVICE_PORT": "80"},
+			expected: "http://10.0.0.1:80",
+		},
+		{
+			name:     "service with an IPv6 address",
+			location: DefaultLocation,
+			env:      map[string]string{"BOSKOS_SERVICE_HOST": "fd00::1", "BOSKOS_SERVICE_PORT": "80"},
+			expected: "http://[fd00::1]:80",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			getenv := func(key string) string { return tc.env[key] }
+			if actual := DiscoverLocation(tc.location, getenv); actual != tc.expected {
+				t.Errorf("expected location %q, but got %q", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"io"
 	osexec "os/exec"
 	"strings"
+	"syscall"
+	"time"
 
 	"k8s.io/klog/v2"
 )
@@ -86,3 +88,19 @@ func (cmd *LocalCmd) SetDir(dir string) Cmd {
 func (cmd *LocalCmd) Run() error {
 	return cmd.Cmd.Run()
 }
+
+// SetCancelGracePeriod makes a command created with CommandContext receive
+// SIGTERM instead of being killed outright when its context is done. The command
+// is only killed if it has not exited within gracePeriod, giving it a chance to
+// clean up. A non-positive gracePeriod keeps the default behavior, and commands
+// not backed by a local process are left unchanged.
+func SetCancelGracePeriod(cmd Cmd, gracePeriod time.Duration) {
+	local, ok := cmd.(*LocalCmd)
+	if !ok || gracePeriod <= 0 {
+		return
+	}
+	local.Cancel = func() error {
+		return local.Process.Signal(syscall.SIGTERM)
+	}
+	local.WaitDelay = gracePeriod
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSetCancelGracePeriod(t *testing.T) {
+	testCases := []struct {
+		name            string
+		script          string
+		gracePeriod     time.Duration
+		expectCleanedUp bool
+	}{
+		{
+			name:            "cleans up within the grace period",
+			script:          `trap 'touch "$MARKER"; exit 0' TERM; sleep 10 & wait`,
+			gracePeriod:     5 * time.Second,
+			expectCleanedUp: true,
+		},
+		{
+			name:        "killed after exceeding the grace period",
+			script:      `trap '' TERM; exec sleep 10`,
+			gracePeriod: 200 * time.Millisecond,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			marker := filepath.Join(t.TempDir(), "cleaned-up")
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			cmd := CommandContext(ctx, "sh", "-c", tc.script)
+			cmd.SetEnv("MARKER=" + marker)
+			SetCancelGracePeriod(cmd, tc.gracePeriod)
+
+			done := make(chan error, 1)
+			go func() {
+				done <- cmd.Run()
+			}()
+			// give the shell time to install its trap
+			time.Sleep(200 * time.Millisecond)
+			cancel()
+
+			select {
+			case err := <-done:
+				if err == nil {
+					t.Errorf("expected an error from the cancelled command")
+				}
+			case <-time.After(tc.gracePeriod + 5*time.Second):
+				t.Fatalf("command did not exit after the grace period")
+			}
+			_, err := os.Stat(marker)
+			if cleanedUp := err == nil; cleanedUp != tc.expectCleanedUp {
+				t.Errorf("expected cleaned up to be %v, but got %v", tc.expectCleanedUp, cleanedUp)
+			}
+		})
+	}
+}
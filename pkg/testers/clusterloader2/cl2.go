@@ -24,7 +24,6 @@ import (
 	"strings"
 
 	"github.com/kballard/go-shellquote"
-	"github.com/octago/sflags/gen/gpflag"
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/kubetest2/pkg/exec"
@@ -121,7 +120,7 @@ func (t *Tester) Test() error {
 }
 
 func (t *Tester) Execute() error {
-	fs, err := gpflag.Parse(t)
+	fs, err := testers.ParseFlags(t)
 	if err != nil {
 		return fmt.Errorf("failed to initialize tester: %v", err)
 	}
@@ -21,7 +21,6 @@ import (
 	"os"
 	"strings"
 
-	"github.com/octago/sflags/gen/gpflag"
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/kubetest2/pkg/process"
@@ -40,7 +39,7 @@ const usage = `kubetest2 --test=exec --  [TestCommand] [TestArgs]
 `
 
 func (t *Tester) Execute() error {
-	fs, err := gpflag.Parse(t)
+	fs, err := testers.ParseFlags(t)
 	if err != nil {
 		return fmt.Errorf("failed to initialize tester: %v", err)
 	}
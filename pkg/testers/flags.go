@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testers
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/octago/sflags"
+	"github.com/octago/sflags/gen/gpflag"
+	"github.com/spf13/pflag"
+)
+
+// ParseFlags generates the flags for the exported fields of the tester struct
+// pointed to by t, like gpflag.Parse. Unlike gpflag.Parse, which silently skips
+// fields of unsupported types and panics on duplicate flag names, it returns an
+// error naming the offending field.
+func ParseFlags(t interface{}) (*pflag.FlagSet, error) {
+	if err := checkFlagFields(t); err != nil {
+		return nil, err
+	}
+	return gpflag.Parse(t)
+}
+
+// checkFlagFields checks that each exported field of the struct pointed to by
+// t generates flags and that no two fields generate the same flag
+func checkFlagFields(t interface{}) error {
+	v := reflect.ValueOf(t)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("flags must be parsed from a pointer to a struct, got %T", t)
+	}
+	structType := v.Elem().Type()
+
+	flagFields := map[string]string{}
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() || field.Tag.Get("flag") == "-" {
+			continue
+		}
+		// parse the field on its own to know which flags it generates
+		single := reflect.StructOf([]reflect.StructField{{
+			Name:      field.Name,
+			Type:      field.Type,
+			Tag:       field.Tag,
+			Anonymous: field.Anonymous,
+		}})
+		flags, err := sflags.ParseStruct(reflect.New(single).Interface())
+		if err != nil {
+			return fmt.Errorf("failed to parse flags for field %s: %w", field.Name, err)
+		}
+		if len(flags) == 0 {
+			return fmt.Errorf("field %s of type %s (tag %q) does not generate a flag, use a supported type or tag it with flag:\"-\"", field.Name, field.Type, field.Tag)
+		}
+		for _, flag := range flags {
+			if other, exists := flagFields[flag.Name]; exists {
+				return fmt.Errorf("fields %s and %s both generate the flag --%s", other, field.Name, flag.Name)
+			}
+			flagFields[flag.Name] = field.Name
+		}
+	}
+	return nil
+}
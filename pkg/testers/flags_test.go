@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testers
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type validTester struct {
+	Focus    string        `desc:"focus"`
+	Parallel int           `desc:"parallel"`
+	Timeout  time.Duration `desc:"timeout"`
+	Args     []string      `desc:"args"`
+	Ignored  chan struct{} `flag:"-"`
+	internal chan struct{}
+}
+
+type unsupportedFieldTester struct {
+	Focus   string        `desc:"focus"`
+	Results chan struct{} `desc:"results"`
+}
+
+type duplicateFlagTester struct {
+	Focus      string `desc:"focus"`
+	FocusRegex string `flag:"focus" desc:"focus regex"`
+}
+
+func TestParseFlags(t *testing.T) {
+	testCases := []struct {
+		name          string
+		tester        interface{}
+		expectedFlags []string
+		expectedError []string
+	}{
+		{
+			name:          "valid fields",
+			tester:        &validTester{},
+			expectedFlags: []string{"focus", "parallel", "timeout", "args"},
+		},
+		{
+			name:          "unsupported field type",
+			tester:        &unsupportedFieldTester{},
+			expectedError: []string{"Results", "chan struct {}"},
+		},
+		{
+			name:          "duplicate flag name",
+			tester:        &duplicateFlagTester{},
+			expectedError: []string{"Focus", "FocusRegex", "--focus"},
+		},
+		{
+			name:          "not a pointer",
+			tester:        validTester{},
+			expectedError: []string{"pointer to a struct"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			fs, err := ParseFlags(tc.tester)
+			if len(tc.expectedError) > 0 {
+				if err == nil {
+					t.Fatalf("expected an error but got none")
+				}
+				for _, expected := range tc.expectedError {
+					if !strings.Contains(err.Error(), expected) {
+						t.Errorf("expected the error to mention %q, but got %v", expected, err)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, name := range tc.expectedFlags {
+				if fs.Lookup(name) == nil {
+					t.Errorf("expected flag --%s to be generated", name)
+				}
+			}
+		})
+	}
+}
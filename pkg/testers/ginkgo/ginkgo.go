@@ -26,7 +26,6 @@ import (
 	"time"
 
 	"github.com/kballard/go-shellquote"
-	"github.com/octago/sflags/gen/gpflag"
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/kubetest2/pkg/artifacts"
@@ -186,7 +185,7 @@ func (t *Tester) validateBinariesFromPath() error {
 }
 
 func (t *Tester) Execute() error {
-	fs, err := gpflag.Parse(t)
+	fs, err := testers.ParseFlags(t)
 	if err != nil {
 		return fmt.Errorf("failed to initialize tester: %v", err)
 	}
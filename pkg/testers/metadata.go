@@ -25,6 +25,12 @@ import (
 )
 
 func WriteVersionToMetadata(version string) error {
+	return WriteToMetadata("tester-version", version)
+}
+
+// WriteToMetadata adds the key and value to the metadata.json in the artifacts directory,
+// creating the file if it does not exist yet
+func WriteToMetadata(key, value string) error {
 	var meta *metadata.CustomJSON
 	// check existing metadata and initialize it if it exists
 	metadataPath := filepath.Join(artifacts.BaseDir(), "metadata.json")
@@ -51,7 +57,7 @@ func WriteVersionToMetadata(version string) error {
 		}
 	}
 
-	if err := meta.Add("tester-version", version); err != nil {
+	if err := meta.Add(key, value); err != nil {
 		return err
 	}
 
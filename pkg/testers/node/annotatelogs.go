@@ -0,0 +1,306 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// ginkgoTimestampLayout is the layout of the timestamps ginkgo writes in
+	// the junit failures and timelines, e.g. "@ 01/02/26 03:04:05.678"
+	ginkgoTimestampLayout = "01/02/06 15:04:05.000"
+	// klogTimestampLayout is the layout of the time in a klog header, without the year
+	klogTimestampLayout = "0102 15:04:05.000000"
+	// maxSnippetLines caps the log lines embedded for each node and failure
+	maxSnippetLines = 200
+)
+
+var ginkgoTimestampRegex = regexp.MustCompile(`@ (\d{2}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}\.\d{3})`)
+
+// nodeLogLine is a timestamped line from the kubelet log of a test node
+type nodeLogLine struct {
+	time time.Time
+	text string
+}
+
+// nodeLog is the timestamped kubelet log of a test node
+type nodeLog struct {
+	host  string
+	lines []nodeLogLine
+}
+
+// readNodeLog reads the klog lines of the kubelet log at path, the klog
+// headers have no year so year is used
+func readNodeLog(path, host string, year int) (nodeLog, error) {
+	log := nodeLog{host: host}
+	f, err := os.Open(path)
+	if err != nil {
+		return log, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		match := klogHeaderRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		ts, err := time.Parse(klogTimestampLayout, match[1])
+		if err != nil {
+			continue
+		}
+		log.lines = append(log.lines, nodeLogLine{time: ts.AddDate(year-ts.Year(), 0, 0), text: line})
+	}
+	if err := scanner.Err(); err != nil {
+		return log, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return log, nil
+}
+
+// failureWindow returns the time window of a failed testcase from the ginkgo
+// timestamps in its text, widened by padding on both sides
+func failureWindow(text string, padding time.Duration) (time.Time, time.Time, bool) {
+	var start, end time.Time
+	for _, match := range ginkgoTimestampRegex.FindAllStringSubmatch(text, -1) {
+		ts, err := time.Parse(ginkgoTimestampLayout, match[1])
+		if err != nil {
+			continue
+		}
+		if start.IsZero() || ts.Before(start) {
+			start = ts
+		}
+		if ts.After(end) {
+			end = ts
+		}
+	}
+	if start.IsZero() {
+		return start, end, false
+	}
+	return start.Add(-padding), end.Add(padding), true
+}
+
+// logSnippet returns the lines of the logs between start and end, by node
+func logSnippet(logs []nodeLog, start, end time.Time) string {
+	var b strings.Builder
+	for _, log := range logs {
+		var lines []string
+		for _, line := range log.lines {
+			if !line.time.Before(start) && !line.time.After(end) {
+				lines = append(lines, line.text)
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		truncated := ""
+		if len(lines) > maxSnippetLines {
+			truncated = fmt.Sprintf(", last %d of %d lines", maxSnippetLines, len(lines))
+			lines = lines[len(lines)-maxSnippetLines:]
+		}
+		fmt.Fprintf(&b, "\n--- kubelet log of %s from %s to %s%s ---\n", log.host, start.Format(ginkgoTimestampLayout), end.Format(ginkgoTimestampLayout), truncated)
+		b.WriteString(strings.Join(lines, "\n"))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// logsForJUnit returns the logs of the node a junit file ran on, the junit
+// files are named after their node. If the node cannot be told all logs are returned.
+func logsForJUnit(junitName string, logs []nodeLog) []nodeLog {
+	for _, log := range logs {
+		if strings.Contains(junitName, log.host) {
+			return []nodeLog{log}
+		}
+	}
+	return logs
+}
+
+// annotateJUnit embeds the node log snippet of each failed testcase of the junit
+// document read from r into the testcase system-out, writing the result to w.
+// It returns how many testcases were annotated.
+func annotateJUnit(r io.Reader, w io.Writer, logs []nodeLog, padding time.Duration) (int, error) {
+	decoder := xml.NewDecoder(r)
+	encoder := xml.NewEncoder(w)
+	annotated := 0
+
+	// the tokens of a testcase are buffered until its end, when it is known
+	// whether it failed and where to add the snippet
+	var testcase []xml.Token
+	var text strings.Builder
+	failed, hasSystemOut := false, false
+	depth := 0
+
+	emit := func(tokens ...xml.Token) error {
+		for _, token := range tokens {
+			if err := encoder.EncodeToken(token); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		token = xml.CopyToken(token)
+
+		if testcase == nil {
+			if start, ok := token.(xml.StartElement); ok && start.Name.Local == "testcase" {
+				testcase = []xml.Token{token}
+				text.Reset()
+				failed, hasSystemOut = false, false
+				depth = 1
+				continue
+			}
+			if err := emit(token); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		switch tok := token.(type) {
+		case xml.StartElement:
+			depth++
+			switch tok.Name.Local {
+			case "failure", "error":
+				failed = true
+			case "system-out":
+				hasSystemOut = true
+			}
+		case xml.CharData:
+			text.Write(tok)
+		case xml.EndElement:
+			depth--
+		}
+		testcase = append(testcase, token)
+		if depth > 0 {
+			continue
+		}
+
+		// the end of the testcase
+		snippet := ""
+		if failed {
+			if start, end, ok := failureWindow(text.String(), padding); ok {
+				snippet = logSnippet(logs, start, end)
+			}
+		}
+		if snippet == "" {
+			if err := emit(testcase...); err != nil {
+				return 0, err
+			}
+			testcase = nil
+			continue
+		}
+		annotated++
+		closing := testcase[len(testcase)-1]
+		for _, t := range testcase[:len(testcase)-1] {
+			if end, ok := t.(xml.EndElement); ok && end.Name.Local == "system-out" {
+				if err := emit(xml.CharData(snippet)); err != nil {
+					return 0, err
+				}
+			}
+			if err := emit(t); err != nil {
+				return 0, err
+			}
+		}
+		if !hasSystemOut {
+			systemOut := xml.StartElement{Name: xml.Name{Local: "system-out"}}
+			if err := emit(systemOut, xml.CharData(snippet), systemOut.End()); err != nil {
+				return 0, err
+			}
+		}
+		if err := emit(closing); err != nil {
+			return 0, err
+		}
+		testcase = nil
+	}
+	if err := encoder.Flush(); err != nil {
+		return 0, err
+	}
+	return annotated, nil
+}
+
+// annotateFailuresWithLogs embeds the kubelet log window of each failed
+// testcase of the run in artifactsDir into its junit system-out
+func (t *Tester) annotateFailuresWithLogs(artifactsDir string) {
+	logFiles, err := findResultFiles(artifactsDir, func(name string) bool { return name == kubeletLogName })
+	if err != nil {
+		klog.Warningf("failed to find node logs: %v", err)
+		return
+	}
+	if len(logFiles) == 0 {
+		klog.V(1).Info("no node logs to annotate the failures with")
+		return
+	}
+	sort.Strings(logFiles)
+	year := t.clock.Now().Year()
+	var logs []nodeLog
+	for _, file := range logFiles {
+		log, err := readNodeLog(file, filepath.Base(filepath.Dir(file)), year)
+		if err != nil {
+			klog.Warningf("failed to read node log: %v", err)
+			continue
+		}
+		logs = append(logs, log)
+	}
+
+	junitFiles, err := findResultFiles(artifactsDir, isJUnitResultsFile)
+	if err != nil {
+		klog.Warningf("failed to find junit files: %v", err)
+		return
+	}
+	for _, file := range junitFiles {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			klog.Warningf("failed to read %s: %v", file, err)
+			continue
+		}
+		var out bytes.Buffer
+		annotated, err := annotateJUnit(bytes.NewReader(data), &out, logsForJUnit(filepath.Base(file), logs), t.FailureLogWindow)
+		if err != nil {
+			klog.Warningf("failed to annotate %s: %v", file, err)
+			continue
+		}
+		if annotated == 0 {
+			continue
+		}
+		if err := os.WriteFile(file, out.Bytes(), 0644); err != nil {
+			klog.Warningf("failed to write %s: %v", file, err)
+			continue
+		}
+		klog.V(1).Infof("annotated %d failed testcases in %s with node logs", annotated, file)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const annotateJUnitSample = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" failures="2">
+  <testsuite name="E2eNode Suite" tests="3" failures="2">
+    <testcase name="[It] passing" time="1"></testcase>
+    <testcase name="[It] failing early" time="2">
+      <failure message="timed out" type="failed">[FAILED] timed out&#xA;In [It] at: pods.go:42 @ 01/02/26 03:04:10.000</failure>
+      <system-err>STEP: creating a pod @ 01/02/26 03:04:08.000</system-err>
+    </testcase>
+    <testcase name="[It] failing late" time="2">
+      <failure message="expected true" type="failed">[FAILED] expected true&#xA;In [It] at: probes.go:7 @ 01/02/26 03:20:00.000</failure>
+      <system-out>existing output</system-out>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+
+const annotateKubeletLog = `I0102 03:03:00.000000    1234 kubelet.go:10] "too early"
+I0102 03:04:07.000000    1234 kubelet.go:11] "SyncLoop ADD" pods=["pod-a"]
+E0102 03:04:09.500000    1234 kubelet.go:12] "Error syncing pod" err="image pull failed"
+I0102 03:04:30.000000    1234 kubelet.go:13] "too late"
+  continuation line without a header
+I0102 03:19:59.000000    1234 kubelet.go:14] "Probe failed" probeType="Liveness"
+`
+
+func TestAnnotateFailuresWithLogs(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, dir, "tmp-node-e2e-cos/"+kubeletLogName, annotateKubeletLog)
+	writeArtifact(t, dir, "junit_tmp-node-e2e-cos_01.xml", annotateJUnitSample)
+	writeArtifact(t, dir, "junit_tmp-node-e2e-ubuntu_01.xml", annotateJUnitSample)
+	writeArtifact(t, dir, "tmp-node-e2e-ubuntu/"+kubeletLogName, "")
+
+	tester := NewDefaultTester()
+	tester.clock = newFakeClock(0)
+	tester.FailureLogWindow = 5 * time.Second
+	tester.annotateFailuresWithLogs(dir)
+
+	results, err := parseJUnitFile(filepath.Join(dir, "junit_tmp-node-e2e-cos_01.xml"))
+	if err != nil {
+		t.Fatalf("failed to parse the annotated junit: %v", err)
+	}
+	if len(results) != 3 || results[1].Status != SpecFailed || results[1].Message != "timed out" {
+		t.Fatalf("expected the annotated junit to keep its results, but got %+v", results)
+	}
+
+	suites := readJUnit(t, filepath.Join(dir, "junit_tmp-node-e2e-cos_01.xml"))
+	cases := suites[0].Cases
+	if cases[0].SystemOut != "" {
+		t.Errorf("expected the passing testcase not to be annotated, but got %q", cases[0].SystemOut)
+	}
+
+	early := cases[1].SystemOut
+	for _, expected := range []string{"kubelet log of tmp-node-e2e-cos", `"SyncLoop ADD"`, `"Error syncing pod"`} {
+		if !strings.Contains(early, expected) {
+			t.Errorf("expected the early failure to embed %q, but got %q", expected, early)
+		}
+	}
+	for _, unexpected := range []string{"too early", "too late", "Probe failed"} {
+		if strings.Contains(early, unexpected) {
+			t.Errorf("expected the early failure not to embed %q, but got %q", unexpected, early)
+		}
+	}
+
+	late := cases[2].SystemOut
+	if !strings.HasPrefix(late, "existing output") || !strings.Contains(late, `"Probe failed"`) {
+		t.Errorf("expected the late failure to keep its output and embed the probe failure, but got %q", late)
+	}
+
+	// the ubuntu node log has no lines in the window, so its junit is left alone
+	for _, c := range readJUnit(t, filepath.Join(dir, "junit_tmp-node-e2e-ubuntu_01.xml"))[0].Cases {
+		if strings.Contains(c.SystemOut, "kubelet log") {
+			t.Errorf("expected the ubuntu junit not to be annotated, but got %q", c.SystemOut)
+		}
+	}
+}
+
+func readJUnit(t *testing.T, path string) []junitTestSuite {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+	var suites junitTestSuites
+	if err := xml.Unmarshal(data, &suites); err != nil {
+		t.Fatalf("failed to parse %s: %v", path, err)
+	}
+	return suites.Suites
+}
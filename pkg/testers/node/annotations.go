@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kubetest2/pkg/testers"
+)
+
+// annotationsMetadataKey holds the --metadata-annotation entries in
+// metadata.json. Its values are strings, so the annotations are recorded as
+// a JSON encoded map.
+const annotationsMetadataKey = "annotations"
+
+// parseMetadataAnnotations parses key=value annotations, the value may contain '='
+func parseMetadataAnnotations(entries []string) (map[string]string, error) {
+	annotations := map[string]string{}
+	for _, entry := range entries {
+		key, value, ok := strings.Cut(entry, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%q is not of the form key=value", entry)
+		}
+		if _, exists := annotations[key]; exists {
+			return nil, fmt.Errorf("annotation %s is set more than once", key)
+		}
+		annotations[key] = value
+	}
+	return annotations, nil
+}
+
+// writeAnnotations records the annotations of the run in metadata.json
+func writeAnnotations(annotations map[string]string) error {
+	data, err := json.Marshal(annotations)
+	if err != nil {
+		return fmt.Errorf("failed to encode the annotations: %w", err)
+	}
+	return testers.WriteToMetadata(annotationsMetadataKey, string(data))
+}
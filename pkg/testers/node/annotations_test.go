@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMetadataAnnotations(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations []string
+		expected    map[string]string
+		expectErr   bool
+	}{
+		{
+			name:        "annotations",
+			annotations: []string{"team=sig-node", "environment=ci", "query=a=b"},
+			expected:    map[string]string{"team": "sig-node", "environment": "ci", "query": "a=b"},
+		},
+		{
+			name:        "empty value",
+			annotations: []string{"ticket="},
+			expected:    map[string]string{"ticket": ""},
+		},
+		{
+			name:        "missing value",
+			annotations: []string{"team"},
+			expectErr:   true,
+		},
+		{
+			name:        "missing key",
+			annotations: []string{"=sig-node"},
+			expectErr:   true,
+		},
+		{
+			name:        "duplicate key",
+			annotations: []string{"team=sig-node", "team=sig-testing"},
+			expectErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("ARTIFACTS", dir)
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.GCPZone = "us-central1-a"
+			tester.MetadataAnnotation = tc.annotations
+			err := tester.validateFlags()
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error for %q but got none", tc.annotations)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := tester.writeMetadata(); err != nil {
+				t.Fatalf("unexpected error writing metadata: %v", err)
+			}
+
+			var annotations map[string]string
+			if err := json.Unmarshal([]byte(readMetadata(t, dir)[annotationsMetadataKey]), &annotations); err != nil {
+				t.Fatalf("failed to parse the annotations: %v", err)
+			}
+			if !reflect.DeepEqual(annotations, tc.expected) {
+				t.Errorf("expected annotations %v, but got %v", tc.expected, annotations)
+			}
+		})
+	}
+}
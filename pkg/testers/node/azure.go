@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+)
+
+// azureProvider runs the node e2e tests on Azure VMs
+const azureProvider = "azure"
+
+// validateAzure checks that the flags the azure provider needs to create the
+// VMs are set, and that they are not set with the other providers
+func (t *Tester) validateAzure() error {
+	azureFlags := []struct {
+		name  string
+		value string
+	}{
+		{name: "azure-resource-group", value: t.AzureResourceGroup},
+		{name: "azure-location", value: t.AzureLocation},
+		{name: "azure-vm-size", value: t.AzureVMSize},
+	}
+	for _, flag := range azureFlags {
+		if t.Provider == azureProvider && flag.value == "" {
+			return fmt.Errorf("required --%s with the azure provider", flag.name)
+		}
+		if t.Provider != azureProvider && flag.value != "" {
+			return fmt.Errorf("--%s is only supported with the azure provider", flag.name)
+		}
+	}
+	return nil
+}
+
+// azureArgs returns the make arguments locating the VMs of an azure run
+func (t *Tester) azureArgs() []string {
+	return []string{
+		"AZURE_RESOURCE_GROUP=" + t.AzureResourceGroup,
+		"AZURE_LOCATION=" + t.AzureLocation,
+		"AZURE_VM_SIZE=" + t.AzureVMSize,
+	}
+}
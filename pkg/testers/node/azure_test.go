@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"testing"
+)
+
+func TestAzureArgs(t *testing.T) {
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.Provider = azureProvider
+	tester.AzureResourceGroup = "node-e2e"
+	tester.AzureLocation = "eastus"
+	tester.AzureVMSize = "Standard_D4s_v5"
+	if err := tester.validateFlags(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	args := tester.constructArgs()
+	for _, expected := range []string{
+		"AZURE_RESOURCE_GROUP=node-e2e",
+		"AZURE_LOCATION=eastus",
+		"AZURE_VM_SIZE=Standard_D4s_v5",
+	} {
+		if !containsString(args, expected) {
+			t.Errorf("expected %q in the args, but got %v", expected, args)
+		}
+	}
+
+	tester.Provider = "gce"
+	tester.AzureResourceGroup, tester.AzureLocation, tester.AzureVMSize = "", "", ""
+	for _, arg := range tester.constructArgs() {
+		if arg == "AZURE_RESOURCE_GROUP=" {
+			t.Errorf("expected no azure args with the gce provider, but got %q", arg)
+		}
+	}
+}
+
+func TestAzureSkipsBoskos(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cmder := &fakeCmder{}
+	tester := NewDefaultTester()
+	tester.Provider = azureProvider
+	tester.cmder = cmder
+
+	if err := tester.setupProvider(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tester.boskos != nil || tester.GCPProject != "" {
+		t.Errorf("expected no project to be acquired from boskos, but got %q", tester.GCPProject)
+	}
+	if len(cmder.cmds) != 0 {
+		t.Errorf("expected no commands to be run, but got %s %v", cmder.cmds[0].name, cmder.cmds[0].args)
+	}
+}
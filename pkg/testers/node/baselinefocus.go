@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// passingSpecs returns the sorted names of the specs that passed in the
+// baseline junit results at path, either a junit file or a directory of them
+func passingSpecs(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	results := &Summary{}
+	if info.IsDir() {
+		if results, err = parseJUnitResults(path); err != nil {
+			return nil, err
+		}
+	} else {
+		specs, err := parseJUnitFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, spec := range specs {
+			results.Add(spec)
+		}
+	}
+
+	seen := map[string]bool{}
+	var names []string
+	for _, spec := range results.Specs {
+		if spec.Status == SpecPassed && !seen[spec.Name] {
+			seen[spec.Name] = true
+			names = append(names, spec.Name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// focusFromPassingJUnit returns a focus regex matching only the specs that
+// passed in the baseline junit results at path
+func focusFromPassingJUnit(path string) (string, error) {
+	names, err := passingSpecs(path)
+	if err != nil {
+		return "", err
+	}
+	// an empty focus would run every spec instead of none
+	if len(names) == 0 {
+		return "", fmt.Errorf("no passing specs in %s", path)
+	}
+	return specFocusRegex(names), nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestFocusFromPassingJUnit(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, dir, "baseline/junit_01.xml", sampleJUnit)
+	writeArtifact(t, dir, "baseline/junit_02.xml", `<testsuite><testcase name="[It] [sig-node] Pods should run (slow) [NodeConformance]"/></testsuite>`)
+	writeArtifact(t, dir, "failing/junit_01.xml", `<testsuite><testcase name="[It] broken"><failure message="failed"/></testcase></testsuite>`)
+
+	testCases := []struct {
+		name          string
+		baseline      string
+		focusRegex    string
+		expectedFocus string
+		matches       []string
+		notMatches    []string
+		expectErr     bool
+	}{
+		{
+			name:          "single junit file",
+			baseline:      filepath.Join(dir, "baseline", "junit_01.xml"),
+			expectedFocus: "fixed bug",
+			matches:       []string{"fixed bug"},
+			notMatches:    []string{"known flake", "regression", "not run"},
+		},
+		{
+			name:          "directory of junit files",
+			baseline:      filepath.Join(dir, "baseline"),
+			expectedFocus: `\[sig-node\] Pods should run \(slow\) \[NodeConformance\]|fixed bug`,
+			matches:       []string{"fixed bug", "[sig-node] Pods should run (slow) [NodeConformance]"},
+			notMatches:    []string{"known flake", "regression"},
+		},
+		{
+			name:      "no passing specs",
+			baseline:  filepath.Join(dir, "failing"),
+			expectErr: true,
+		},
+		{
+			name:      "missing baseline",
+			baseline:  filepath.Join(dir, "missing.xml"),
+			expectErr: true,
+		},
+		{
+			name:       "combined with a focus",
+			baseline:   filepath.Join(dir, "baseline"),
+			focusRegex: `\[NodeConformance\]`,
+			expectErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.GCPZone = "us-central1-a"
+			tester.FocusFromPassingJUnit = tc.baseline
+			tester.FocusRegex = tc.focusRegex
+			err := tester.validateFlags()
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error for baseline %q but got none", tc.baseline)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tester.FocusRegex != tc.expectedFocus {
+				t.Errorf("expected focus %q, but got %q", tc.expectedFocus, tester.FocusRegex)
+			}
+			focus := regexp.MustCompile(tester.FocusRegex)
+			for _, spec := range tc.matches {
+				if !focus.MatchString(spec) {
+					t.Errorf("expected the focus to match %q", spec)
+				}
+			}
+			for _, spec := range tc.notMatches {
+				if focus.MatchString(spec) {
+					t.Errorf("expected the focus not to match %q", spec)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	baselineRegressionsMetadataKey = "baseline-regressions"
+	baselineFixesMetadataKey       = "baseline-fixes"
+)
+
+// summaryDiff is the difference between the summary of a run and a baseline
+type summaryDiff struct {
+	// Regressions are the specs failing in the run that did not fail in the baseline
+	Regressions []string
+	// Fixes are the specs passing in the run that failed in the baseline
+	Fixes []string
+}
+
+// loadRunSummary reads a summary.json written by a previous run
+func loadRunSummary(path string) (runSummary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return runSummary{}, err
+	}
+	var summary runSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return runSummary{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return summary, nil
+}
+
+// diffSummaries compares the specs of current against baseline, the results
+// are sorted as the specs of a summary are
+func diffSummaries(baseline, current runSummary) summaryDiff {
+	baselineFailed := map[string]bool{}
+	for _, spec := range baseline.FailedSpecs {
+		baselineFailed[spec] = true
+	}
+	var diff summaryDiff
+	for _, spec := range current.FailedSpecs {
+		if !baselineFailed[spec] {
+			diff.Regressions = append(diff.Regressions, spec)
+		}
+	}
+	for _, spec := range current.PassedSpecs {
+		if baselineFailed[spec] {
+			diff.Fixes = append(diff.Fixes, spec)
+		}
+	}
+	return diff
+}
+
+// compareWithBaseline diffs current against the --baseline-summary, logs the
+// regressions and fixes and records them in metadata.json. It returns an
+// error for the regressions if --fail-on-regressions is set, a baseline that
+// cannot be read is only logged.
+func (t *Tester) compareWithBaseline(artifactsDir string, current runSummary) error {
+	baseline, err := loadRunSummary(t.BaselineSummary)
+	if err != nil {
+		klog.Warningf("failed to read the baseline summary: %v", err)
+		return nil
+	}
+	diff := diffSummaries(baseline, current)
+	for _, spec := range diff.Regressions {
+		klog.Warningf("regression from the baseline: %s", spec)
+	}
+	for _, spec := range diff.Fixes {
+		klog.V(0).Infof("fixed since the baseline: %s", spec)
+	}
+	klog.V(0).Infof("compared with baseline %s: %d regressions, %d fixes", t.BaselineSummary, len(diff.Regressions), len(diff.Fixes))
+	if err := setMetadata(artifactsDir, baselineRegressionsMetadataKey, strings.Join(diff.Regressions, ", ")); err != nil {
+		klog.Warningf("failed to record the baseline regressions: %v", err)
+	}
+	if err := setMetadata(artifactsDir, baselineFixesMetadataKey, strings.Join(diff.Fixes, ", ")); err != nil {
+		klog.Warningf("failed to record the baseline fixes: %v", err)
+	}
+	if t.FailOnRegressions && len(diff.Regressions) > 0 {
+		return fmt.Errorf("%d specs regressed from the baseline summary", len(diff.Regressions))
+	}
+	return nil
+}
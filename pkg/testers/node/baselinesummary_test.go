@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDiffSummaries(t *testing.T) {
+	testCases := []struct {
+		name     string
+		baseline runSummary
+		current  runSummary
+		expected summaryDiff
+	}{
+		{
+			name:     "same failures",
+			baseline: runSummary{FailedSpecs: []string{"[It] a"}, PassedSpecs: []string{"[It] b"}},
+			current:  runSummary{FailedSpecs: []string{"[It] a"}, PassedSpecs: []string{"[It] b"}},
+		},
+		{
+			name:     "regressions and fixes",
+			baseline: runSummary{FailedSpecs: []string{"[It] a", "[It] b"}, PassedSpecs: []string{"[It] c", "[It] d"}},
+			current:  runSummary{FailedSpecs: []string{"[It] b", "[It] c"}, PassedSpecs: []string{"[It] a", "[It] d"}},
+			expected: summaryDiff{Regressions: []string{"[It] c"}, Fixes: []string{"[It] a"}},
+		},
+		{
+			name:     "new failing spec",
+			baseline: runSummary{PassedSpecs: []string{"[It] a"}},
+			current:  runSummary{FailedSpecs: []string{"[It] new"}, PassedSpecs: []string{"[It] a"}},
+			expected: summaryDiff{Regressions: []string{"[It] new"}},
+		},
+		{
+			name:     "baseline failure not run",
+			baseline: runSummary{FailedSpecs: []string{"[It] a"}},
+			current:  runSummary{PassedSpecs: []string{"[It] b"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := diffSummaries(tc.baseline, tc.current); !reflect.DeepEqual(diff, tc.expected) {
+				t.Errorf("expected diff %+v, but got %+v", tc.expected, diff)
+			}
+		})
+	}
+}
+
+func TestCompareWithBaseline(t *testing.T) {
+	current := runSummary{
+		Passed:      1,
+		Failed:      2,
+		FailedSpecs: []string{"[It] known flake", "[It] regression"},
+		PassedSpecs: []string{"[It] fixed bug"},
+	}
+	baseline := runSummary{
+		Passed:      2,
+		Failed:      2,
+		FailedSpecs: []string{"[It] fixed bug", "[It] known flake"},
+		PassedSpecs: []string{"[It] regression"},
+	}
+
+	testCases := []struct {
+		name              string
+		failOnRegressions bool
+		expectErr         bool
+	}{
+		{
+			name: "regressions are reported",
+		},
+		{
+			name:              "regressions fail the run",
+			failOnRegressions: true,
+			expectErr:         true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			baselinePath := filepath.Join(dir, "baseline.json")
+			if err := writeJSON(baselinePath, baseline); err != nil {
+				t.Fatalf("failed to write the baseline: %v", err)
+			}
+			tester := NewDefaultTester()
+			tester.BaselineSummary = baselinePath
+			tester.FailOnRegressions = tc.failOnRegressions
+
+			err := tester.compareWithBaseline(dir, current)
+			if tc.expectErr != (err != nil) {
+				t.Errorf("expected error %v, but got %v", tc.expectErr, err)
+			}
+			meta := readMetadata(t, dir)
+			if actual := meta[baselineRegressionsMetadataKey]; actual != "[It] regression" {
+				t.Errorf("expected regressions %q in metadata, but got %q", "[It] regression", actual)
+			}
+			if actual := meta[baselineFixesMetadataKey]; actual != "[It] fixed bug" {
+				t.Errorf("expected fixes %q in metadata, but got %q", "[It] fixed bug", actual)
+			}
+		})
+	}
+}
+
+func TestFailOnRegressionsRequiresBaseline(t *testing.T) {
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.GCPZone = "us-central1-a"
+	tester.FailOnRegressions = true
+	if err := tester.validateFlags(); err == nil {
+		t.Error("expected --fail-on-regressions to require --baseline-summary")
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/klog/v2"
+)
+
+// watchBoskosHold aborts the run by calling cancel and releases the boskos
+// resource by calling release once the resource has been held for
+// MaxBoskosHold, unless the returned stop function is called first
+func (t *Tester) watchBoskosHold(cancel context.CancelCauseFunc, release func()) (stop func()) {
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	expired := t.clock.After(t.MaxBoskosHold)
+	go func() {
+		defer close(exited)
+		select {
+		case <-expired:
+			err := fmt.Errorf("boskos resource held for longer than --max-boskos-hold=%s", t.MaxBoskosHold)
+			klog.Errorf("%v, releasing it and aborting the run", err)
+			cancel(err)
+			release()
+		case <-done:
+		}
+	}()
+	return func() {
+		close(done)
+		<-exited
+	}
+}
+
+// watchBoskosHeartbeat aborts the run by calling cancel once the boskos
+// heartbeat reports BoskosHeartbeatMaxFailures failures in a row, before the
+// resource is reaped from under the run, unless the returned stop function is
+// called first
+func (t *Tester) watchBoskosHeartbeat(cancel context.CancelCauseFunc) (stop func()) {
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		select {
+		case heartbeatErr := <-t.boskosHeartbeatFailed:
+			err := fmt.Errorf("boskos resource %s may be reaped, exceeded --boskos-heartbeat-max-failures=%d: %w", t.GCPProject, t.BoskosHeartbeatMaxFailures, heartbeatErr)
+			klog.Errorf("%v, aborting the run", err)
+			cancel(err)
+		case <-done:
+		}
+	}()
+	return func() {
+		close(done)
+		<-exited
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMaxBoskosHold(t *testing.T) {
+	t.Setenv("ARTIFACTS", t.TempDir())
+	clock := newFakeClock(0)
+	ctx, abort := context.WithCancelCause(context.Background())
+	defer abort(nil)
+	released := make(chan struct{})
+
+	tester := NewDefaultTester()
+	tester.MaxBoskosHold = time.Hour
+	tester.clock = clock
+	tester.ctx = ctx
+	tester.cmder = &fakeCmder{run: func(cmd *fakeCmd) error {
+		// the tests are still running when the hold expires
+		clock.Advance(time.Hour)
+		<-cmd.ctx.Done()
+		return cmd.ctx.Err()
+	}}
+
+	stop := tester.watchBoskosHold(abort, func() { close(released) })
+	defer stop()
+
+	err := tester.Test()
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the run to be aborted, but got %v", err)
+	}
+	if !strings.Contains(err.Error(), "--max-boskos-hold") {
+		t.Errorf("expected the error to name --max-boskos-hold, but got %v", err)
+	}
+	select {
+	case <-released:
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected the boskos resource to be released")
+	}
+}
+
+func TestMaxBoskosHoldStopped(t *testing.T) {
+	clock := newFakeClock(0)
+	ctx, abort := context.WithCancelCause(context.Background())
+	defer abort(nil)
+	released := false
+
+	tester := NewDefaultTester()
+	tester.MaxBoskosHold = time.Hour
+	tester.clock = clock
+
+	stop := tester.watchBoskosHold(abort, func() { released = true })
+	stop()
+	clock.Advance(2 * time.Hour)
+
+	if ctx.Err() != nil || released {
+		t.Errorf("expected a stopped watch not to abort the run or release the resource")
+	}
+}
+
+func TestBoskosHeartbeatFailures(t *testing.T) {
+	t.Setenv("ARTIFACTS", t.TempDir())
+	ctx, abort := context.WithCancelCause(context.Background())
+	defer abort(nil)
+
+	tester := NewDefaultTester()
+	tester.GCPProject = "boskos-project"
+	tester.ctx = ctx
+	tester.cmder = &fakeCmder{run: func(cmd *fakeCmd) error {
+		// the heartbeats keep failing while the tests are running
+		tester.boskosHeartbeatFailed <- errors.New("3 heartbeats in a row for boskos-project failed, the last with: connection refused")
+		<-cmd.ctx.Done()
+		return cmd.ctx.Err()
+	}}
+
+	stop := tester.watchBoskosHeartbeat(abort)
+	defer stop()
+
+	err := tester.Test()
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the run to be aborted, but got %v", err)
+	}
+	for _, expected := range []string{"--boskos-heartbeat-max-failures=3", "boskos-project", "connection refused"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected the error to contain %q, but got %v", expected, err)
+		}
+	}
+}
+
+func TestBoskosHeartbeatWatchStopped(t *testing.T) {
+	ctx, abort := context.WithCancelCause(context.Background())
+	defer abort(nil)
+
+	tester := NewDefaultTester()
+	stop := tester.watchBoskosHeartbeat(abort)
+	stop()
+	tester.boskosHeartbeatFailed <- errors.New("connection refused")
+
+	if ctx.Err() != nil {
+		t.Errorf("expected a stopped watch not to abort the run")
+	}
+}
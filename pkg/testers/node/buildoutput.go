@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// outDirVar is the make variable the kubernetes build writes its output
+// under. make exports it to test-e2e-node.sh, so the test phase reads the
+// binaries from the same location.
+const outDirVar = "OUT_DIR"
+
+// validateBuildOutputDir creates --build-output-dir if needed, checks that it
+// is writable and resolves its absolute path, make does not run in the current
+// directory
+func (t *Tester) validateBuildOutputDir() error {
+	if t.BuildOutputDir == "" {
+		return nil
+	}
+	dir, err := filepath.Abs(t.BuildOutputDir)
+	if err != nil {
+		return fmt.Errorf("invalid --build-output-dir: %v", err)
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("invalid --build-output-dir: %v", err)
+	}
+	f, err := os.CreateTemp(dir, ".kubetest2-writable-")
+	if err != nil {
+		return fmt.Errorf("invalid --build-output-dir: %s is not writable: %v", dir, err)
+	}
+	f.Close()
+	if err := os.Remove(f.Name()); err != nil {
+		return fmt.Errorf("invalid --build-output-dir: %v", err)
+	}
+	t.buildOutputDir = dir
+	return nil
+}
+
+// buildOutputArgs returns the make variables redirecting the build output
+func (t *Tester) buildOutputArgs() []string {
+	if t.buildOutputDir == "" {
+		return nil
+	}
+	return []string{outDirVar + "=" + t.buildOutputDir}
+}
+
+// localBinDir is where a local run finds the binaries built to --build-output-dir
+func (t *Tester) localBinDir() string {
+	return filepath.Join(t.buildOutputDir, "local", "go", "bin")
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildOutputDir(t *testing.T) {
+	testCases := []struct {
+		name     string
+		provider string
+	}{
+		{name: "remote", provider: "gce"},
+		{name: "local", provider: "local"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "out")
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.Provider = tc.provider
+			tester.GCPZone = "us-central1-a"
+			tester.BuildOutputDir = dir
+			if err := tester.validateFlags(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+				t.Fatalf("expected the build output directory to be created, but got %v", err)
+			}
+			entries, _ := os.ReadDir(dir)
+			if len(entries) != 0 {
+				t.Errorf("expected the writability check to leave no files behind, but got %v", entries)
+			}
+
+			args := tester.constructArgs()
+			if actual := argValue(t, args, outDirVar); actual != dir {
+				t.Errorf("expected the build to write to %s=%q, but got %q", outDirVar, dir, actual)
+			}
+			binDir := "--k8s-bin-dir=" + filepath.Join(dir, "local", "go", "bin")
+			if testArgs := argValue(t, args, "TEST_ARGS"); strings.Contains(testArgs, binDir) != (tc.provider == "local") {
+				t.Errorf("expected the local tests to read the binaries from %s, but got TEST_ARGS=%q", dir, testArgs)
+			}
+		})
+	}
+}
+
+func TestBuildOutputDirNotWritable(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, dir, "file", "")
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.GCPZone = "us-central1-a"
+	tester.BuildOutputDir = filepath.Join(dir, "file", "out")
+	if err := tester.validateFlags(); err == nil {
+		t.Errorf("expected a build output directory that cannot be created to be rejected")
+	}
+}
+
+func TestBuildOutputDirUnset(t *testing.T) {
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.GCPZone = "us-central1-a"
+	if err := tester.validateFlags(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, arg := range tester.constructArgs() {
+		if strings.HasPrefix(arg, outDirVar+"=") {
+			t.Errorf("expected no %s without --build-output-dir, but got %q", outDirVar, arg)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// the test runner builds the test binaries before it sets up the first
+	// test node, everything it prints before that is build output, e.g.
+	//   Initializing e2e tests using image cos-cloud/cos-stable/tmp-node-e2e-1234.
+	buildFinishedRegex = regexp.MustCompile(`Initializing e2e tests using|Staging test binaries on`)
+	// warnings of the compilers, make and docker, e.g.
+	//   pkg/foo.go:12:2: warning: unused variable
+	//   make[1]: warning: jobserver unavailable
+	//   WARNING: The requested image's platform does not match
+	buildWarningRegex = regexp.MustCompile(`(?i)\bwarning:`)
+)
+
+// buildWarningsError is returned when --fail-on-build-warnings is set and the build produced warnings
+type buildWarningsError struct {
+	warnings []string
+}
+
+func (e *buildWarningsError) Error() string {
+	return fmt.Sprintf("build produced %d warnings with --fail-on-build-warnings:\n%s", len(e.warnings), strings.Join(e.warnings, "\n"))
+}
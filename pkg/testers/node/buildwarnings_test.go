@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+const (
+	cleanBuildOutput = `+++ [0102 03:04:05] Building go targets for linux/amd64
+    k8s.io/kubernetes/test/e2e_node/e2e_node.test (test)
+`
+	warningBuildOutput = `+++ [0102 03:04:05] Building go targets for linux/amd64
+# k8s.io/kubernetes/vendor/github.com/opencontainers/runc/libcontainer/cgroups
+vendor/github.com/opencontainers/runc/libcontainer/nsenter/cloned_binary.c:42:2: warning: 'memfd_create' is deprecated
+make[1]: warning: jobserver unavailable: using -j1.  Add '+' to parent make rule.
+`
+	testPhaseOutput = `Initializing e2e tests using image cos-cloud/cos-stable/tmp-node-e2e-1234.
+W0102 03:05:06.000000    1234 pod.go:42] Warning: pod took longer than expected to start
+STEP: warning: this step is flaky
+`
+)
+
+func TestFailOnBuildWarnings(t *testing.T) {
+	testCases := []struct {
+		name             string
+		output           string
+		failOnWarnings   bool
+		expectedWarnings []string
+	}{
+		{
+			name:           "clean build",
+			output:         cleanBuildOutput + testPhaseOutput,
+			failOnWarnings: true,
+		},
+		{
+			name:           "build warnings",
+			output:         warningBuildOutput + testPhaseOutput,
+			failOnWarnings: true,
+			expectedWarnings: []string{
+				"vendor/github.com/opencontainers/runc/libcontainer/nsenter/cloned_binary.c:42:2: warning: 'memfd_create' is deprecated",
+				"make[1]: warning: jobserver unavailable: using -j1.  Add '+' to parent make rule.",
+			},
+		},
+		{
+			name:   "build warnings are ignored by default",
+			output: warningBuildOutput + testPhaseOutput,
+		},
+		{
+			name:           "warnings of the tests are not build warnings",
+			output:         cleanBuildOutput + testPhaseOutput + "make: warning: Clock skew detected\n",
+			failOnWarnings: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.FailOnBuildWarnings = tc.failOnWarnings
+			tester.cmder = &fakeCmder{run: func(cmd *fakeCmd) error {
+				_, _ = io.WriteString(cmd.stderr, tc.output)
+				return nil
+			}}
+
+			err := tester.runOnce(t.TempDir())
+			if tc.expectedWarnings == nil {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var warningsErr *buildWarningsError
+			if !errors.As(err, &warningsErr) {
+				t.Fatalf("expected a build warnings error, but got %v", err)
+			}
+			if !reflect.DeepEqual(warningsErr.warnings, tc.expectedWarnings) {
+				t.Errorf("expected warnings %q, but got %q", tc.expectedWarnings, warningsErr.warnings)
+			}
+		})
+	}
+}
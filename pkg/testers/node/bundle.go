@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"k8s.io/klog/v2"
+)
+
+const failureBundleName = "failure-bundle.tar.gz"
+
+// failureBundlePatterns match the base names of the artifacts worth
+// bundling on failure: run and node logs, junit results and metadata
+var failureBundlePatterns = []string{
+	"*.log",
+	"*.txt",
+	"junit*.xml",
+	"*.json",
+}
+
+func isFailureBundleFile(name string) bool {
+	for _, pattern := range failureBundlePatterns {
+		if matched, _ := filepath.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// writeFailureBundle packages the key artifacts found under dir into a
+// single gzipped tarball written to dir, so that CI has one file to
+// download when a run fails
+func writeFailureBundle(dir string) error {
+	bundlePath := filepath.Join(dir, failureBundleName)
+	klog.V(1).Infof("writing failure bundle to %s", bundlePath)
+
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() && isFailureBundleFile(d.Name()) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to collect artifacts under %s: %w", dir, err)
+	}
+	return writeTarGz(bundlePath, dir, files)
+}
+
+// writeTarGz writes files to a gzipped tarball at path, named relative to
+// baseDir. On an error the partial tarball is removed, so that it is not
+// mistaken for a complete one.
+func writeTarGz(path, baseDir string, files []string) (err error) {
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	gzw := gzip.NewWriter(out)
+	tw := tar.NewWriter(gzw)
+	defer func() {
+		if closeErr := tw.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to finalize tar: %w", closeErr)
+		}
+		if closeErr := gzw.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to finalize gzip: %w", closeErr)
+		}
+		if closeErr := out.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close %s: %w", path, closeErr)
+		}
+		if err != nil {
+			if removeErr := os.Remove(path); removeErr != nil {
+				klog.Warningf("failed to remove the partial %s: %v", path, removeErr)
+			}
+		}
+	}()
+
+	for _, file := range files {
+		if err := addFileToTar(tw, baseDir, file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addFileToTar(tw *tar.Writer, baseDir, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return fmt.Errorf("failed to create tar header for %s: %w", path, err)
+	}
+	if header.Name, err = filepath.Rel(baseDir, path); err != nil {
+		return err
+	}
+	header.Name = filepath.ToSlash(header.Name)
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write tar header for %s: %w", path, err)
+	}
+	if _, err := io.Copy(tw, f); err != nil {
+		return fmt.Errorf("failed to write %s to tar: %w", path, err)
+	}
+	return nil
+}
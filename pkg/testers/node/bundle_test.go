@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func writeArtifact(t *testing.T, dir, name, contents string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create directory for %s: %v", name, err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+}
+
+func tarEntries(t *testing.T, path string) []string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer f.Close()
+	gzr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("failed to read gzip: %v", err)
+	}
+	var names []string
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("failed to read tar: %v", err)
+		}
+		names = append(names, header.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestFailureBundle(t *testing.T) {
+	testCases := []struct {
+		name          string
+		runErr        error
+		expectBundle  bool
+		expectedFiles []string
+	}{
+		{
+			name: "no bundle on success",
+		},
+		{
+			name:         "bundle on failure",
+			runErr:       errors.New("make failed"),
+			expectBundle: true,
+			expectedFiles: []string{
+				"build-log.txt",
+				"junit_01.xml",
+				"metadata.json",
+				"tmp-node-e2e-1234/kubelet.log",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("ARTIFACTS", dir)
+			tester := NewDefaultTester()
+			tester.cmder = &fakeCmder{run: func(*fakeCmd) error {
+				writeArtifact(t, dir, "build-log.txt", "building")
+				writeArtifact(t, dir, "junit_01.xml", "<testsuite/>")
+				writeArtifact(t, dir, "metadata.json", "{}")
+				writeArtifact(t, dir, "tmp-node-e2e-1234/kubelet.log", "kubelet")
+				writeArtifact(t, dir, "tmp-node-e2e-1234/image.bin", "not an artifact")
+				return tc.runErr
+			}}
+
+			err := tester.Test()
+			if !errors.Is(err, tc.runErr) {
+				t.Errorf("expected error %v, but got %v", tc.runErr, err)
+			}
+			bundlePath := filepath.Join(dir, failureBundleName)
+			_, statErr := os.Stat(bundlePath)
+			if !tc.expectBundle {
+				if statErr == nil {
+					t.Errorf("expected no failure bundle to be written")
+				}
+				return
+			}
+			if statErr != nil {
+				t.Fatalf("expected failure bundle to be written: %v", statErr)
+			}
+			if actual := tarEntries(t, bundlePath); !reflect.DeepEqual(actual, tc.expectedFiles) {
+				t.Errorf("expected bundle to contain %v, but got %v", tc.expectedFiles, actual)
+			}
+		})
+	}
+}
+
+func TestFailureBundleOfSubRuns(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ARTIFACTS", dir)
+	tester := NewDefaultTester()
+	tester.featureGateMatrix = []featureGateCombination{
+		{label: "on", gates: "GateA=true"},
+		{label: "off", gates: "GateA=false"},
+		{label: "default"},
+	}
+	tester.cmder = &fakeCmder{run: func(cmd *fakeCmd) error {
+		artifactsDir := argValue(t, cmd.env, "ARTIFACTS")
+		writeArtifact(t, artifactsDir, "tmp-node-e2e-1234/kubelet.log", "kubelet")
+		if strings.Contains(argValue(t, cmd.args, "TEST_ARGS"), "GateA=") {
+			writeArtifact(t, artifactsDir, "junit_01.xml", `<testsuite><testcase name="a"><failure message="failed"/></testcase></testsuite>`)
+			return errors.New("specs failed")
+		}
+		writeArtifact(t, artifactsDir, "junit_01.xml", `<testsuite><testcase name="a"/></testsuite>`)
+		return nil
+	}}
+
+	if err := tester.Test(); err == nil {
+		t.Fatalf("expected the failed sub-runs to fail the run")
+	}
+
+	for _, label := range []string{"on", "off"} {
+		if _, err := os.Stat(filepath.Join(dir, label, failureBundleName)); err != nil {
+			t.Errorf("expected a failure bundle of sub-run %s: %v", label, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "default", failureBundleName)); !os.IsNotExist(err) {
+		t.Errorf("expected no failure bundle of the passing sub-run, but got %v", err)
+	}
+	entries := tarEntries(t, filepath.Join(dir, failureBundleName))
+	for _, expected := range []string{"on/junit_01.xml", "off/tmp-node-e2e-1234/kubelet.log", "default/junit_01.xml", summaryTextFileName} {
+		if !containsString(entries, expected) {
+			t.Errorf("expected %s in the top-level failure bundle, but got %v", expected, entries)
+		}
+	}
+}
+
+func TestFailureBundleRemovedOnError(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, dir, "build-log.txt", "building")
+	bundlePath := filepath.Join(dir, failureBundleName)
+	files := []string{filepath.Join(dir, "build-log.txt"), filepath.Join(dir, "missing.log")}
+	if err := writeTarGz(bundlePath, dir, files); err == nil {
+		t.Fatal("expected an error for the missing file but got none")
+	}
+	if _, err := os.Stat(bundlePath); !os.IsNotExist(err) {
+		t.Errorf("expected the partial bundle to be removed, but got %v", err)
+	}
+}
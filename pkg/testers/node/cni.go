@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// cniPluginEnv is the node env entry telling the node which CNI plugin to configure
+	cniPluginEnv = "CNI_PLUGIN"
+	// cniConfigDir is where the CNI network config is installed on the test nodes
+	cniConfigDir = "/etc/cni/net.d"
+	// cniConfigEOF terminates the here-document installing the CNI network config
+	cniConfigEOF = "KUBETEST2_CNI_CONFIG"
+)
+
+// validCNIPlugins are the CNI plugins the test nodes can be configured with
+var validCNIPlugins = []string{"bridge", "calico", "cilium", "flannel", "ptp"}
+
+func isValidCNIPlugin(plugin string) bool {
+	for _, valid := range validCNIPlugins {
+		if plugin == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// validateCNI checks --cni-plugin and --cni-config, and reads the config
+func (t *Tester) validateCNI() error {
+	if t.CNIPlugin == "" {
+		if t.CNIConfig != "" {
+			return fmt.Errorf("--cni-config requires --cni-plugin")
+		}
+		return nil
+	}
+	if !isValidCNIPlugin(t.CNIPlugin) {
+		return fmt.Errorf("invalid --cni-plugin %q, valid options are %s", t.CNIPlugin, strings.Join(validCNIPlugins, ", "))
+	}
+	if t.CNIConfig == "" {
+		return nil
+	}
+	if t.Provider == "ec2" && t.UserDataFile != "" {
+		return fmt.Errorf("--cni-config cannot be combined with --user-data-file on ec2")
+	}
+	config, err := os.ReadFile(t.CNIConfig)
+	if err != nil {
+		return fmt.Errorf("invalid --cni-config: %v", err)
+	}
+	if !json.Valid(config) {
+		return fmt.Errorf("invalid --cni-config: %s is not a JSON network config", t.CNIConfig)
+	}
+	t.cniConfig = config
+	return nil
+}
+
+// cniConfigScript returns the startup script lines installing config as the
+// network config of plugin, so the node uses it in place of the default one
+func cniConfigScript(plugin string, config []byte) []byte {
+	var script bytes.Buffer
+	fmt.Fprintf(&script, "mkdir -p %s\n", cniConfigDir)
+	fmt.Fprintf(&script, "cat > %s/10-%s.conflist <<'%s'\n", cniConfigDir, plugin, cniConfigEOF)
+	script.Write(bytes.TrimRight(config, "\n"))
+	fmt.Fprintf(&script, "\n%s\n", cniConfigEOF)
+	return script.Bytes()
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCNIPlugin(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, dir, "calico.conflist", `{"cniVersion": "1.0.0", "name": "k8s-pod-network", "plugins": [{"type": "calico"}]}`+"\n")
+	writeArtifact(t, dir, "invalid.conflist", "cniVersion: 1.0.0\n")
+
+	testCases := []struct {
+		name            string
+		provider        string
+		plugin          string
+		config          string
+		userDataFile    string
+		expectedNodeEnv string
+		expectErr       bool
+	}{
+		{
+			name:            "plugin",
+			plugin:          "cilium",
+			expectedNodeEnv: " CNI_PLUGIN=cilium",
+		},
+		{
+			name:            "plugin with config",
+			plugin:          "calico",
+			config:          filepath.Join(dir, "calico.conflist"),
+			expectedNodeEnv: " CNI_PLUGIN=calico",
+		},
+		{
+			name:      "unknown plugin",
+			plugin:    "weave",
+			expectErr: true,
+		},
+		{
+			name:      "config without plugin",
+			config:    filepath.Join(dir, "calico.conflist"),
+			expectErr: true,
+		},
+		{
+			name:      "missing config",
+			plugin:    "calico",
+			config:    filepath.Join(dir, "missing.conflist"),
+			expectErr: true,
+		},
+		{
+			name:      "config is not JSON",
+			plugin:    "calico",
+			config:    filepath.Join(dir, "invalid.conflist"),
+			expectErr: true,
+		},
+		{
+			name:         "config with ec2 user data",
+			provider:     "ec2",
+			plugin:       "calico",
+			config:       filepath.Join(dir, "calico.conflist"),
+			userDataFile: "user-data.sh",
+			expectErr:    true,
+		},
+		{
+			name:      "local run",
+			provider:  "local",
+			plugin:    "bridge",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.GCPZone = "us-central1-a"
+			if tc.provider != "" {
+				tester.Provider = tc.provider
+			}
+			tester.InstanceType = "m5.large"
+			tester.UserDataFile = tc.userDataFile
+			tester.CNIPlugin = tc.plugin
+			tester.CNIConfig = tc.config
+			err := tester.validateFlags()
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := argValue(t, tester.constructArgs(), "NODE_ENV"); actual != tc.expectedNodeEnv {
+				t.Errorf("expected NODE_ENV=%q, but got %q", tc.expectedNodeEnv, actual)
+			}
+		})
+	}
+}
+
+func TestCNIConfigStartupScript(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, dir, "calico.conflist", `{"cniVersion": "1.0.0", "name": "k8s-pod-network"}`+"\n")
+	writeArtifact(t, dir, "setup.sh", "#!/bin/bash\necho setup\n")
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.GCPZone = "us-central1-a"
+	tester.CNIPlugin = "calico"
+	tester.CNIConfig = filepath.Join(dir, "calico.conflist")
+	tester.NodeStartupScript = filepath.Join(dir, "setup.sh")
+	if err := tester.validateFlags(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	artifactsDir := t.TempDir()
+	if err := tester.writeNodeSysctlsScript(artifactsDir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	script := filepath.Join(artifactsDir, nodeSysctlsScriptFileName)
+	if actual := argValue(t, tester.constructArgs(), "INSTANCE_METADATA"); actual != "startup-script<"+script {
+		t.Errorf("expected the generated startup script in the instance metadata, but got %q", actual)
+	}
+	contents, err := os.ReadFile(script)
+	if err != nil {
+		t.Fatalf("failed to read the script: %v", err)
+	}
+	expected := "#!/bin/bash\nset -o errexit\n\n" +
+		"mkdir -p /etc/cni/net.d\n" +
+		"cat > /etc/cni/net.d/10-calico.conflist <<'KUBETEST2_CNI_CONFIG'\n" +
+		`{"cniVersion": "1.0.0", "name": "k8s-pod-network"}` + "\n" +
+		"KUBETEST2_CNI_CONFIG\n" +
+		"\n#!/bin/bash\necho setup\n"
+	if string(contents) != expected {
+		t.Errorf("expected script %q, but got %q", expected, string(contents))
+	}
+}
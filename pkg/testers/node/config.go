@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"sort"
+
+	"k8s.io/klog/v2"
+)
+
+// logConfig logs the configuration the run resolved to after the flags were
+// validated and the project acquired, one line per setting
+func (t *Tester) logConfig() {
+	if !klog.V(1).Enabled() {
+		return
+	}
+	lines, err := t.configLines()
+	if err != nil {
+		klog.Warningf("failed to resolve the configuration: %v", err)
+		return
+	}
+	for _, line := range lines {
+		klog.V(1).Infof("config: %s", line)
+	}
+}
+
+// configLines returns the resolved configuration as sorted key=value lines.
+// The private key is listed by path, its contents are never read here.
+func (t *Tester) configLines() ([]string, error) {
+	config, err := t.flagValues()
+	if err != nil {
+		return nil, err
+	}
+	config["ssh-user"] = t.sshUser
+	config["ssh-private-key"] = t.privateKey
+	config["run-id"] = t.runID
+	if t.boskos != nil {
+		config["boskos-acquired-project"] = t.GCPProject
+	}
+	lines := make([]string, 0, len(config))
+	for key, value := range config {
+		lines = append(lines, key+"="+value)
+	}
+	sort.Strings(lines)
+	return lines, nil
+}
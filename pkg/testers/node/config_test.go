@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfigLines(t *testing.T) {
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.GCPZone = "us-central1-a"
+	tester.GCPProject = "node-e2e-project"
+	tester.BoskosHeader = []string{"Authorization: Bearer secret-token", "X-Team: node"}
+	if err := tester.validateFlags(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tester.sshUser = "prow"
+	tester.privateKey = "/root/.ssh/google_compute_engine"
+
+	lines, err := tester.configLines()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		"gcp-project=node-e2e-project",
+		"gcp-zone=us-central1-a",
+		"ssh-user=prow",
+		"ssh-private-key=/root/.ssh/google_compute_engine",
+		"boskos-header=Authorization: <redacted>, X-Team: node",
+	} {
+		if !containsString(lines, expected) {
+			t.Errorf("expected %q in the configuration, but got %v", expected, lines)
+		}
+	}
+	for _, line := range lines {
+		if strings.Contains(line, "secret-token") {
+			t.Errorf("expected the boskos header value to be redacted, but got %q", line)
+		}
+	}
+}
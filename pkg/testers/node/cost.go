@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/testers"
+)
+
+const (
+	estimatedCostMetadataKey = "estimated-cost"
+	unknownCost              = "unknown"
+)
+
+// instancePrices are rough on-demand prices in USD per hour by provider and
+// instance type, they are only meant to give an idea of the cost of a run
+var instancePrices = map[string]map[string]float64{
+	"gce": {
+		"e2-standard-2":  0.067,
+		"e2-standard-4":  0.134,
+		"e2-standard-8":  0.268,
+		"n1-standard-1":  0.0475,
+		"n1-standard-2":  0.095,
+		"n1-standard-4":  0.19,
+		"n1-standard-8":  0.38,
+		"n2-standard-2":  0.0971,
+		"n2-standard-4":  0.1942,
+		"n2-standard-8":  0.3885,
+		"t2a-standard-2": 0.077,
+		"t2a-standard-4": 0.154,
+	},
+	"ec2": {
+		"c5.large":   0.085,
+		"c5.xlarge":  0.17,
+		"m5.large":   0.096,
+		"m5.xlarge":  0.192,
+		"m6g.large":  0.077,
+		"m6g.xlarge": 0.154,
+		"t3.large":   0.0832,
+		"t3.medium":  0.0416,
+	},
+}
+
+// estimateCost estimates the cost in USD of running count instances for duration,
+// it returns false if the price of the instance type is not in prices
+func estimateCost(prices map[string]map[string]float64, provider, instanceType string, count int, duration time.Duration) (float64, bool) {
+	price, ok := prices[provider][instanceType]
+	if !ok {
+		return 0, false
+	}
+	return price * float64(count) * duration.Hours(), true
+}
+
+// instanceCount is the number of instances created for each run,
+// one per image under test unless set by --node-count-per-image
+func (t *Tester) instanceCount() int {
+	if t.Images != "" {
+		count := 0
+		for _, image := range strings.Split(t.Images, ",") {
+			count += t.nodeCount(image)
+		}
+		return count
+	}
+	if t.ImageConfigFile != "" {
+		config, err := readImageConfig(t.imageConfigPath(t.ImageConfigFile))
+		if err != nil {
+			klog.Warningf("failed to count the images in the image config: %v", err)
+		} else if images, ok := config["images"].(map[string]interface{}); ok && len(images) > 0 {
+			return len(images)
+		}
+	}
+	return 1
+}
+
+// reportCost logs the estimated cost of a run that took duration and records it in metadata.json
+func (t *Tester) reportCost(duration time.Duration) error {
+	count := t.instanceCount()
+	value := unknownCost
+	if cost, ok := estimateCost(instancePrices, t.Provider, t.InstanceType, count, duration); ok {
+		value = fmt.Sprintf("%.2f USD", cost)
+		klog.V(0).Infof("estimated cost of the run: %s (%d %s instances for %s)", value, count, t.InstanceType, duration.Round(time.Second))
+	} else {
+		klog.V(0).Infof("cost unknown: no price for %s instance type %q", t.Provider, t.InstanceType)
+	}
+	return testers.WriteToMetadata(estimatedCostMetadataKey, value)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEstimateCost(t *testing.T) {
+	prices := map[string]map[string]float64{
+		"gce": {"n1-standard-2": 0.10},
+		"ec2": {"m5.large": 0.20},
+	}
+	testCases := []struct {
+		name         string
+		provider     string
+		instanceType string
+		count        int
+		duration     time.Duration
+		expectedCost float64
+		expectKnown  bool
+	}{
+		{
+			name:         "single instance for an hour",
+			provider:     "gce",
+			instanceType: "n1-standard-2",
+			count:        1,
+			duration:     time.Hour,
+			expectedCost: 0.10,
+			expectKnown:  true,
+		},
+		{
+			name:         "several instances for part of an hour",
+			provider:     "ec2",
+			instanceType: "m5.large",
+			count:        3,
+			duration:     30 * time.Minute,
+			expectedCost: 0.30,
+			expectKnown:  true,
+		},
+		{
+			name:         "unknown instance type",
+			provider:     "gce",
+			instanceType: "m5.large",
+			count:        1,
+			duration:     time.Hour,
+		},
+		{
+			name:         "unknown provider",
+			provider:     "local",
+			instanceType: "n1-standard-2",
+			count:        1,
+			duration:     time.Hour,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cost, known := estimateCost(prices, tc.provider, tc.instanceType, tc.count, tc.duration)
+			if known != tc.expectKnown {
+				t.Fatalf("expected known %v, but got %v", tc.expectKnown, known)
+			}
+			if math.Abs(cost-tc.expectedCost) > 1e-9 {
+				t.Errorf("expected cost %v, but got %v", tc.expectedCost, cost)
+			}
+		})
+	}
+}
+
+func TestReportCost(t *testing.T) {
+	testCases := []struct {
+		name         string
+		instanceType string
+		expected     string
+	}{
+		{
+			name:         "known instance type",
+			instanceType: "n1-standard-2",
+			expected:     "0.38 USD",
+		},
+		{
+			name:         "unknown instance type",
+			instanceType: "custom-4-8192",
+			expected:     unknownCost,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("ARTIFACTS", dir)
+			tester := NewDefaultTester()
+			tester.InstanceType = tc.instanceType
+			tester.Images = "cos-stable,ubuntu-2204"
+			if err := tester.reportCost(2 * time.Hour); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := readMetadata(t, dir)[estimatedCostMetadataKey]; actual != tc.expected {
+				t.Errorf("expected estimated cost %q in metadata, but got %q", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// countSpecs lists the specs selected by the focus and skip regexes with a
+// dry run and prints their count to w, followed by their names with ListSpecs
+func (t *Tester) countSpecs(artifactsDir string, w io.Writer) error {
+	names, err := t.listSpecs(artifactsDir)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "%d specs selected\n", len(names)); err != nil {
+		return err
+	}
+	if !t.ListSpecs {
+		return nil
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := fmt.Fprintln(w, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCountSpecs(t *testing.T) {
+	testCases := []struct {
+		name      string
+		listSpecs bool
+		expected  string
+	}{
+		{
+			name:     "count",
+			expected: "3 specs selected\n",
+		},
+		{
+			name:      "count and list",
+			listSpecs: true,
+			expected: "3 specs selected\n" +
+				"[It] [sig-node] Pods should start\n" +
+				"[It] [sig-node] Pods should stop\n" +
+				"[It] [sig-node] Probes should restart (liveness)\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+				if !strings.Contains(argValue(t, cmd.args, "TEST_ARGS"), "--ginkgo.dry-run") {
+					t.Errorf("expected only a dry run, but got %v", cmd.args)
+				}
+				writeArtifact(t, argValue(t, cmd.env, "ARTIFACTS"), "junit_01.xml", dryRunJUnit)
+				return nil
+			}}
+			tester := NewDefaultTester()
+			tester.FocusRegex = `\[sig-node\]`
+			tester.CountSpecs = true
+			tester.ListSpecs = tc.listSpecs
+			tester.cmder = cmder
+
+			var out bytes.Buffer
+			if err := tester.countSpecs(dir, &out); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if out.String() != tc.expected {
+				t.Errorf("expected output %q, but got %q", tc.expected, out.String())
+			}
+			if len(cmder.cmds) != 1 || argValue(t, cmder.cmds[0].args, "FOCUS") != `\[sig-node\]` {
+				t.Errorf("expected a single dry run with the focus, but got %v", cmder.cmds)
+			}
+		})
+	}
+}
+
+func TestCountSpecsDoesNotRun(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ARTIFACTS", filepath.Join(dir, "artifacts"))
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		writeArtifact(t, argValue(t, cmd.env, "ARTIFACTS"), "junit_01.xml", dryRunJUnit)
+		return nil
+	}}
+	tester := NewDefaultTester()
+	tester.CountSpecs = true
+	tester.Warmup = true
+	tester.cmder = cmder
+
+	if err := tester.Test(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cmder.cmds) != 1 || !strings.Contains(argValue(t, cmder.cmds[0].args, "TEST_ARGS"), "--ginkgo.dry-run") {
+		t.Errorf("expected only the dry run, but got %v", cmder.cmds)
+	}
+}
+
+func TestListSpecsRequiresCountSpecs(t *testing.T) {
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.GCPZone = "us-central1-a"
+	tester.ListSpecs = true
+	if err := tester.validateFlags(); err == nil {
+		t.Errorf("expected --list-specs to be rejected without --count-specs")
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+const (
+	// the latest COS image is the newest image of the cos-stable family
+	defaultGCEImageFamily  = "cos-stable"
+	defaultGCEImageProject = "cos-cloud"
+	// the latest Amazon Linux 2 AMI is published as a public SSM parameter
+	defaultEC2ImageParameter = "/aws/service/ami-amazon-linux-latest/amzn2-ami-hvm-x86_64-gp2"
+)
+
+// defaultsImages reports whether Images is set to the latest default image of
+// the provider by defaultImages, which runs after the flags are validated
+func (t *Tester) defaultsImages() bool {
+	return t.Images == "" && t.ImageConfigFile == "" && !t.local() && (t.Provider == "gce" || t.Provider == "ec2")
+}
+
+// defaultImages sets Images to the latest default image of the provider when
+// neither --images nor --image-config-file is set. An explicit --image-project
+// on gce selects the project of the default image family. A dry run only logs
+// the image it would look up.
+func (t *Tester) defaultImages() error {
+	if !t.defaultsImages() {
+		return nil
+	}
+	var command []string
+	var description string
+	switch t.Provider {
+	case "gce":
+		if t.ImageProject == "" {
+			t.ImageProject = defaultGCEImageProject
+		}
+		description = fmt.Sprintf("the latest %s image in %s", defaultGCEImageFamily, t.ImageProject)
+		command = []string{"gcloud", "compute", "images", "describe-from-family", defaultGCEImageFamily,
+			"--project=" + t.ImageProject, "--format=value(name)"}
+	case "ec2":
+		description = "the latest Amazon Linux 2 AMI"
+		command = []string{"aws", "ssm", "get-parameter", "--name=" + defaultEC2ImageParameter,
+			"--query=Parameter.Value", "--output=text"}
+	}
+	if t.DryRun {
+		klog.V(0).Infof("no --images or --image-config-file given, a run would default to %s", description)
+		return nil
+	}
+	out, err := exec.Output(t.cmder.Command(command[0], command[1:]...))
+	if err != nil {
+		return fmt.Errorf("no --images or --image-config-file given and failed to find %s: %w", description, err)
+	}
+	image := strings.TrimSpace(string(out))
+	if image == "" {
+		return fmt.Errorf("no --images or --image-config-file given and found no %s", description)
+	}
+	klog.V(0).Infof("no --images or --image-config-file given, defaulting to %s: %s", description, image)
+	t.Images = image
+	return nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestDefaultImages(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		provider             string
+		images               string
+		imageConfigFile      string
+		imageProject         string
+		dryRun               bool
+		output               string
+		runErr               error
+		expectedImages       string
+		expectedImageProject string
+		expectedCommand      []string
+		expectErr            bool
+	}{
+		{
+			name:                 "latest cos image on gce",
+			provider:             "gce",
+			output:               "cos-117-18613-0-79\n",
+			expectedImages:       "cos-117-18613-0-79",
+			expectedImageProject: "cos-cloud",
+			expectedCommand:      []string{"gcloud", "compute", "images", "describe-from-family", "cos-stable", "--project=cos-cloud", "--format=value(name)"},
+		},
+		{
+			name:                 "explicit image project on gce",
+			provider:             "gce",
+			imageProject:         "my-cos-mirror",
+			output:               "cos-117-18613-0-79\n",
+			expectedImages:       "cos-117-18613-0-79",
+			expectedImageProject: "my-cos-mirror",
+			expectedCommand:      []string{"gcloud", "compute", "images", "describe-from-family", "cos-stable", "--project=my-cos-mirror", "--format=value(name)"},
+		},
+		{
+			name:            "latest al2 ami on ec2",
+			provider:        "ec2",
+			output:          "ami-0123456789abcdef0\n",
+			expectedImages:  "ami-0123456789abcdef0",
+			expectedCommand: []string{"aws", "ssm", "get-parameter", "--name=/aws/service/ami-amazon-linux-latest/amzn2-ami-hvm-x86_64-gp2", "--query=Parameter.Value", "--output=text"},
+		},
+		{
+			name:                 "explicit images win",
+			provider:             "gce",
+			images:               "ubuntu-2204",
+			imageProject:         "ubuntu-os-cloud",
+			expectedImages:       "ubuntu-2204",
+			expectedImageProject: "ubuntu-os-cloud",
+		},
+		{
+			name:            "explicit image config wins",
+			provider:        "ec2",
+			imageConfigFile: "image-config.yaml",
+		},
+		{
+			name:                 "dry run does not look up the image",
+			provider:             "gce",
+			dryRun:               true,
+			expectedImageProject: "cos-cloud",
+		},
+		{
+			name:      "default image lookup fails",
+			provider:  "gce",
+			runErr:    errors.New("permission denied"),
+			expectErr: true,
+		},
+		{
+			name:      "no default image found",
+			provider:  "ec2",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+				_, _ = io.WriteString(cmd.stdout, tc.output)
+				return tc.runErr
+			}}
+			tester := NewDefaultTester()
+			tester.Provider = tc.provider
+			tester.Images = tc.images
+			tester.ImageConfigFile = tc.imageConfigFile
+			tester.ImageProject = tc.imageProject
+			tester.DryRun = tc.dryRun
+			tester.cmder = cmder
+
+			err := tester.defaultImages()
+			if tc.expectErr {
+				if err == nil {
+					t.Error("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tester.Images != tc.expectedImages {
+				t.Errorf("expected images %q, but got %q", tc.expectedImages, tester.Images)
+			}
+			if tester.ImageProject != tc.expectedImageProject {
+				t.Errorf("expected image project %q, but got %q", tc.expectedImageProject, tester.ImageProject)
+			}
+			if tc.expectedCommand == nil {
+				if len(cmder.cmds) != 0 {
+					t.Errorf("expected no default image lookup, but got %v", cmder.cmds[0].args)
+				}
+				return
+			}
+			if len(cmder.cmds) != 1 {
+				t.Fatalf("expected one default image lookup, but got %d", len(cmder.cmds))
+			}
+			if actual := append([]string{cmder.cmds[0].name}, cmder.cmds[0].args...); !reflect.DeepEqual(actual, tc.expectedCommand) {
+				t.Errorf("expected command %q, but got %q", tc.expectedCommand, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// deletionAttempts is how many times the instances that survive their
+// deletion are deleted again before the run fails
+const deletionAttempts = 3
+
+// instanceReaper finds and deletes the test instances through the provider API
+type instanceReaper interface {
+	// ExistingInstances returns the zone of each of the instances that still exists
+	ExistingInstances(instances []string) (map[string]string, error)
+	DeleteInstances(zone string, instances []string) error
+}
+
+// gceInstanceReaper finds and deletes gce instances with gcloud
+type gceInstanceReaper struct {
+	cmder   exec.Cmder
+	clock   clock
+	ctx     context.Context
+	project string
+	stdout  io.Writer
+	stderr  io.Writer
+}
+
+var _ instanceReaper = &gceInstanceReaper{}
+
+func (g *gceInstanceReaper) ExistingInstances(instances []string) (map[string]string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := g.cmder.Command("gcloud", "compute", "instances", "list", "--project="+g.project,
+		"--filter=name=("+strings.Join(instances, " ")+")", "--format=value(name,zone.basename())")
+	cmd.SetStdout(&stdout)
+	cmd.SetStderr(&stderr)
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to list the instances: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	existing := map[string]string{}
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 {
+			existing[fields[0]] = fields[1]
+		}
+	}
+	return existing, nil
+}
+
+func (g *gceInstanceReaper) DeleteInstances(zone string, instances []string) error {
+	args := append([]string{"compute", "instances", "delete", "--quiet", "--project=" + g.project, "--zone=" + zone}, instances...)
+	return retryAuthRefresh(g.ctx, g.clock, "the deletion of the instances", func(stderr io.Writer) error {
+		cmd := g.cmder.Command("gcloud", args...)
+		exec.SetOutput(cmd, g.stdout, io.MultiWriter(g.stderr, stderr))
+		return cmd.Run()
+	})
+}
+
+// instanceReaper returns the reaper of the instances of the run, the output of
+// the deletions is observed so that they are recorded in the lifecycle
+func (t *Tester) instanceReaper(output *runOutput) instanceReaper {
+	return &gceInstanceReaper{
+		cmder:   t.cmder,
+		clock:   t.clock,
+		ctx:     t.context(),
+		project: t.GCPProject,
+		stdout:  output.watch(os.Stdout),
+		stderr:  output.watch(os.Stderr),
+	}
+}
+
+// verifyInstancesDeleted confirms through the provider API that the instances
+// the run created were deleted when the outcome of the run, runErr, means they
+// should have been, the kept instances were deliberately not deleted. The
+// instances that survived are deleted again, and the run fails if they persist.
+func (t *Tester) verifyInstancesDeleted(output *runOutput, runErr error, kept []string) error {
+	if t.keepInstances(runErr) || t.Provider != "gce" {
+		return nil
+	}
+	keptSet := map[string]bool{}
+	for _, instance := range kept {
+		keptSet[instance] = true
+	}
+	var instances []string
+	for _, instance := range createdInstances(output.lifecycle) {
+		if !keptSet[instance] {
+			instances = append(instances, instance)
+		}
+	}
+	if len(instances) == 0 {
+		return nil
+	}
+	defer output.flush()
+	return verifyDeletion(t.instanceReaper(output), instances, t.instanceDeletionLimit())
+}
+
+// instanceDeletionLimit is the most instances deleted at once, 0 if unlimited
+func (t *Tester) instanceDeletionLimit() int {
+	if t.MaxParallelInstanceDeletion > 0 {
+		return t.MaxParallelInstanceDeletion
+	}
+	return t.MaxParallelInstanceCreation
+}
+
+// deleteInstances deletes the instances of each zone in byZone. Without a
+// limit, the instances of a zone are deleted at once, otherwise each instance
+// is deleted separately with at most limit deleted at once. It is best
+// effort, failures are only logged.
+func deleteInstances(reaper instanceReaper, zones []string, byZone map[string][]string, limit int) {
+	if limit <= 0 {
+		for _, zone := range zones {
+			if err := reaper.DeleteInstances(zone, byZone[zone]); err != nil {
+				klog.Warningf("failed to delete instances %v: %v", byZone[zone], err)
+			}
+		}
+		return
+	}
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for _, zone := range zones {
+		for _, instance := range byZone[zone] {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func(zone, instance string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				if err := reaper.DeleteInstances(zone, []string{instance}); err != nil {
+					klog.Warningf("failed to delete instance %s: %v", instance, err)
+				}
+			}(zone, instance)
+		}
+	}
+	wg.Wait()
+}
+
+// verifyDeletion deletes the instances that still exist again until none are
+// left, at most deletionAttempts times, with at most limit deleted at once
+func verifyDeletion(reaper instanceReaper, instances []string, limit int) error {
+	for attempt := 1; ; attempt++ {
+		existing, err := reaper.ExistingInstances(instances)
+		if err != nil {
+			return fmt.Errorf("failed to verify the deletion of instances %v: %w", instances, err)
+		}
+		if len(existing) == 0 {
+			klog.V(1).Infof("verified the deletion of instances %v", instances)
+			return nil
+		}
+		survivors := sortedKeys(existing)
+		if attempt > deletionAttempts {
+			return fmt.Errorf("instances %v still exist after deleting them %d times, they must be deleted manually", survivors, deletionAttempts)
+		}
+		klog.Warningf("instances %v still exist after their deletion, deleting them again (attempt %d/%d)", survivors, attempt, deletionAttempts)
+		byZone := map[string][]string{}
+		for _, instance := range survivors {
+			byZone[existing[instance]] = append(byZone[existing[instance]], instance)
+		}
+		zones := make([]string, 0, len(byZone))
+		for zone := range byZone {
+			zones = append(zones, zone)
+		}
+		sort.Strings(zones)
+		deleteInstances(reaper, zones, byZone, limit)
+	}
+}
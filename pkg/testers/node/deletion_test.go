@@ -0,0 +1,281 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeInstanceReaper deletes instances from its existing instances, except
+// for the ones that survive the deletion a number of times
+type fakeInstanceReaper struct {
+	// existing maps each existing instance to its zone
+	existing map[string]string
+	// survive is how many deletions each instance survives
+	survive map[string]int
+	listErr error
+	deleted [][]string
+}
+
+func (f *fakeInstanceReaper) ExistingInstances(instances []string) (map[string]string, error) {
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	existing := map[string]string{}
+	for _, instance := range instances {
+		if zone, ok := f.existing[instance]; ok {
+			existing[instance] = zone
+		}
+	}
+	return existing, nil
+}
+
+func (f *fakeInstanceReaper) DeleteInstances(zone string, instances []string) error {
+	f.deleted = append(f.deleted, append([]string{zone}, instances...))
+	for _, instance := range instances {
+		if f.survive[instance] > 0 {
+			f.survive[instance]--
+			continue
+		}
+		delete(f.existing, instance)
+	}
+	return nil
+}
+
+func TestVerifyDeletion(t *testing.T) {
+	testCases := []struct {
+		name            string
+		reaper          *fakeInstanceReaper
+		expectedDeleted [][]string
+		expectedErr     string
+	}{
+		{
+			name:   "all instances deleted",
+			reaper: &fakeInstanceReaper{existing: map[string]string{}},
+		},
+		{
+			name: "survivor deleted by the verification",
+			reaper: &fakeInstanceReaper{
+				existing: map[string]string{"tmp-node-e2e-cos": "us-central1-b"},
+			},
+			expectedDeleted: [][]string{{"us-central1-b", "tmp-node-e2e-cos"}},
+		},
+		{
+			name: "survivor deleted on a later attempt",
+			reaper: &fakeInstanceReaper{
+				existing: map[string]string{"tmp-node-e2e-cos": "us-central1-a"},
+				survive:  map[string]int{"tmp-node-e2e-cos": 1},
+			},
+			expectedDeleted: [][]string{{"us-central1-a", "tmp-node-e2e-cos"}, {"us-central1-a", "tmp-node-e2e-cos"}},
+		},
+		{
+			name: "persistent survivor fails",
+			reaper: &fakeInstanceReaper{
+				existing: map[string]string{"tmp-node-e2e-cos": "us-central1-a"},
+				survive:  map[string]int{"tmp-node-e2e-cos": deletionAttempts},
+			},
+			expectedDeleted: [][]string{{"us-central1-a", "tmp-node-e2e-cos"}, {"us-central1-a", "tmp-node-e2e-cos"}, {"us-central1-a", "tmp-node-e2e-cos"}},
+			expectedErr:     "instances [tmp-node-e2e-cos] still exist after deleting them 3 times",
+		},
+		{
+			name:        "listing fails",
+			reaper:      &fakeInstanceReaper{listErr: errors.New("permission denied")},
+			expectedErr: "failed to verify the deletion of instances",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifyDeletion(tc.reaper, []string{"tmp-node-e2e-cos", "tmp-node-e2e-ubuntu"}, 0)
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+				t.Errorf("expected an error containing %q, but got %v", tc.expectedErr, err)
+			}
+			if !reflect.DeepEqual(tc.reaper.deleted, tc.expectedDeleted) {
+				t.Errorf("expected deletions %v, but got %v", tc.expectedDeleted, tc.reaper.deleted)
+			}
+		})
+	}
+}
+
+func TestVerifyInstancesDeletedAfterRun(t *testing.T) {
+	dir := t.TempDir()
+	listed := 0
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		if cmd.name == "make" {
+			_, _ = io.WriteString(cmd.stderr, "Created [https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/tmp-node-e2e-cos].\n")
+			return nil
+		}
+		if cmd.args[2] == "list" {
+			// the deletion during the run left the instance behind
+			listed++
+			if listed == 1 {
+				_, _ = io.WriteString(cmd.stdout, "tmp-node-e2e-cos\tus-central1-a\n")
+			}
+		}
+		return nil
+	}}
+	tester := NewDefaultTester()
+	tester.GCPProject = "p"
+	tester.GCPZone = "us-central1-a"
+	tester.cmder = cmder
+
+	if err := tester.runOnce(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var commands [][]string
+	for _, cmd := range cmder.cmds[1:] {
+		commands = append(commands, cmd.args)
+	}
+	list := []string{"compute", "instances", "list", "--project=p", "--filter=name=(tmp-node-e2e-cos)", "--format=value(name,zone.basename())"}
+	expected := [][]string{
+		list,
+		{"compute", "instances", "delete", "--quiet", "--project=p", "--zone=us-central1-a", "tmp-node-e2e-cos"},
+		list,
+	}
+	if !reflect.DeepEqual(commands, expected) {
+		t.Errorf("expected the surviving instance to be deleted again, but got commands %v", commands)
+	}
+}
+
+// countingDeleter records how many instances are being deleted at once
+type countingDeleter struct {
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	deleted     []string
+}
+
+func (c *countingDeleter) ExistingInstances(instances []string) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+func (c *countingDeleter) DeleteInstances(zone string, instances []string) error {
+	c.mu.Lock()
+	c.inFlight += len(instances)
+	if c.inFlight > c.maxInFlight {
+		c.maxInFlight = c.inFlight
+	}
+	c.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inFlight -= len(instances)
+	for _, instance := range instances {
+		c.deleted = append(c.deleted, zone+"/"+instance)
+	}
+	return nil
+}
+
+func TestDeleteInstances(t *testing.T) {
+	testCases := []struct {
+		name     string
+		limit    int
+		expected int
+	}{
+		{name: "sequential", limit: 1, expected: 1},
+		{name: "limited", limit: 3, expected: 3},
+		{name: "unlimited deletes each zone at once", limit: 0, expected: 4},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			byZone := map[string][]string{}
+			var expected []string
+			for i := 0; i < 8; i++ {
+				zone := []string{"us-central1-a", "us-central1-b"}[i%2]
+				instance := fmt.Sprintf("instance-%d", i)
+				byZone[zone] = append(byZone[zone], instance)
+				expected = append(expected, zone+"/"+instance)
+			}
+			deleter := &countingDeleter{}
+
+			deleteInstances(deleter, []string{"us-central1-a", "us-central1-b"}, byZone, tc.limit)
+
+			if deleter.maxInFlight != tc.expected {
+				t.Errorf("expected at most %d instances deleted at once, but got %d", tc.expected, deleter.maxInFlight)
+			}
+			sort.Strings(expected)
+			sort.Strings(deleter.deleted)
+			if !reflect.DeepEqual(deleter.deleted, expected) {
+				t.Errorf("expected deleted instances %v, but got %v", expected, deleter.deleted)
+			}
+		})
+	}
+}
+
+func TestInstanceDeletionLimit(t *testing.T) {
+	testCases := []struct {
+		name     string
+		creation int
+		deletion int
+		expected int
+	}{
+		{name: "unset", expected: 0},
+		{name: "defaults to the creation limit", creation: 4, expected: 4},
+		{name: "deletion limit", creation: 4, deletion: 1, expected: 1},
+	}
+
+	for _, tc := range testCases {
+		tester := NewDefaultTester()
+		tester.MaxParallelInstanceCreation = tc.creation
+		tester.MaxParallelInstanceDeletion = tc.deletion
+		if actual := tester.instanceDeletionLimit(); actual != tc.expected {
+			t.Errorf("%s: expected limit %d, but got %d", tc.name, tc.expected, actual)
+		}
+	}
+}
+
+func TestMaxParallelInstanceDeletionValidation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		provider string
+		limit    int
+	}{
+		{name: "negative", provider: "gce", limit: -1},
+		{name: "ec2", provider: "ec2", limit: 2},
+		{name: "local", provider: "local", limit: 2},
+	}
+
+	for _, tc := range testCases {
+		tester := NewDefaultTester()
+		tester.RepoRoot = fakeRepoRoot(t)
+		tester.Provider = tc.provider
+		tester.GCPZone = "us-central1-a"
+		tester.InstanceType = "m5.large"
+		tester.UserDataFile = "user-data.sh"
+		tester.MaxParallelInstanceDeletion = tc.limit
+		if err := tester.validateFlags(); err == nil {
+			t.Errorf("%s: expected --max-parallel-instance-deletion=%d to be rejected", tc.name, tc.limit)
+		}
+	}
+}
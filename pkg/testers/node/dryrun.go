@@ -0,0 +1,46 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// dryRunCommand returns the command line of the node e2e target, quoted so
+// it can be pasted into a shell
+func (t *Tester) dryRunCommand() string {
+	argv := append([]string{"make", t.makeTarget()}, t.constructArgs()...)
+	quoted := make([]string, 0, len(argv))
+	for _, arg := range argv {
+		quoted = append(quoted, shellQuote(arg))
+	}
+	return strings.Join(quoted, " ")
+}
+
+// logDryRun logs the command line of the node e2e target and the directory it
+// would run in, instead of running it
+func (t *Tester) logDryRun() {
+	klog.V(0).Infof("dry run, not running the tests in %s: %s", t.RepoRoot, t.dryRunCommand())
+	if t.createsInstances() {
+		klog.V(0).Infof("dry run, the tester would create the instances of --images and run the tests on them with HOSTS set")
+	}
+	if t.GCPProject == "" && t.Provider == "gce" {
+		klog.V(0).Infof("dry run, the GCP project would be acquired from boskos")
+	}
+}
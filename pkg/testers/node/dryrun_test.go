@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ARTIFACTS", filepath.Join(dir, "artifacts"))
+	cmder := &fakeCmder{}
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.GCPZone = "us-central1-a"
+	tester.FocusRegex = `\[NodeConformance\]`
+	tester.SkipRegex = "it's flaky"
+	tester.Warmup = true
+	tester.DryRun = true
+	tester.cmder = cmder
+	if err := tester.validateFlags(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := tester.setupProvider(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tester.boskos != nil {
+		t.Errorf("expected no project to be acquired from boskos in a dry run")
+	}
+	if err := tester.Test(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cmder.cmds) != 0 {
+		t.Errorf("expected nothing to run in a dry run, but got %v", cmder.cmds)
+	}
+
+	command := tester.dryRunCommand()
+	for _, expected := range []string{"'make' 'test-e2e-node'", `'FOCUS=\[NodeConformance\]'`, `'SKIP=it'\''s flaky'`, "'ZONE=us-central1-a'"} {
+		if !strings.Contains(command, expected) {
+			t.Errorf("expected %s in the dry run command, but got %s", expected, command)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	gcpCredentialsEnv = "GOOGLE_APPLICATION_CREDENTIALS"
+	awsCredentialsEnv = "AWS_SHARED_CREDENTIALS_FILE"
+)
+
+// cleanEnvAllowlist are the inherited environment variables kept with --clean-env,
+// the ones the build, gcloud/aws and ssh need to work
+var cleanEnvAllowlist = []string{
+	"HOME",
+	"PATH",
+	"USER",
+	"TMPDIR",
+	"GOPATH",
+	"GOROOT",
+	"GOCACHE",
+	"GOPROXY",
+	gcpCredentialsEnv,
+	"CLOUDSDK_CONFIG",
+	"AWS_PROFILE",
+	"AWS_REGION",
+	awsCredentialsEnv,
+	"KUBE_SSH_USER",
+	ciPrivateKeyEnv,
+	ciPublicKeyEnv,
+}
+
+// filterEnv returns the entries of environ whose names are in allowed
+func filterEnv(environ []string, allowed []string) []string {
+	allowedNames := map[string]bool{}
+	for _, name := range allowed {
+		allowedNames[name] = true
+	}
+	var filtered []string
+	for _, entry := range environ {
+		name, _, _ := strings.Cut(entry, "=")
+		if allowedNames[name] {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// runEnv returns the environment of the make invocation writing its artifacts to artifactsDir
+func (t *Tester) runEnv(artifactsDir string) []string {
+	env := os.Environ()
+	if t.CleanEnv {
+		env = filterEnv(env, append(append([]string{}, cleanEnvAllowlist...), t.CleanEnvAllow...))
+	}
+	env = append(env, t.credentialsEnv()...)
+	return append(env, "ARTIFACTS="+artifactsDir, runIDEnv+"="+t.runID)
+}
+
+// credentialsEnv returns the variables pointing gcloud and aws at the
+// credentials files given to the tester instead of the ambient credentials
+func (t *Tester) credentialsEnv() []string {
+	var env []string
+	if t.gcpCredentialsFile != "" {
+		env = append(env, gcpCredentialsEnv+"="+t.gcpCredentialsFile)
+	}
+	if t.awsCredentialsFile != "" {
+		env = append(env, awsCredentialsEnv+"="+t.awsCredentialsFile)
+	}
+	return env
+}
+
+// resolveFile returns the absolute path of path, which must be an existing regular file
+func resolveFile(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return "", err
+	}
+	if !info.Mode().IsRegular() {
+		return "", fmt.Errorf("%s is not a file", abs)
+	}
+	return abs, nil
+}
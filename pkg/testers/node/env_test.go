@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCleanEnv(t *testing.T) {
+	t.Setenv("PATH", "/usr/bin")
+	t.Setenv("HOME", "/home/prow")
+	t.Setenv("KUBE_SSH_USER", "core")
+	t.Setenv("EXTRA_ALLOWED", "yes")
+	t.Setenv("CONFLICTING_VAR", "stale")
+	t.Setenv("IMAGES", "from-the-environment")
+
+	testCases := []struct {
+		name       string
+		cleanEnv   bool
+		allow      []string
+		present    []string
+		notPresent []string
+	}{
+		{
+			name:    "inherits everything by default",
+			present: []string{"PATH", "HOME", "KUBE_SSH_USER", "EXTRA_ALLOWED", "CONFLICTING_VAR", "IMAGES", "ARTIFACTS", runIDEnv},
+		},
+		{
+			name:       "only allowlisted and tester set variables",
+			cleanEnv:   true,
+			present:    []string{"PATH", "HOME", "KUBE_SSH_USER", "ARTIFACTS", runIDEnv},
+			notPresent: []string{"EXTRA_ALLOWED", "CONFLICTING_VAR", "IMAGES"},
+		},
+		{
+			name:       "additional allowed variables",
+			cleanEnv:   true,
+			allow:      []string{"EXTRA_ALLOWED"},
+			present:    []string{"PATH", "HOME", "KUBE_SSH_USER", "EXTRA_ALLOWED", "ARTIFACTS", runIDEnv},
+			notPresent: []string{"CONFLICTING_VAR", "IMAGES"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			cmder := &fakeCmder{}
+			tester := NewDefaultTester()
+			tester.runID = "run"
+			tester.CleanEnv = tc.cleanEnv
+			tester.CleanEnvAllow = tc.allow
+			tester.cmder = cmder
+			if err := tester.runOnce(dir); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			names := map[string]bool{}
+			for _, entry := range cmder.cmds[0].env {
+				name, _, _ := strings.Cut(entry, "=")
+				names[name] = true
+			}
+			for _, name := range tc.present {
+				if !names[name] {
+					t.Errorf("expected %s in the env, but got %v", name, cmder.cmds[0].env)
+				}
+			}
+			for _, name := range tc.notPresent {
+				if names[name] {
+					t.Errorf("expected %s not to be in the env, but got %v", name, cmder.cmds[0].env)
+				}
+			}
+			if tc.cleanEnv {
+				for name := range names {
+					if !isAllowed(name, tc.allow) {
+						t.Errorf("unexpected variable %s in the clean env", name)
+					}
+				}
+			}
+			if actual := argValue(t, cmder.cmds[0].env, "ARTIFACTS"); actual != dir {
+				t.Errorf("expected ARTIFACTS=%s, but got %s", dir, actual)
+			}
+		})
+	}
+}
+
+func isAllowed(name string, allow []string) bool {
+	for _, allowed := range append(append([]string{"ARTIFACTS", runIDEnv}, cleanEnvAllowlist...), allow...) {
+		if name == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCredentialsFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, dir, "gcp.json", `{"type": "service_account"}`)
+	writeArtifact(t, dir, "aws-credentials", "[default]\n")
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "/ambient/gcp.json")
+
+	testCases := []struct {
+		name      string
+		gcpFile   string
+		awsFile   string
+		cleanEnv  bool
+		expected  map[string]string
+		expectErr bool
+	}{
+		{
+			name:     "ambient credentials by default",
+			expected: map[string]string{"GOOGLE_APPLICATION_CREDENTIALS": "/ambient/gcp.json"},
+		},
+		{
+			name:    "credentials files are exported",
+			gcpFile: filepath.Join(dir, "gcp.json"),
+			awsFile: filepath.Join(dir, "aws-credentials"),
+			expected: map[string]string{
+				"GOOGLE_APPLICATION_CREDENTIALS": filepath.Join(dir, "gcp.json"),
+				"AWS_SHARED_CREDENTIALS_FILE":    filepath.Join(dir, "aws-credentials"),
+			},
+		},
+		{
+			name:     "credentials files are exported with a clean env",
+			gcpFile:  filepath.Join(dir, "gcp.json"),
+			cleanEnv: true,
+			expected: map[string]string{"GOOGLE_APPLICATION_CREDENTIALS": filepath.Join(dir, "gcp.json")},
+		},
+		{
+			name:      "missing gcp credentials file",
+			gcpFile:   filepath.Join(dir, "missing.json"),
+			expectErr: true,
+		},
+		{
+			name:      "aws credentials file is a directory",
+			awsFile:   dir,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := &fakeCmder{}
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.GCPZone = "us-central1-a"
+			tester.GCPCredentialsFile = tc.gcpFile
+			tester.AWSCredentialsFile = tc.awsFile
+			tester.CleanEnv = tc.cleanEnv
+			tester.cmder = cmder
+			err := tester.validateFlags()
+			if tc.expectErr {
+				if err == nil {
+					t.Error("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := tester.runOnce(t.TempDir()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for name, expected := range tc.expected {
+				if actual := argValue(t, cmder.cmds[0].env, name); actual != expected {
+					t.Errorf("expected %s=%s in the env, but got %s", name, expected, actual)
+				}
+			}
+		})
+	}
+}
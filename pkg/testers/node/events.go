@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	eventsDirName = "events"
+
+	// nodeEventsCommand prints the events the kubelet recorded during the run,
+	// which it logs as they occur. The node e2e kubelet runs as a transient
+	// kubelet-<id> unit, and grep fails when there are no events.
+	nodeEventsCommand = `sudo journalctl --no-pager -o short-precise -u 'kubelet*' | grep -F 'Event occurred' || true`
+)
+
+// eventsCollectionTimeout bounds the collection of the events of all the
+// instances, the run may already have been cancelled
+var eventsCollectionTimeout = 2 * time.Minute
+
+// eventSource writes the events recorded on an instance to w
+type eventSource interface {
+	Events(ctx context.Context, instance string, w io.Writer) error
+}
+
+// sshEventSource reads the events from the kubelet journal over SSH
+type sshEventSource struct {
+	transport SSHTransport
+}
+
+var _ eventSource = &sshEventSource{}
+
+func (s *sshEventSource) Events(ctx context.Context, instance string, w io.Writer) error {
+	var stderr bytes.Buffer
+	if err := s.transport.Exec(ctx, instance, nodeEventsCommand, w, &stderr); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// collectEvents writes the events of each instance to events/<instance>.log
+// in artifactsDir. It is best effort, failures are only logged.
+func collectEvents(artifactsDir string, source eventSource, instances []string) {
+	if len(instances) == 0 {
+		return
+	}
+	dir := filepath.Join(artifactsDir, eventsDirName)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		klog.Warningf("failed to create the events directory: %v", err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), eventsCollectionTimeout)
+	defer cancel()
+	for _, instance := range instances {
+		var events bytes.Buffer
+		if err := source.Events(ctx, instance, &events); err != nil {
+			klog.Warningf("failed to collect the events of instance %s: %v", instance, err)
+			continue
+		}
+		path := filepath.Join(dir, instance+".log")
+		if err := os.WriteFile(path, events.Bytes(), 0644); err != nil {
+			klog.Warningf("failed to write the events of instance %s: %v", instance, err)
+			continue
+		}
+		klog.V(0).Infof("collected the events of instance %s to %s", instance, path)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeEventSource returns the events of each instance, or fails for
+// instances without events
+type fakeEventSource struct {
+	events map[string]string
+}
+
+func (f *fakeEventSource) Events(ctx context.Context, instance string, w io.Writer) error {
+	events, ok := f.events[instance]
+	if !ok {
+		return errors.New("connection refused")
+	}
+	_, err := io.WriteString(w, events)
+	return err
+}
+
+func TestCollectEvents(t *testing.T) {
+	dir := t.TempDir()
+	source := &fakeEventSource{events: map[string]string{
+		"tmp-node-e2e-cos-1234": `I0102 03:04:05.000000 event.go:389] "Event occurred" object="default/pod" reason="BackOff"` + "\n",
+	}}
+	collectEvents(dir, source, []string{"tmp-node-e2e-cos-1234", "tmp-node-e2e-ubuntu-1234"})
+
+	data, err := os.ReadFile(filepath.Join(dir, eventsDirName, "tmp-node-e2e-cos-1234.log"))
+	if err != nil {
+		t.Fatalf("failed to read the events: %v", err)
+	}
+	if string(data) != source.events["tmp-node-e2e-cos-1234"] {
+		t.Errorf("expected events %q, but got %q", source.events["tmp-node-e2e-cos-1234"], data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, eventsDirName, "tmp-node-e2e-ubuntu-1234.log")); !os.IsNotExist(err) {
+		t.Errorf("expected no events file for the unreachable instance, but got %v", err)
+	}
+}
+
+func TestCollectEventsOnFailure(t *testing.T) {
+	const events = `I0102 03:04:05.000000 event.go:389] "Event occurred" object="default/pod" reason="Failed"` + "\n"
+	testCases := []struct {
+		name            string
+		runErr          error
+		expectedEvents  []string
+		expectedDeleted []string
+	}{
+		{
+			name:            "failing run",
+			runErr:          errors.New("specs failed"),
+			expectedEvents:  []string{"tmp-node-e2e-ubuntu-1234"},
+			expectedDeleted: []string{"tmp-node-e2e-ubuntu-1234"},
+		},
+		{
+			name:            "passing run",
+			expectedDeleted: []string{"tmp-node-e2e-ubuntu-1234"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			var deleted []string
+			cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+				switch {
+				case cmd.name == "make":
+					_, _ = io.WriteString(cmd.stderr, lifecycleOutput)
+					return tc.runErr
+				case cmd.args[1] == "ssh":
+					if !strings.HasSuffix(cmd.args[4], "@tmp-node-e2e-ubuntu-1234") || cmd.args[5] != "--command="+nodeEventsCommand {
+						return fmt.Errorf("unexpected ssh %v", cmd.args)
+					}
+					_, _ = io.WriteString(cmd.stdout, events)
+				default:
+					deleted = append(deleted, cmd.args[6:]...)
+				}
+				return nil
+			}}
+			tester := NewDefaultTester()
+			tester.GCPProject = "p"
+			tester.GCPZone = "us-central1-a"
+			tester.CollectEvents = true
+			tester.clock = newFakeClock(time.Minute)
+			tester.cmder = cmder
+
+			if err := tester.runOnce(dir); !errors.Is(err, tc.runErr) {
+				t.Fatalf("expected error %v, but got %v", tc.runErr, err)
+			}
+			if actual := argValue(t, cmder.cmds[0].args, "DELETE_INSTANCES"); actual != "false" {
+				t.Errorf("expected the instances to be kept for the events, but got DELETE_INSTANCES=%s", actual)
+			}
+			var collected []string
+			entries, _ := os.ReadDir(filepath.Join(dir, eventsDirName))
+			for _, entry := range entries {
+				data, err := os.ReadFile(filepath.Join(dir, eventsDirName, entry.Name()))
+				if err != nil || string(data) != events {
+					t.Errorf("expected events %q in %s, but got %q (%v)", events, entry.Name(), data, err)
+				}
+				collected = append(collected, strings.TrimSuffix(entry.Name(), ".log"))
+			}
+			if !reflect.DeepEqual(collected, tc.expectedEvents) {
+				t.Errorf("expected the events of %v, but got %v", tc.expectedEvents, collected)
+			}
+			if !reflect.DeepEqual(deleted, tc.expectedDeleted) {
+				t.Errorf("expected deleted instances %v, but got %v", tc.expectedDeleted, deleted)
+			}
+		})
+	}
+}
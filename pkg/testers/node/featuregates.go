@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var featureGateNameRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
+
+// featureGateCombination is a single labeled entry of a feature gate matrix
+type featureGateCombination struct {
+	label string
+	gates string
+}
+
+// parseFeatureGates parses a comma-separated list of Gate=true|false pairs
+func parseFeatureGates(gates string) (map[string]bool, error) {
+	parsed := map[string]bool{}
+	for _, entry := range strings.Split(gates, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid feature gate %q, expected Gate=true|false", entry)
+		}
+		if !featureGateNameRegex.MatchString(name) {
+			return nil, fmt.Errorf("invalid feature gate name %q", name)
+		}
+		switch value {
+		case "true":
+			parsed[name] = true
+		case "false":
+			parsed[name] = false
+		default:
+			return nil, fmt.Errorf("invalid value %q for feature gate %s, expected true or false", value, name)
+		}
+	}
+	return parsed, nil
+}
+
+// formatFeatureGates formats the gates as a comma-separated list sorted by name
+func formatFeatureGates(gates map[string]bool) string {
+	names := make([]string, 0, len(gates))
+	for name := range gates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	entries := make([]string, 0, len(names))
+	for _, name := range names {
+		entries = append(entries, fmt.Sprintf("%s=%t", name, gates[name]))
+	}
+	return strings.Join(entries, ",")
+}
+
+// mergeFeatureGates returns base with the gates from override applied on top
+func mergeFeatureGates(base, override string) (string, error) {
+	merged, err := parseFeatureGates(base)
+	if err != nil {
+		return "", err
+	}
+	overrides, err := parseFeatureGates(override)
+	if err != nil {
+		return "", err
+	}
+	for name, value := range overrides {
+		merged[name] = value
+	}
+	return formatFeatureGates(merged), nil
+}
+
+// loadFeatureGateMatrix reads a feature gate matrix file. Each non-empty line
+// that does not start with # is a single combination, optionally prefixed
+// by a "label:" used to name the sub-run, e.g.
+//
+//	baseline: GateA=false,GateB=false
+//	GateA=true,GateB=false
+//
+// Combinations without a label are named combination-<line number>.
+func loadFeatureGateMatrix(path string) ([]featureGateCombination, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open feature gate matrix: %w", err)
+	}
+	defer f.Close()
+
+	var matrix []featureGateCombination
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(f)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		combination := featureGateCombination{
+			label: fmt.Sprintf("combination-%d", lineNumber),
+			gates: line,
+		}
+		if label, gates, found := strings.Cut(line, ":"); found {
+			combination.label = strings.TrimSpace(label)
+			combination.gates = strings.TrimSpace(gates)
+		}
+		if !subRunLabelRegex.MatchString(combination.label) {
+			return nil, fmt.Errorf("invalid label %q on line %d of %s", combination.label, lineNumber, path)
+		}
+		if seen[combination.label] {
+			return nil, fmt.Errorf("duplicate label %q on line %d of %s", combination.label, lineNumber, path)
+		}
+		seen[combination.label] = true
+		gates, err := parseFeatureGates(combination.gates)
+		if err != nil {
+			return nil, fmt.Errorf("line %d of %s: %w", lineNumber, path, err)
+		}
+		combination.gates = formatFeatureGates(gates)
+		matrix = append(matrix, combination)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read feature gate matrix: %w", err)
+	}
+	if len(matrix) == 0 {
+		return nil, fmt.Errorf("feature gate matrix %s has no combinations", path)
+	}
+	return matrix, nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFeatureGates(t *testing.T) {
+	testCases := []struct {
+		name             string
+		testArgs         string
+		featureGates     string
+		expectErr        bool
+		expectedTestArgs string
+	}{
+		{
+			name:             "no feature gates",
+			testArgs:         "--foo=bar",
+			expectedTestArgs: "--foo=bar",
+		},
+		{
+			name:             "single gate",
+			featureGates:     "GateA=true",
+			expectedTestArgs: "--feature-gates=GateA=true",
+		},
+		{
+			name:             "multiple gates with test args",
+			testArgs:         "--foo=bar",
+			featureGates:     "GateA=true,GateB=false",
+			expectedTestArgs: "--foo=bar --feature-gates=GateA=true,GateB=false",
+		},
+		{
+			name:         "missing value",
+			featureGates: "GateA",
+			expectErr:    true,
+		},
+		{
+			name:         "non boolean value",
+			featureGates: "GateA=yes",
+			expectErr:    true,
+		},
+		{
+			name:         "invalid gate name",
+			featureGates: "Gate-A=true",
+			expectErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.GCPZone = "us-central1-a"
+			tester.TestArgs = tc.testArgs
+			tester.FeatureGates = tc.featureGates
+			err := tester.validateFlags()
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error for feature gates %q but got none", tc.featureGates)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := argValue(t, tester.constructArgs(), "TEST_ARGS"); actual != tc.expectedTestArgs {
+				t.Errorf("expected TEST_ARGS=%q, but got %q", tc.expectedTestArgs, actual)
+			}
+		})
+	}
+}
+
+func TestFeatureGateMatrix(t *testing.T) {
+	dir := t.TempDir()
+	matrixPath := filepath.Join(dir, "matrix.txt")
+	matrix := strings.Join([]string{
+		"# feature gate combinations",
+		"baseline: GateA=false",
+		"",
+		"GateB=true,GateA=true",
+	}, "\n")
+	if err := os.WriteFile(matrixPath, []byte(matrix), 0644); err != nil {
+		t.Fatalf("failed to write matrix: %v", err)
+	}
+
+	artifactsDir := filepath.Join(dir, "artifacts")
+	t.Setenv("ARTIFACTS", artifactsDir)
+	cmder := &fakeCmder{}
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.GCPZone = "us-central1-a"
+	tester.FeatureGates = "GateC=true"
+	tester.FeatureGateMatrix = matrixPath
+	tester.cmder = cmder
+	if err := tester.validateFlags(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := tester.Test(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	type invocation struct {
+		testArgs  string
+		artifacts string
+	}
+	expected := []invocation{
+		{
+			testArgs:  "--feature-gates=GateA=false,GateC=true",
+			artifacts: filepath.Join(artifactsDir, "baseline"),
+		},
+		{
+			testArgs:  "--feature-gates=GateA=true,GateB=true,GateC=true",
+			artifacts: filepath.Join(artifactsDir, "combination-4"),
+		},
+	}
+	var actual []invocation
+	for _, cmd := range cmder.cmds {
+		actual = append(actual, invocation{
+			testArgs:  argValue(t, cmd.args, "TEST_ARGS"),
+			artifacts: argValue(t, cmd.env, "ARTIFACTS"),
+		})
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected invocations %+v, but got %+v", expected, actual)
+	}
+}
+
+func TestLoadFeatureGateMatrixErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		matrix string
+	}{
+		{
+			name:   "empty",
+			matrix: "# nothing here\n",
+		},
+		{
+			name:   "invalid gate",
+			matrix: "GateA=maybe\n",
+		},
+		{
+			name:   "duplicate label",
+			matrix: "a: GateA=true\na: GateA=false\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "matrix.txt")
+			if err := os.WriteFile(path, []byte(tc.matrix), 0644); err != nil {
+				t.Fatalf("failed to write matrix: %v", err)
+			}
+			if _, err := loadFeatureGateMatrix(path); err == nil {
+				t.Errorf("expected an error but got none")
+			}
+		})
+	}
+}
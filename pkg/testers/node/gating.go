@@ -0,0 +1,47 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"k8s.io/klog/v2"
+)
+
+// gatingMetadataKey records in metadata.json whether the run is gating
+const gatingMetadataKey = "gating"
+
+// rejectFlakes fails a gating run with specs that only passed after failing,
+// which a non-gating run tolerates. runErr is the outcome of the run so far.
+func (t *Tester) rejectFlakes(artifactsDir string, runErr error) error {
+	if runErr != nil {
+		return runErr
+	}
+	results, err := t.results(artifactsDir)
+	if err != nil {
+		klog.Warningf("failed to parse test results: %v", err)
+		return nil
+	}
+	flaky := results.flakySpecs()
+	if len(flaky) == 0 {
+		return nil
+	}
+	for _, name := range flaky {
+		klog.Errorf("flaky spec in a gating run: %s", name)
+	}
+	return fmt.Errorf("%d specs only passed after failing, which a gating run does not tolerate", len(flaky))
+}
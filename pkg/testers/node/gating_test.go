@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+const flakyJUnit = `<testsuite name="E2eNode Suite">
+  <testcase name="[It] flaky" status="passed" time="3"><failure message="attempt 1 failed"></failure></testcase>
+  <testcase name="[It] stable" status="passed" time="1"></testcase>
+</testsuite>`
+
+func TestGating(t *testing.T) {
+	runErr := errors.New("make failed")
+	testCases := []struct {
+		name          string
+		junit         string
+		runErr        error
+		knownFailures string
+		gatingErr     bool
+		nonGatingErr  bool
+	}{
+		{
+			name:  "clean run",
+			junit: `<testsuite><testcase name="[It] stable"></testcase></testsuite>`,
+		},
+		{
+			name:      "flaky with flake attempts",
+			junit:     flakyJUnit,
+			gatingErr: true,
+		},
+		{
+			name:          "only known failures",
+			junit:         sampleJUnit,
+			runErr:        runErr,
+			knownFailures: "[It] known flake\n[It] regression\n",
+			gatingErr:     true,
+		},
+		{
+			name:         "new failures",
+			junit:        sampleJUnit,
+			runErr:       runErr,
+			gatingErr:    true,
+			nonGatingErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		for _, gating := range []bool{true, false} {
+			gating := gating
+			t.Run(fmt.Sprintf("%s gating=%v", tc.name, gating), func(t *testing.T) {
+				dir := t.TempDir()
+				writeArtifact(t, dir, "junit_01.xml", tc.junit)
+				tester := NewDefaultTester()
+				tester.Gating = gating
+				if tc.knownFailures != "" {
+					writeArtifact(t, dir, "known-failures.txt", tc.knownFailures)
+					known, err := loadSpecList(filepath.Join(dir, "known-failures.txt"))
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					tester.knownFailures = known
+				}
+
+				err := tester.processResults(dir, tc.runErr)
+				if tester.Gating {
+					err = tester.rejectFlakes(dir, err)
+				}
+				expectErr := tc.nonGatingErr
+				if gating {
+					expectErr = tc.gatingErr
+				}
+				if expectErr && err == nil {
+					t.Errorf("expected the run to fail with gating=%v", gating)
+				}
+				if !expectErr && err != nil {
+					t.Errorf("expected the run to pass with gating=%v, but got %v", gating, err)
+				}
+			})
+		}
+	}
+}
+
+func TestGatingMetadata(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ARTIFACTS", dir)
+	tester := NewDefaultTester()
+	tester.Gating = true
+	tester.runID = "8f14e45f-ceea-467f-a8b0-12c0a1b2c3d4"
+	if err := tester.writeMetadata(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual := readMetadata(t, dir)[gatingMetadataKey]; actual != "true" {
+		t.Errorf("expected gating=true in the metadata, but got %q", actual)
+	}
+}
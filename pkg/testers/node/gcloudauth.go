@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// authRefreshFailedRegex matches the errors gcloud prints when refreshing its
+// access token fails. These are usually transient on long runs, unlike the
+// other gcloud errors the operation is worth retrying after a short wait.
+var authRefreshFailedRegex = regexp.MustCompile(`There was a problem refreshing your current auth tokens|Failed to retrieve access token|RefreshError|oauth2: cannot fetch token`)
+
+const (
+	// authRefreshAttempts is how many times a gcloud operation is attempted
+	// when its auth token refresh fails
+	authRefreshAttempts = 3
+	// authRefreshBackoff is the wait before retrying the operation
+	authRefreshBackoff = 10 * time.Second
+)
+
+// retryAuthRefresh runs a gcloud operation, retrying it after authRefreshBackoff
+// if it fails because refreshing the auth token failed. run is passed a writer
+// to copy the stderr of the operation to, to detect the failure from.
+func retryAuthRefresh(ctx context.Context, clk clock, operation string, run func(stderr io.Writer) error) error {
+	for attempt := 1; ; attempt++ {
+		var stderr bytes.Buffer
+		err := run(&stderr)
+		if err == nil || !authRefreshFailedRegex.Match(stderr.Bytes()) {
+			return err
+		}
+		if attempt == authRefreshAttempts {
+			return fmt.Errorf("failed to refresh the gcloud auth token %d times: %w", attempt, err)
+		}
+		klog.Warningf("refreshing the gcloud auth token failed during %s, retrying in %s", operation, authRefreshBackoff)
+		select {
+		case <-clk.After(authRefreshBackoff):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
+
+// checkGCloudAuth fails early when gcloud has no active account on gce, which
+// would otherwise fail the run partway through provisioning. It is skipped
+// with --skip-auth-check and for a dry run, which runs no gcloud commands.
+func (t *Tester) checkGCloudAuth() error {
+	if t.Provider != "gce" || t.SkipAuthCheck || t.DryRun {
+		return nil
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := t.cmder.Command("gcloud", "auth", "list", "--filter=status:ACTIVE", "--format=value(account)")
+	cmd.SetStdout(&stdout)
+	cmd.SetStderr(&stderr)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to list the gcloud accounts: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	account := strings.TrimSpace(stdout.String())
+	if account == "" {
+		return fmt.Errorf("gcloud has no active account, authenticate with 'gcloud auth login' or 'gcloud auth activate-service-account', " +
+			"or set --skip-auth-check to use application default credentials")
+	}
+	klog.V(1).Infof("gcloud is authenticated as %s", account)
+	return nil
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+const tokenRefreshError = `ERROR: (gcloud.compute.instances.create) There was a problem refreshing your current auth tokens: ('Unable to acquire impersonated credentials', '{"error": {"code": 503}}')
+Please run:
+
+  $ gcloud auth login
+`
+
+func TestRetryAuthRefresh(t *testing.T) {
+	testCases := []struct {
+		name          string
+		failures      int
+		stderr        string
+		expectedCalls int
+		expectErr     bool
+	}{
+		{
+			name:          "succeeds after token refresh failures",
+			failures:      2,
+			stderr:        tokenRefreshError,
+			expectedCalls: 3,
+		},
+		{
+			name:          "token refresh keeps failing",
+			failures:      authRefreshAttempts,
+			stderr:        tokenRefreshError,
+			expectedCalls: authRefreshAttempts,
+			expectErr:     true,
+		},
+		{
+			name:          "other errors are not retried",
+			failures:      1,
+			stderr:        "ERROR: (gcloud.compute.instances.create) Quota 'CPUS' exceeded.\n",
+			expectedCalls: 1,
+			expectErr:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			clock := newFakeClock(0)
+			var mu sync.Mutex
+			calls := 0
+			done := make(chan error)
+			go func() {
+				done <- retryAuthRefresh(context.Background(), clock, "the test", func(stderr io.Writer) error {
+					mu.Lock()
+					defer mu.Unlock()
+					calls++
+					if calls <= tc.failures {
+						_, _ = io.WriteString(stderr, tc.stderr)
+						return errors.New("exit status 1")
+					}
+					return nil
+				})
+			}()
+			var err error
+			for finished := false; !finished; {
+				select {
+				case err = <-done:
+					finished = true
+				default:
+					if clock.HasWaiters() {
+						clock.Advance(authRefreshBackoff)
+					}
+					time.Sleep(time.Millisecond)
+				}
+			}
+
+			if tc.expectErr != (err != nil) {
+				t.Errorf("expected error %v, but got %v", tc.expectErr, err)
+			}
+			if calls != tc.expectedCalls {
+				t.Errorf("expected %d attempts, but got %d", tc.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestCreateInstanceRetriesAuthRefresh(t *testing.T) {
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		if cmd.cmder.count() == 1 {
+			_, _ = io.WriteString(cmd.stderr, tokenRefreshError)
+			return errors.New("exit status 1")
+		}
+		return nil
+	}}
+	clock := newFakeClock(0)
+	creator := &gceInstanceCreator{cmder: cmder, clock: clock, project: "p", zone: "us-central1-a"}
+	done := make(chan error)
+	go func() {
+		done <- creator.CreateInstance(context.Background(), pendingInstance{name: "tmp-node-e2e-cos", image: "cos", stdout: io.Discard, stderr: io.Discard})
+	}()
+	waitFor(t, clock.HasWaiters)
+	clock.Advance(authRefreshBackoff)
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cmder.cmds) != 2 {
+		t.Errorf("expected the instance creation to be retried once, but got %d attempts", len(cmder.cmds))
+	}
+}
+
+func TestCheckGCloudAuth(t *testing.T) {
+	testCases := []struct {
+		name          string
+		provider      string
+		skipAuthCheck bool
+		dryRun        bool
+		accounts      string
+		listErr       error
+		expectCheck   bool
+		expectErr     bool
+	}{
+		{
+			name:        "active account",
+			provider:    "gce",
+			accounts:    "prow@k8s-infra-prow-build.iam.gserviceaccount.com\n",
+			expectCheck: true,
+		},
+		{
+			name:        "no active account",
+			provider:    "gce",
+			expectCheck: true,
+			expectErr:   true,
+		},
+		{
+			name:        "gcloud fails",
+			provider:    "gce",
+			listErr:     errors.New("exit status 1"),
+			expectCheck: true,
+			expectErr:   true,
+		},
+		{
+			name:          "skipped",
+			provider:      "gce",
+			skipAuthCheck: true,
+		},
+		{
+			name:     "dry run",
+			provider: "gce",
+			dryRun:   true,
+		},
+		{
+			name:     "ec2",
+			provider: "ec2",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+				_, _ = io.WriteString(cmd.stdout, tc.accounts)
+				return tc.listErr
+			}}
+			tester := NewDefaultTester()
+			tester.Provider = tc.provider
+			tester.SkipAuthCheck = tc.skipAuthCheck
+			tester.DryRun = tc.dryRun
+			tester.cmder = cmder
+
+			err := tester.checkGCloudAuth()
+			if tc.expectErr != (err != nil) {
+				t.Errorf("expected error %t, but got %v", tc.expectErr, err)
+			}
+			if checked := len(cmder.cmds) > 0; checked != tc.expectCheck {
+				t.Fatalf("expected the check to run %t, but got commands %v", tc.expectCheck, cmder.cmds)
+			}
+			if tc.expectCheck {
+				expected := []string{"gcloud", "auth", "list", "--filter=status:ACTIVE", "--format=value(account)"}
+				if actual := append([]string{cmder.cmds[0].name}, cmder.cmds[0].args...); !reflect.DeepEqual(actual, expected) {
+					t.Errorf("expected %v, but got %v", expected, actual)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// infraError reports whether the sub-run failed with an infra error rather
+// than a test failure, i.e. it failed without any failed spec in its results
+func (r subRunResult) infraError() bool {
+	return r.Error != "" && r.Failed == 0
+}
+
+// haltsAfter reports whether the sub-runs after the one with result are
+// skipped, which they are with --halt-on-infra-error after an infra error
+func (t *Tester) haltsAfter(result subRunResult) bool {
+	return t.HaltOnInfraError && result.infraError()
+}
+
+// haltSubRuns records the remaining sub-runs, skipped after the infra error of
+// the sub-run labeled label, as not run and returns the error reporting them.
+// Nothing is skipped after the last sub-run.
+func haltSubRuns(label string, remaining []subRun, results []subRunResult) ([]subRunResult, error) {
+	if len(remaining) == 0 {
+		return results, nil
+	}
+	var skipped []string
+	for _, r := range remaining {
+		skipped = append(skipped, r.label)
+		results = append(results, subRunResult{Label: r.label, NotRun: true})
+	}
+	klog.Errorf("sub-run %s failed with an infra error, skipping the %d remaining sub-runs: %s", label, len(skipped), strings.Join(skipped, ", "))
+	return results, fmt.Errorf("skipped the %d remaining sub-runs after the infra error of sub-run %s: %s", len(skipped), label, strings.Join(skipped, ", "))
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHaltOnInfraError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		halt        bool
+		infraError  bool
+		expectedRun []string
+		notRun      []string
+	}{
+		{
+			name:        "infra error halts the matrix",
+			halt:        true,
+			infraError:  true,
+			expectedRun: []string{"containerd", "crio"},
+			notRun:      []string{"docker"},
+		},
+		{
+			name:        "test failure does not halt the matrix",
+			halt:        true,
+			expectedRun: []string{"containerd", "crio", "docker"},
+		},
+		{
+			name:        "infra error without the flag",
+			infraError:  true,
+			expectedRun: []string{"containerd", "crio", "docker"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("ARTIFACTS", dir)
+			cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+				artifactsDir := argValue(t, cmd.env, "ARTIFACTS")
+				if filepath.Base(artifactsDir) != "crio" {
+					writeArtifact(t, artifactsDir, "junit_01.xml", `<testsuite><testcase name="a"/></testsuite>`)
+					return nil
+				}
+				if tc.infraError {
+					return errors.New("failed to create instances")
+				}
+				writeArtifact(t, artifactsDir, "junit_01.xml", `<testsuite><testcase name="a"><failure message="failed"/></testcase></testsuite>`)
+				return errors.New("specs failed")
+			}}
+			tester := NewDefaultTester()
+			tester.runtimeEndpoints = []runtimeEndpoint{
+				{label: "containerd", endpoint: "unix:///run/containerd/containerd.sock"},
+				{label: "crio", endpoint: "unix:///var/run/crio/crio.sock"},
+				{label: "docker", endpoint: "unix:///var/run/cri-dockerd.sock"},
+			}
+			tester.HaltOnInfraError = tc.halt
+			tester.cmder = cmder
+
+			err := tester.Test()
+			if err == nil {
+				t.Fatal("expected the crio sub-run to fail the run")
+			}
+			for _, label := range tc.notRun {
+				if !strings.Contains(err.Error(), "skipped the 1 remaining sub-runs after the infra error of sub-run crio: "+label) {
+					t.Errorf("expected the error to report that %s was skipped, but got: %v", label, err)
+				}
+			}
+
+			var run []string
+			for _, cmd := range cmder.cmds {
+				run = append(run, filepath.Base(argValue(t, cmd.env, "ARTIFACTS")))
+			}
+			if !reflect.DeepEqual(run, tc.expectedRun) {
+				t.Errorf("expected sub-runs %v to run, but got %v", tc.expectedRun, run)
+			}
+
+			data, err := os.ReadFile(filepath.Join(dir, subRunResultsFileName))
+			if err != nil {
+				t.Fatalf("failed to read the sub-run results: %v", err)
+			}
+			var results []subRunResult
+			if err := json.Unmarshal(data, &results); err != nil {
+				t.Fatalf("failed to parse the sub-run results: %v", err)
+			}
+			var notRun []string
+			for _, result := range results {
+				if result.NotRun {
+					notRun = append(notRun, result.Label)
+				}
+			}
+			if len(results) != 3 || !reflect.DeepEqual(notRun, tc.notRun) {
+				t.Errorf("expected %v to be recorded as not run, but got %+v", tc.notRun, results)
+			}
+		})
+	}
+}
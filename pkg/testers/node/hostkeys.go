@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+var (
+	hostKeyVerificationFailedRegex = regexp.MustCompile(`Host key verification failed`)
+	// ssh suggests how to remove the offending entry when a host key changes, e.g.
+	//   ssh-keygen -f "/root/.ssh/google_compute_known_hosts" -R "compute.1234"
+	removeHostKeyRegex = regexp.MustCompile(`ssh-keygen -f ["']?([^"'\s]+)["']? -R ["']?([^"'\s]+)["']?`)
+)
+
+// staleHostKey is a known_hosts entry that no longer matches the host
+type staleHostKey struct {
+	knownHostsFile string
+	host           string
+}
+
+func parseStaleHostKey(line string) (staleHostKey, bool) {
+	match := removeHostKeyRegex.FindStringSubmatch(line)
+	if match == nil {
+		return staleHostKey{}, false
+	}
+	return staleHostKey{knownHostsFile: match[1], host: match[2]}, true
+}
+
+func appendStaleHostKey(keys []staleHostKey, key staleHostKey) []staleHostKey {
+	for _, k := range keys {
+		if k == key {
+			return keys
+		}
+	}
+	return append(keys, key)
+}
+
+// staleHostKeyError is returned when a run failed because of stale ssh host keys,
+// typically because an instance name was reused and got a new host key
+type staleHostKeyError struct {
+	inner   error
+	keys    []staleHostKey
+	cleared bool
+}
+
+func (e *staleHostKeyError) Error() string {
+	hosts := make([]string, 0, len(e.keys))
+	for _, key := range e.keys {
+		hosts = append(hosts, key.host)
+	}
+	msg := fmt.Sprintf("ssh host key verification failed for %s", strings.Join(hosts, ", "))
+	switch {
+	case len(e.keys) == 0:
+		msg = "ssh host key verification failed, the known_hosts file likely contains stale entries for reused instance names"
+	case e.cleared:
+		msg += ", the stale known_hosts entries have been removed and the tests can be rerun"
+	default:
+		removals := make([]string, 0, len(e.keys))
+		for _, key := range e.keys {
+			removals = append(removals, fmt.Sprintf("ssh-keygen -f %q -R %q", key.knownHostsFile, key.host))
+		}
+		msg += fmt.Sprintf(", remove the stale known_hosts entries with `%s` or rerun with --clear-stale-host-keys", strings.Join(removals, " && "))
+	}
+	return fmt.Sprintf("%s: %v", msg, e.inner)
+}
+
+func (e *staleHostKeyError) Unwrap() error {
+	return e.inner
+}
+
+// checkStaleHostKeys turns a run failure caused by stale host keys into an
+// actionable error, clearing the stale entries if configured to do so
+func (t *Tester) checkStaleHostKeys(runErr error, output *runOutput) error {
+	if !output.hostKeyVerificationFailed {
+		return runErr
+	}
+	hostKeyErr := &staleHostKeyError{inner: runErr, keys: output.staleHostKeys}
+	if t.ClearStaleHostKeys && len(output.staleHostKeys) > 0 {
+		hostKeyErr.cleared = true
+		for _, key := range output.staleHostKeys {
+			klog.V(0).Infof("removing stale host key for %s from %s", key.host, key.knownHostsFile)
+			cmd := t.cmder.Command("ssh-keygen", "-f", key.knownHostsFile, "-R", key.host)
+			exec.InheritOutput(cmd)
+			if err := cmd.Run(); err != nil {
+				klog.Warningf("failed to remove stale host key for %s: %v", key.host, err)
+				hostKeyErr.cleared = false
+			}
+		}
+	}
+	return hostKeyErr
+}
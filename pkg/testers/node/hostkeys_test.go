@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const hostKeyMismatchOutput = `@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@
+@    WARNING: REMOTE HOST IDENTIFICATION HAS CHANGED!     @
+@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@
+IT IS POSSIBLE THAT SOMEONE IS DOING SOMETHING NASTY!
+Offending ECDSA key in /root/.ssh/google_compute_known_hosts:3
+  remove with:
+  ssh-keygen -f "/root/.ssh/google_compute_known_hosts" -R "compute.1234"
+Host key for compute.1234 has changed and you have requested strict checking.
+Host key verification failed.
+`
+
+func TestStaleHostKeys(t *testing.T) {
+	testCases := []struct {
+		name            string
+		output          string
+		clear           bool
+		expectHostKey   bool
+		expectedCmds    [][]string
+		expectedMessage string
+	}{
+		{
+			name:   "unrelated failure",
+			output: "F1015 failed to create instance\n",
+			expectedCmds: [][]string{
+				{"make"},
+			},
+			expectedMessage: "make failed",
+		},
+		{
+			name:          "stale host key",
+			output:        hostKeyMismatchOutput,
+			expectHostKey: true,
+			expectedCmds: [][]string{
+				{"make"},
+			},
+			expectedMessage: `ssh-keygen -f "/root/.ssh/google_compute_known_hosts" -R "compute.1234"`,
+		},
+		{
+			name:          "stale host key cleared",
+			output:        hostKeyMismatchOutput,
+			clear:         true,
+			expectHostKey: true,
+			expectedCmds: [][]string{
+				{"make"},
+				{"ssh-keygen", "-f", "/root/.ssh/google_compute_known_hosts", "-R", "compute.1234"},
+			},
+			expectedMessage: "stale known_hosts entries have been removed",
+		},
+		{
+			name:          "verification failure without a suggested removal",
+			output:        "Host key verification failed.",
+			clear:         true,
+			expectHostKey: true,
+			expectedCmds: [][]string{
+				{"make"},
+			},
+			expectedMessage: "known_hosts file likely contains stale entries",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("ARTIFACTS", t.TempDir())
+			runErr := errors.New("make failed")
+			cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+				if cmd.name != "make" {
+					return nil
+				}
+				_, _ = io.WriteString(cmd.stderr, tc.output)
+				return runErr
+			}}
+			tester := NewDefaultTester()
+			tester.ClearStaleHostKeys = tc.clear
+			tester.cmder = cmder
+
+			err := tester.Test()
+			if !errors.Is(err, runErr) {
+				t.Errorf("expected the run error to be wrapped, but got %v", err)
+			}
+			var hostKeyErr *staleHostKeyError
+			if isHostKeyErr := errors.As(err, &hostKeyErr); isHostKeyErr != tc.expectHostKey {
+				t.Errorf("expected host key error to be %v, but got %v", tc.expectHostKey, err)
+			}
+			if !strings.Contains(err.Error(), tc.expectedMessage) {
+				t.Errorf("expected error to contain %q, but got %q", tc.expectedMessage, err.Error())
+			}
+			var actualCmds [][]string
+			for _, cmd := range cmder.cmds {
+				if cmd.name == "make" {
+					actualCmds = append(actualCmds, []string{cmd.name})
+				} else {
+					actualCmds = append(actualCmds, append([]string{cmd.name}, cmd.args...))
+				}
+			}
+			if !reflect.DeepEqual(actualCmds, tc.expectedCmds) {
+				t.Errorf("expected commands %v, but got %v", tc.expectedCmds, actualCmds)
+			}
+		})
+	}
+}
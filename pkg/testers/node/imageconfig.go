@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+const effectiveImageConfigName = "effective-image-config.yaml"
+
+// imageConfigPath resolves an image config file the same way the node e2e
+// remote runner does, relative to ImageConfigDir when it is set
+func (t *Tester) imageConfigPath(file string) string {
+	if t.ImageConfigDir != "" {
+		return filepath.Join(t.ImageConfigDir, file)
+	}
+	return file
+}
+
+func readImageConfig(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image config: %w", err)
+	}
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse image config %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// imageConfigKeys returns the keys of a section of an image config sorted
+func imageConfigKeys(section map[string]interface{}) []string {
+	keys := make([]string, 0, len(section))
+	for key := range section {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// mergeImageConfig merges overlay onto base in place. Nested maps are merged
+// recursively, any other value in overlay replaces the one in base.
+func mergeImageConfig(base, overlay map[string]interface{}, path string) {
+	for _, key := range imageConfigKeys(overlay) {
+		value := overlay[key]
+		keyPath := path + "." + key
+		existing, exists := base[key]
+		existingMap, existingIsMap := existing.(map[string]interface{})
+		valueMap, valueIsMap := value.(map[string]interface{})
+		switch {
+		case existingIsMap && valueIsMap:
+			mergeImageConfig(existingMap, valueMap, keyPath)
+		case exists && !reflect.DeepEqual(existing, value):
+			klog.V(1).Infof("image config overlay overrides %s: %v -> %v", keyPath, existing, value)
+			base[key] = value
+		default:
+			base[key] = value
+		}
+	}
+}
+
+// effectiveImageConfigData reads ImageConfigFile and merges the overlays onto it in order
+func (t *Tester) effectiveImageConfigData() (map[string]interface{}, error) {
+	config, err := readImageConfig(t.imageConfigPath(t.ImageConfigFile))
+	if err != nil {
+		return nil, err
+	}
+	for _, overlayFile := range t.ImageConfigOverlay {
+		overlay, err := readImageConfig(t.imageConfigPath(overlayFile))
+		if err != nil {
+			return nil, err
+		}
+		mergeImageConfig(config, overlay, "")
+	}
+	return config, nil
+}
+
+// resolveMetadataFiles makes the key<file entries of the image metadata in
+// config absolute. The remote runner reads them relative to IMAGE_CONFIG_DIR,
+// which does not apply to the effective config written elsewhere.
+func (t *Tester) resolveMetadataFiles(config map[string]interface{}) error {
+	if t.ImageConfigDir == "" {
+		return nil
+	}
+	images, _ := config["images"].(map[string]interface{})
+	for _, name := range imageConfigKeys(images) {
+		settings, _ := images[name].(map[string]interface{})
+		metadata, ok := settings["metadata"].(string)
+		if !ok {
+			continue
+		}
+		entries := strings.Split(metadata, ",")
+		for i, entry := range entries {
+			key, file, ok := strings.Cut(entry, "<")
+			if !ok || filepath.IsAbs(file) {
+				continue
+			}
+			path, err := filepath.Abs(t.imageConfigPath(file))
+			if err != nil {
+				return fmt.Errorf("failed to resolve the metadata file %s of image %s: %w", file, name, err)
+			}
+			entries[i] = key + "<" + path
+		}
+		settings["metadata"] = strings.Join(entries, ",")
+	}
+	return nil
+}
+
+// writeEffectiveImageConfig merges the overlays onto ImageConfigFile in order
+// and writes the result to dir, returning the path of the written file
+func (t *Tester) writeEffectiveImageConfig(dir string) (string, error) {
+	config, err := t.effectiveImageConfigData()
+	if err != nil {
+		return "", err
+	}
+	if err := t.resolveMetadataFiles(config); err != nil {
+		return "", err
+	}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal effective image config: %w", err)
+	}
+	path, err := filepath.Abs(filepath.Join(dir, effectiveImageConfigName))
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write effective image config: %w", err)
+	}
+	klog.V(1).Infof("wrote effective image config to %s", path)
+	return path, nil
+}
+
+// listImages writes the images of the image config, with the overlays
+// applied, to w with their settings. Settings that are not strings, e.g. the
+// resources of an image, are written as JSON.
+func (t *Tester) listImages(w io.Writer) error {
+	if t.ImageConfigFile == "" {
+		return fmt.Errorf("--list-images requires --image-config-file")
+	}
+	config, err := t.effectiveImageConfigData()
+	if err != nil {
+		return err
+	}
+	images, ok := config["images"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("image config %s has no images", t.imageConfigPath(t.ImageConfigFile))
+	}
+	for _, name := range imageConfigKeys(images) {
+		if _, err := fmt.Fprintf(w, "%s:\n", name); err != nil {
+			return err
+		}
+		settings, _ := images[name].(map[string]interface{})
+		for _, key := range imageConfigKeys(settings) {
+			value, ok := settings[key].(string)
+			if !ok {
+				data, err := json.Marshal(settings[key])
+				if err != nil {
+					return fmt.Errorf("failed to format %s of image %s: %w", key, name, err)
+				}
+				value = string(data)
+			}
+			if _, err := fmt.Fprintf(w, "  %s: %s\n", key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
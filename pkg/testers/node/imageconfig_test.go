@@ -0,0 +1,230 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestImageConfigOverlays(t *testing.T) {
+	configDir := t.TempDir()
+	files := map[string]string{
+		"base.yaml": `images:
+  cos:
+    image_family: cos-stable
+    project: cos-cloud
+    machine: n1-standard-2
+  ubuntu:
+    image_family: ubuntu-2204-lts
+    project: ubuntu-os-cloud
+`,
+		"gce.yaml": `images:
+  cos:
+    machine: n1-standard-4
+    metadata: "user-data<cos-init.yaml,cpu-manager=static"
+`,
+		"gce-gpu.yaml": `images:
+  cos:
+    machine: n1-standard-8
+  cos-gpu:
+    image_family: cos-stable
+    project: cos-cloud
+`,
+	}
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(configDir, name), []byte(contents), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	artifactsDir := t.TempDir()
+	t.Setenv("ARTIFACTS", artifactsDir)
+	cmder := &fakeCmder{}
+	tester := NewDefaultTester()
+	tester.ImageConfigDir = configDir
+	tester.ImageConfigFile = "base.yaml"
+	tester.ImageConfigOverlay = []string{"gce.yaml", "gce-gpu.yaml"}
+	tester.cmder = cmder
+	if err := tester.Test(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	effectivePath := filepath.Join(artifactsDir, effectiveImageConfigName)
+	args := cmder.cmds[0].args
+	if actual := argValue(t, args, "IMAGE_CONFIG_FILE"); actual != effectivePath {
+		t.Errorf("expected IMAGE_CONFIG_FILE=%q, but got %q", effectivePath, actual)
+	}
+	if actual := argValue(t, args, "IMAGE_CONFIG_DIR"); actual != "" {
+		t.Errorf("expected IMAGE_CONFIG_DIR to be cleared, but got %q", actual)
+	}
+
+	actual, err := readImageConfig(effectivePath)
+	if err != nil {
+		t.Fatalf("failed to read effective image config: %v", err)
+	}
+	expected := map[string]interface{}{
+		"images": map[string]interface{}{
+			"cos": map[string]interface{}{
+				"image_family": "cos-stable",
+				"project":      "cos-cloud",
+				"machine":      "n1-standard-8",
+				"metadata":     "user-data<" + filepath.Join(configDir, "cos-init.yaml") + ",cpu-manager=static",
+			},
+			"cos-gpu": map[string]interface{}{
+				"image_family": "cos-stable",
+				"project":      "cos-cloud",
+			},
+			"ubuntu": map[string]interface{}{
+				"image_family": "ubuntu-2204-lts",
+				"project":      "ubuntu-os-cloud",
+			},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected effective image config %v, but got %v", expected, actual)
+	}
+}
+
+func TestResolveMetadataFiles(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testCases := []struct {
+		name           string
+		imageConfigDir string
+		metadata       string
+		expected       string
+	}{
+		{
+			name:           "relative metadata file",
+			imageConfigDir: "config",
+			metadata:       "user-data<init/cos.yaml,cpu-manager=static",
+			expected:       "user-data<" + filepath.Join(cwd, "config", "init", "cos.yaml") + ",cpu-manager=static",
+		},
+		{
+			name:           "absolute metadata file",
+			imageConfigDir: "config",
+			metadata:       "user-data</etc/cos.yaml",
+			expected:       "user-data</etc/cos.yaml",
+		},
+		{
+			name:     "without an image config dir",
+			metadata: "user-data<init/cos.yaml",
+			expected: "user-data<init/cos.yaml",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.ImageConfigDir = tc.imageConfigDir
+			config := map[string]interface{}{
+				"images": map[string]interface{}{
+					"cos": map[string]interface{}{"metadata": tc.metadata},
+				},
+			}
+			if err := tester.resolveMetadataFiles(config); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			actual := config["images"].(map[string]interface{})["cos"].(map[string]interface{})["metadata"]
+			if actual != tc.expected {
+				t.Errorf("expected metadata %q, but got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestListImages(t *testing.T) {
+	configDir := t.TempDir()
+	files := map[string]string{
+		"base.yaml": `images:
+  ubuntu:
+    image_family: ubuntu-2204-lts
+    project: ubuntu-os-cloud
+  cos:
+    image_family: cos-stable
+    project: cos-cloud
+    resources:
+      accelerators:
+      - type: nvidia-tesla-t4
+        count: 1
+`,
+		"gce.yaml": `images:
+  cos:
+    machine: n1-standard-4
+`,
+	}
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(configDir, name), []byte(contents), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	tester := NewDefaultTester()
+	tester.ImageConfigDir = configDir
+	tester.ImageConfigFile = "base.yaml"
+	tester.ImageConfigOverlay = []string{"gce.yaml"}
+
+	var out bytes.Buffer
+	if err := tester.listImages(&out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `cos:
+  image_family: cos-stable
+  machine: n1-standard-4
+  project: cos-cloud
+  resources: {"accelerators":[{"count":1,"type":"nvidia-tesla-t4"}]}
+ubuntu:
+  image_family: ubuntu-2204-lts
+  project: ubuntu-os-cloud
+`
+	if out.String() != expected {
+		t.Errorf("expected images:\n%s\nbut got:\n%s", expected, out.String())
+	}
+}
+
+func TestListImagesErrors(t *testing.T) {
+	configDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(configDir, "empty.yaml"), []byte("other: {}\n"), 0644); err != nil {
+		t.Fatalf("failed to write the image config: %v", err)
+	}
+	testCases := []struct {
+		name            string
+		imageConfigFile string
+	}{
+		{name: "no image config file"},
+		{name: "missing image config file", imageConfigFile: "missing.yaml"},
+		{name: "image config without images", imageConfigFile: "empty.yaml"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.ImageConfigDir = configDir
+			tester.ImageConfigFile = tc.imageConfigFile
+			if err := tester.listImages(&bytes.Buffer{}); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
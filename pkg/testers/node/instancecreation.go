@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// maxInstanceNameLength is the longest name gce accepts for an instance
+const maxInstanceNameLength = 63
+
+// pendingInstance is a test instance to create and where to write the output of creating it
+type pendingInstance struct {
+	name  string
+	image string
+	// zone overrides the zone of the creator if set
+	zone   string
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// instanceCreator creates a test instance
+type instanceCreator interface {
+	CreateInstance(ctx context.Context, instance pendingInstance) error
+}
+
+// gceInstanceCreator creates gce instances with gcloud
+type gceInstanceCreator struct {
+	cmder        exec.Cmder
+	clock        clock
+	project      string
+	zone         string
+	machineType  string
+	imageProject string
+	// metadata is in the INSTANCE_METADATA format, key=value and key<file entries separated by commas
+	metadata string
+	// reuse treats an instance that already exists as created
+	reuse bool
+}
+
+var _ instanceCreator = &gceInstanceCreator{}
+
+func (g *gceInstanceCreator) CreateInstance(ctx context.Context, instance pendingInstance) error {
+	zone := g.zone
+	if instance.zone != "" {
+		zone = instance.zone
+	}
+	args := []string{"compute", "instances", "create", instance.name, "--project=" + g.project, "--zone=" + zone, "--image=" + instance.image}
+	if g.imageProject != "" {
+		args = append(args, "--image-project="+g.imageProject)
+	}
+	if g.machineType != "" {
+		args = append(args, "--machine-type="+g.machineType)
+	}
+	metadata, fromFile := splitInstanceMetadata(g.metadata)
+	if len(metadata) > 0 {
+		args = append(args, "--metadata="+strings.Join(metadata, ","))
+	}
+	if len(fromFile) > 0 {
+		args = append(args, "--metadata-from-file="+strings.Join(fromFile, ","))
+	}
+	var output bytes.Buffer
+	err := retryAuthRefresh(ctx, g.clock, "the creation of instance "+instance.name, func(stderr io.Writer) error {
+		output.Reset()
+		cmd := g.cmder.CommandContext(ctx, "gcloud", args...)
+		exec.SetOutput(cmd, instance.stdout, io.MultiWriter(instance.stderr, stderr, &output))
+		return cmd.Run()
+	})
+	if err != nil && g.reuse && strings.Contains(output.String(), "already exists") {
+		klog.V(0).Infof("reusing the existing instance %s", instance.name)
+		return nil
+	}
+	return err
+}
+
+// splitInstanceMetadata splits INSTANCE_METADATA into the key=value entries
+// and the key<file entries read from files, the latter as key=file
+func splitInstanceMetadata(metadata string) (values, fromFile []string) {
+	for _, entry := range strings.Split(metadata, ",") {
+		entry = strings.TrimSpace(entry)
+		if key, file, ok := strings.Cut(entry, "<"); ok {
+			fromFile = append(fromFile, key+"="+file)
+		} else if entry != "" {
+			values = append(values, entry)
+		}
+	}
+	return values, fromFile
+}
+
+// createInstances creates the instances with at most limit created at once,
+// it creates every instance even if some fail and returns all the failures
+func createInstances(ctx context.Context, creator instanceCreator, instances []pendingInstance, limit int) error {
+	errs := make([]error, len(instances))
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i, instance := range instances {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, instance pendingInstance) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := creator.CreateInstance(ctx, instance); err != nil {
+				errs[i] = fmt.Errorf("failed to create instance %s: %w", instance.name, err)
+			}
+		}(i, instance)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// createsInstances reports whether the tester creates the instances itself
+// and runs the tests on them, rather than the test process creating them
+func (t *Tester) createsInstances() bool {
+	return t.MaxParallelInstanceCreation > 0 || t.NodeCountPerImage != "" || len(t.gcpZones()) > 1 || len(t.fileUploads) > 0 || t.Warmup
+}
+
+// instanceName returns the name of the instance running image for this run,
+// suffix tells apart the nodes of an image with more than one
+func (t *Tester) instanceName(image, suffix string) string {
+	name := "tmp-node-e2e-"
+	if t.InstanceNamePrefix != "" {
+		// the same names across runs, so the instances can be reused
+		name = t.InstanceNamePrefix + "-"
+	} else if id := strings.ReplaceAll(t.runID, "-", ""); id != "" {
+		if len(id) > 8 {
+			id = id[:8]
+		}
+		name += id + "-"
+	}
+	name += image
+	if suffix != "" {
+		suffix = "-" + suffix
+	}
+	if len(name)+len(suffix) > maxInstanceNameLength {
+		name = strings.TrimRight(name[:maxInstanceNameLength-len(suffix)], "-")
+	}
+	return name + suffix
+}
+
+// plannedInstances returns the nodes of each image the tester creates, spread
+// round-robin across the zones
+func (t *Tester) plannedInstances() []pendingInstance {
+	zones := t.gcpZones()
+	var instances []pendingInstance
+	for _, image := range strings.Split(t.Images, ",") {
+		image = strings.TrimSpace(image)
+		count := t.nodeCount(image)
+		for i := 1; i <= count; i++ {
+			suffix := ""
+			if count > 1 {
+				suffix = strconv.Itoa(i)
+			}
+			instance := pendingInstance{name: t.instanceName(image, suffix), image: image}
+			if len(zones) > 1 {
+				instance.zone = zones[len(instances)%len(zones)]
+			}
+			instances = append(instances, instance)
+		}
+	}
+	return instances
+}
+
+// createTestInstances creates the nodes of each image with at most
+// MaxParallelInstanceCreation created at once, or all at once if unset,
+// recording their creation in output, and returns the names of the instances
+// that were created, or that already existed with --reuse-instances
+func (t *Tester) createTestInstances(ctx context.Context, output *runOutput) ([]string, error) {
+	creator := &gceInstanceCreator{
+		cmder:        t.cmder,
+		clock:        t.clock,
+		project:      t.GCPProject,
+		zone:         t.GCPZone,
+		machineType:  t.InstanceType,
+		imageProject: t.ImageProject,
+		metadata:     t.instanceMetadata(),
+		reuse:        t.ReuseInstances,
+	}
+	instances := t.plannedInstances()
+	for i := range instances {
+		instances[i].stdout = output.watch(os.Stdout)
+		instances[i].stderr = output.watch(os.Stderr)
+	}
+	limit := t.MaxParallelInstanceCreation
+	if limit == 0 {
+		limit = len(instances)
+	}
+	klog.V(0).Infof("creating %d instances, at most %d at once", len(instances), limit)
+	err := createInstances(ctx, creator, instances, limit)
+	output.flush()
+	if t.ReuseInstances {
+		// the reused instances were not created by this run
+		var names []string
+		for _, instance := range instances {
+			names = append(names, instance.name)
+		}
+		return names, err
+	}
+	return undeletedInstances(output.lifecycle), err
+}
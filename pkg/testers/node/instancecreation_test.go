@@ -0,0 +1,210 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingCreator records how many instances are being created at once
+type countingCreator struct {
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	created     []string
+	fail        map[string]bool
+}
+
+func (c *countingCreator) CreateInstance(ctx context.Context, instance pendingInstance) error {
+	c.mu.Lock()
+	c.inFlight++
+	if c.inFlight > c.maxInFlight {
+		c.maxInFlight = c.inFlight
+	}
+	c.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inFlight--
+	if c.fail[instance.name] {
+		return errors.New("quota exceeded")
+	}
+	c.created = append(c.created, instance.name)
+	return nil
+}
+
+func TestCreateInstances(t *testing.T) {
+	testCases := []struct {
+		name      string
+		instances int
+		limit     int
+		fail      map[string]bool
+		expectErr string
+	}{
+		{
+			name:      "one at a time",
+			instances: 5,
+			limit:     1,
+		},
+		{
+			name:      "fewer than the instances",
+			instances: 10,
+			limit:     3,
+		},
+		{
+			name:      "more than the instances",
+			instances: 4,
+			limit:     8,
+		},
+		{
+			name:      "failures do not stop the other instances",
+			instances: 6,
+			limit:     2,
+			fail:      map[string]bool{"instance-2": true},
+			expectErr: "failed to create instance instance-2: quota exceeded",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			creator := &countingCreator{fail: tc.fail}
+			var instances []pendingInstance
+			var expected []string
+			for i := 0; i < tc.instances; i++ {
+				name := fmt.Sprintf("instance-%d", i)
+				instances = append(instances, pendingInstance{name: name, image: "cos-stable"})
+				if !tc.fail[name] {
+					expected = append(expected, name)
+				}
+			}
+
+			err := createInstances(context.Background(), creator, instances, tc.limit)
+			if tc.expectErr != "" {
+				if err == nil || err.Error() != tc.expectErr {
+					t.Errorf("expected error %q, but got %v", tc.expectErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if creator.maxInFlight > tc.limit {
+				t.Errorf("expected at most %d instances to be created at once, but got %d", tc.limit, creator.maxInFlight)
+			}
+			sort.Strings(creator.created)
+			sort.Strings(expected)
+			if !reflect.DeepEqual(creator.created, expected) {
+				t.Errorf("expected instances %v to be created, but got %v", expected, creator.created)
+			}
+		})
+	}
+}
+
+func TestMaxParallelInstanceCreation(t *testing.T) {
+	dir := t.TempDir()
+	var hosts, images string
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		if cmd.name == "gcloud" && cmd.args[2] == "create" {
+			_, _ = fmt.Fprintf(cmd.stderr, "Created [https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/%s].\n", cmd.args[3])
+		}
+		if cmd.name == "make" {
+			hosts = argValue(t, cmd.args, "HOSTS")
+			images = argValue(t, cmd.args, "IMAGES")
+		}
+		return nil
+	}}
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.GCPProject = "p"
+	tester.GCPZone = "us-central1-a"
+	tester.Images = "cos-stable,ubuntu-2204"
+	tester.ImageProject = "images"
+	tester.InstanceType = "e2-standard-2"
+	tester.InstanceMetadata = "user-data<cloud-init.yaml,cpu-manager=static"
+	tester.DeleteInstances = true
+	tester.MaxParallelInstanceCreation = 1
+	tester.runID = "8f14e45f-ceea-467f-a8b0-12c0a1b2c3d4"
+	tester.cmder = cmder
+	if err := tester.validateFlags(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := tester.runOnce(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedHosts := []string{"tmp-node-e2e-8f14e45f-cos-stable", "tmp-node-e2e-8f14e45f-ubuntu-2204"}
+	actualHosts := strings.Split(hosts, ",")
+	sort.Strings(actualHosts)
+	if !reflect.DeepEqual(actualHosts, expectedHosts) {
+		t.Errorf("expected the tests to run on %v, but got HOSTS=%s", expectedHosts, hosts)
+	}
+	if images != "" {
+		t.Errorf("expected the tests not to create instances from the images, but got IMAGES=%s", images)
+	}
+	var created []string
+	for _, cmd := range cmder.sortedCmds() {
+		if cmd.name == "gcloud" && cmd.args[2] == "create" {
+			created = append(created, cmd.args[3])
+			expectedArgs := []string{"--project=p", "--zone=us-central1-a", "--image-project=images", "--machine-type=e2-standard-2",
+				"--metadata=cpu-manager=static", "--metadata-from-file=user-data=cloud-init.yaml"}
+			for _, arg := range expectedArgs {
+				if !containsString(cmd.args, arg) {
+					t.Errorf("expected %s in the create args, but got %v", arg, cmd.args)
+				}
+			}
+		}
+	}
+	sort.Strings(created)
+	if !reflect.DeepEqual(created, expectedHosts) {
+		t.Errorf("expected instances %v to be created, but got %v", expectedHosts, created)
+	}
+	// the deletion is throttled like the creation, one instance at a time
+	var deleted []string
+	for _, cmd := range cmder.sortedCmds() {
+		if cmd.name == "gcloud" && cmd.args[2] == "delete" {
+			if len(cmd.args[6:]) != 1 {
+				t.Errorf("expected one instance deleted at a time, but got %v", cmd.args[6:])
+			}
+			deleted = append(deleted, cmd.args[6:]...)
+		}
+	}
+	sort.Strings(deleted)
+	if !reflect.DeepEqual(deleted, expectedHosts) {
+		t.Errorf("expected instances %v to be deleted, but got %v", expectedHosts, deleted)
+	}
+}
+
+func TestInstanceName(t *testing.T) {
+	tester := NewDefaultTester()
+	tester.runID = "8f14e45f-ceea-467f-a8b0-12c0a1b2c3d4"
+	if actual := tester.instanceName("cos-stable", ""); actual != "tmp-node-e2e-8f14e45f-cos-stable" {
+		t.Errorf("unexpected instance name %s", actual)
+	}
+	long := tester.instanceName("ubuntu-2204-jammy-v20260101-with-a-very-long-image-name-suffix", "2")
+	if len(long) > maxInstanceNameLength || !strings.HasSuffix(long, "-2") || strings.Contains(long, "--") {
+		t.Errorf("expected a valid instance name, but got %s", long)
+	}
+}
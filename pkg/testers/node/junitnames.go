@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// junitNamePlaceholderRegex matches the placeholders of --junit-name-template
+var junitNamePlaceholderRegex = regexp.MustCompile(`\{[^{}]*\}`)
+
+// junitNamePlaceholders are the placeholders --junit-name-template may use
+var junitNamePlaceholders = map[string]bool{
+	"{suite}":   true,
+	"{image}":   true,
+	"{runtime}": true,
+}
+
+// validateJUnitNameTemplate checks that template only uses known placeholders
+// and names a file in the artifacts directory that is still read as junit results
+func validateJUnitNameTemplate(template string) error {
+	for _, placeholder := range junitNamePlaceholderRegex.FindAllString(template, -1) {
+		if !junitNamePlaceholders[placeholder] {
+			return fmt.Errorf("unknown placeholder %s, must be one of {suite}, {image} or {runtime}", placeholder)
+		}
+	}
+	if strings.ContainsAny(template, `/\`) {
+		return fmt.Errorf("%q must be a file name, not a path", template)
+	}
+	if !isJUnitResultsFile(formatJUnitName(template, "suite", "image", "runtime")) {
+		return fmt.Errorf("%q must produce names matching junit*.xml for the results to be read", template)
+	}
+	return nil
+}
+
+// formatJUnitName replaces the placeholders of template
+func formatJUnitName(template, suite, image, runtime string) string {
+	return strings.NewReplacer("{suite}", suite, "{image}", image, "{runtime}", runtime).Replace(template)
+}
+
+// junitSuite is the name of a junit file without its junit prefix and
+// extension, e.g. tmp-node-e2e-cos-1234_01 for junit_tmp-node-e2e-cos-1234_01.xml
+func junitSuite(file string) string {
+	suite := strings.TrimSuffix(filepath.Base(file), ".xml")
+	suite = strings.TrimPrefix(suite, "junit")
+	return strings.TrimPrefix(suite, "_")
+}
+
+// renameJUnitFiles renames the junit files of the run in artifactsDir after
+// --junit-name-template
+func (t *Tester) renameJUnitFiles(artifactsDir string) error {
+	files, err := findResultFiles(artifactsDir, isJUnitResultsFile)
+	if err != nil {
+		return err
+	}
+	renames := map[string]string{}
+	targets := map[string]string{}
+	for _, file := range files {
+		name := formatJUnitName(t.JUnitNameTemplate, junitSuite(file), t.specImage(file), t.specRuntime(artifactsDir, file))
+		target := filepath.Join(filepath.Dir(file), name)
+		if other, ok := targets[target]; ok {
+			return fmt.Errorf("junit files %s and %s would both be named %s, include {suite} in --junit-name-template", other, file, name)
+		}
+		targets[target] = file
+		renames[file] = target
+	}
+	for _, file := range files {
+		target := renames[file]
+		if target == file {
+			continue
+		}
+		if _, ok := renames[target]; ok {
+			return fmt.Errorf("junit file %s would be renamed over %s", file, target)
+		}
+	}
+	for _, file := range files {
+		if target := renames[file]; target != file {
+			if err := os.Rename(file, target); err != nil {
+				return fmt.Errorf("failed to rename junit file %s: %w", file, err)
+			}
+		}
+	}
+	return nil
+}
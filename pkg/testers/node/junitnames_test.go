@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestRenameJUnitFiles(t *testing.T) {
+	testCases := []struct {
+		name     string
+		template string
+		runtimes string
+		files    []string
+		expected []string
+	}{
+		{
+			name:     "suite",
+			template: "junit_nodee2e_{suite}.xml",
+			files:    []string{"junit_tmp-node-e2e-1234-cos-stable_01.xml", "junit_tmp-node-e2e-1234-cos-stable_02.xml"},
+			expected: []string{"junit_nodee2e_tmp-node-e2e-1234-cos-stable_01.xml", "junit_nodee2e_tmp-node-e2e-1234-cos-stable_02.xml"},
+		},
+		{
+			name:     "image and runtime",
+			template: "junit_{image}_{runtime}_{suite}.xml",
+			runtimes: "unix:///run/containerd/containerd.sock",
+			files:    []string{"junit_tmp-node-e2e-1234-cos-stable_01.xml", "junit_tmp-node-e2e-5678-ubuntu_01.xml"},
+			expected: []string{"junit_cos-stable_containerd_tmp-node-e2e-1234-cos-stable_01.xml", "junit_ubuntu_containerd_tmp-node-e2e-5678-ubuntu_01.xml"},
+		},
+		{
+			name:     "unchanged names",
+			template: "junit_{suite}.xml",
+			files:    []string{"junit_01.xml"},
+			expected: []string{"junit_01.xml"},
+		},
+		{
+			name:     "warmup keeps its names",
+			template: "junit_nodee2e_{suite}.xml",
+			files:    []string{"junit_01.xml", "warmup/junit_01.xml"},
+			expected: []string{"junit_nodee2e_01.xml", "warmup/junit_01.xml"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, file := range tc.files {
+				writeArtifact(t, dir, file, sampleJUnit)
+			}
+			tester := NewDefaultTester()
+			tester.Images = "cos-stable,ubuntu"
+			tester.JUnitNameTemplate = tc.template
+			if tc.runtimes != "" {
+				endpoints, err := parseRuntimeEndpoints(tc.runtimes)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				tester.runtimeEndpoints = endpoints
+			}
+
+			if err := tester.renameJUnitFiles(dir); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var actual []string
+			err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+				if err == nil && !d.IsDir() {
+					rel, _ := filepath.Rel(dir, path)
+					actual = append(actual, filepath.ToSlash(rel))
+				}
+				return err
+			})
+			if err != nil {
+				t.Fatalf("failed to list the artifacts: %v", err)
+			}
+			expected := append([]string(nil), tc.expected...)
+			sort.Strings(expected)
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("expected files %v, but got %v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestRenameJUnitFilesCollision(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, dir, "junit_01.xml", sampleJUnit)
+	writeArtifact(t, dir, "junit_02.xml", sampleJUnit)
+	tester := NewDefaultTester()
+	tester.JUnitNameTemplate = "junit_nodee2e.xml"
+
+	if err := tester.renameJUnitFiles(dir); err == nil {
+		t.Errorf("expected an error when two junit files get the same name")
+	}
+	for _, name := range []string{"junit_01.xml", "junit_02.xml"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected %s to be left in place: %v", name, err)
+		}
+	}
+}
+
+func TestValidateJUnitNameTemplate(t *testing.T) {
+	testCases := []struct {
+		template string
+		valid    bool
+	}{
+		{template: "junit_nodee2e_{suite}.xml", valid: true},
+		{template: "junit_{image}_{runtime}_{suite}.xml", valid: true},
+		{template: "junit_{host}.xml"},
+		{template: "results_{suite}.xml"},
+		{template: "junit_{suite}.json"},
+		{template: "nodee2e/junit_{suite}.xml"},
+	}
+
+	for _, tc := range testCases {
+		err := validateJUnitNameTemplate(tc.template)
+		if tc.valid && err != nil {
+			t.Errorf("expected %q to be valid, but got %v", tc.template, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("expected %q to be rejected", tc.template)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// loadSpecList reads a file with one spec name per line, ignoring
+// empty lines and lines starting with #
+func loadSpecList(path string) (map[string]bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	specs := map[string]bool{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		specs[line] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return specs, nil
+}
+
+// knownFailureReport classifies the results of a run against the known failures
+type knownFailureReport struct {
+	// KnownFailures failed and are allowed to
+	KnownFailures []string
+	// UnexpectedPasses are known failures that passed
+	UnexpectedPasses []string
+	// NewFailures failed and are not known failures
+	NewFailures []string
+}
+
+func applyKnownFailures(s *Summary, known map[string]bool) knownFailureReport {
+	var report knownFailureReport
+	for _, spec := range s.Specs {
+		switch {
+		case spec.Status == SpecFailed && known[spec.Name]:
+			report.KnownFailures = append(report.KnownFailures, spec.Name)
+		case spec.Status == SpecFailed:
+			report.NewFailures = append(report.NewFailures, spec.Name)
+		case spec.Status == SpecPassed && known[spec.Name]:
+			report.UnexpectedPasses = append(report.UnexpectedPasses, spec.Name)
+		}
+	}
+	sort.Strings(report.KnownFailures)
+	sort.Strings(report.UnexpectedPasses)
+	sort.Strings(report.NewFailures)
+	return report
+}
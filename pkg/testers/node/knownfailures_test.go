@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const sampleJUnit = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="4" failures="2">
+  <testsuite name="E2eNode Suite" tests="4" failures="2">
+    <testcase name="[It] known flake" classname="E2eNode Suite" time="1.5">
+      <failure message="timed out" type="failed">timed out waiting</failure>
+    </testcase>
+    <testcase name="[It] fixed bug" classname="E2eNode Suite" time="2"></testcase>
+    <testcase name="[It] regression" classname="E2eNode Suite" time="0.5">
+      <failure message="expected true" type="failed"></failure>
+    </testcase>
+    <testcase name="[It] not run" classname="E2eNode Suite" time="0">
+      <skipped message="skipped"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+
+func TestParseJUnitResults(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, dir, "junit_cos_01.xml", sampleJUnit)
+	writeArtifact(t, dir, "tmp-node/junit_cos_02.xml", `<testsuite name="E2eNode Suite"><testcase name="[It] other" time="3"></testcase></testsuite>`)
+
+	results, err := parseJUnitResults(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results.Passed != 2 || results.Failed != 2 || results.Skipped != 1 || results.Total() != 5 {
+		t.Errorf("expected 2 passed, 2 failed, 1 skipped, but got %+v", results)
+	}
+	expectedFailed := []string{"[It] known flake", "[It] regression"}
+	if actual := results.failedSpecs(); !reflect.DeepEqual(actual, expectedFailed) {
+		t.Errorf("expected failed specs %v, but got %v", expectedFailed, actual)
+	}
+	if results.Specs[0].Message != "timed out" {
+		t.Errorf("expected failure message %q, but got %q", "timed out", results.Specs[0].Message)
+	}
+}
+
+func TestParseFlakyJUnit(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, dir, "junit_01.xml", `<testsuite name="E2eNode Suite">
+  <testcase name="[It] flaky" status="passed" time="3"><failure message="attempt 1 failed"></failure></testcase>
+  <testcase name="[It] broken" status="failed" time="1"><failure message="failed"></failure></testcase>
+</testsuite>`)
+
+	results, err := parseJUnitResults(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results.Passed != 1 || results.Failed != 1 {
+		t.Errorf("expected the flaky spec that passed on a later attempt to pass, but got %+v", results)
+	}
+}
+
+func TestKnownFailures(t *testing.T) {
+	testCases := []struct {
+		name                     string
+		knownFailures            string
+		timedOut                 bool
+		cancelled                bool
+		expectErr                bool
+		expectedUnexpectedPasses []string
+	}{
+		{
+			name:          "new failure",
+			knownFailures: "[It] known flake\n",
+			expectErr:     true,
+		},
+		{
+			name:          "only known failures",
+			knownFailures: "# flaky\n[It] known flake\n[It] regression\n",
+		},
+		{
+			name:                     "unexpectedly passing known failure",
+			knownFailures:            "[It] known flake\n[It] regression\n[It] fixed bug\n",
+			expectedUnexpectedPasses: []string{"[It] fixed bug"},
+		},
+		{
+			name:          "only known failures of a timed out run",
+			knownFailures: "[It] known flake\n[It] regression\n",
+			timedOut:      true,
+			expectErr:     true,
+		},
+		{
+			name:          "only known failures of a cancelled run",
+			knownFailures: "[It] known flake\n[It] regression\n",
+			cancelled:     true,
+			expectErr:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			writeArtifact(t, dir, "junit_cos_01.xml", sampleJUnit)
+			knownFailuresPath := filepath.Join(dir, "known-failures.txt")
+			if err := os.WriteFile(knownFailuresPath, []byte(tc.knownFailures), 0644); err != nil {
+				t.Fatalf("failed to write known failures: %v", err)
+			}
+
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.GCPZone = "us-central1-a"
+			tester.KnownFailuresFile = knownFailuresPath
+			if err := tester.validateFlags(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			runErr := errors.New("make failed")
+			if tc.timedOut {
+				runErr = fmt.Errorf("node e2e run timed out after 1h: %w: %w", errHardTimeout, runErr)
+			}
+			if tc.cancelled {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				tester.ctx = ctx
+			}
+			err := tester.processResults(dir, runErr)
+			if tc.expectErr && !errors.Is(err, runErr) {
+				t.Errorf("expected the run error, but got %v", err)
+			}
+			if !tc.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			results, err := parseJUnitResults(dir)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			report := applyKnownFailures(results, tester.knownFailures)
+			if !reflect.DeepEqual(report.UnexpectedPasses, tc.expectedUnexpectedPasses) {
+				t.Errorf("expected unexpected passes %v, but got %v", tc.expectedUnexpectedPasses, report.UnexpectedPasses)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	kubeletLogName          = "kubelet.log"
+	kubeletRestartsFileName = "kubelet-restarts.json"
+)
+
+var (
+	// the kubelet logs its version once each time it starts
+	kubeletStartRegex = regexp.MustCompile(`"Kubelet version" kubeletVersion=`)
+	// klogHeaderRegex captures the time from the header of a klog line
+	klogHeaderRegex = regexp.MustCompile(`^[IWEF](\d{4} \d{2}:\d{2}:\d{2}\.\d+)`)
+)
+
+// kubeletRestart is a start of the kubelet on a test node after its first one
+type kubeletRestart struct {
+	// Host is the test node, the directory holding its kubelet log
+	Host string `json:"host"`
+	// Time is the time of the restart from the klog header, without the year
+	Time string `json:"time,omitempty"`
+}
+
+// kubeletRestartReport is written to kubelet-restarts.json
+type kubeletRestartReport struct {
+	Restarts []kubeletRestart `json:"restarts"`
+	// AffectedSpecs are the failed specs that ran on a node whose kubelet restarted
+	AffectedSpecs []string `json:"affectedSpecs"`
+}
+
+// parseKubeletRestarts returns the restarts of the kubelet found in the kubelet log at path
+func parseKubeletRestarts(path, host string) ([]kubeletRestart, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var restarts []kubeletRestart
+	starts := 0
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !kubeletStartRegex.MatchString(line) {
+			continue
+		}
+		starts++
+		if starts == 1 {
+			continue
+		}
+		restart := kubeletRestart{Host: host}
+		if match := klogHeaderRegex.FindStringSubmatch(line); match != nil {
+			restart.Time = match[1]
+		}
+		restarts = append(restarts, restart)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return restarts, nil
+}
+
+// detectKubeletRestarts returns the nodes with a kubelet log under artifactsDir
+// and the restarts found in those logs
+func detectKubeletRestarts(artifactsDir string) ([]string, []kubeletRestart, error) {
+	files, err := findResultFiles(artifactsDir, func(name string) bool { return name == kubeletLogName })
+	if err != nil {
+		return nil, nil, err
+	}
+	var hosts []string
+	var restarts []kubeletRestart
+	for _, file := range files {
+		host := filepath.Base(filepath.Dir(file))
+		fileRestarts, err := parseKubeletRestarts(file, host)
+		if err != nil {
+			return nil, nil, err
+		}
+		hosts = append(hosts, host)
+		restarts = append(restarts, fileRestarts...)
+	}
+	return hosts, restarts, nil
+}
+
+// affectedSpecs returns the sorted failed specs that may have been affected by
+// the restarts. The junit files are named after the node they ran on, the
+// failed specs of a junit file that cannot be matched to a node are all affected.
+func affectedSpecs(results *Summary, hosts []string, restarts []kubeletRestart) []string {
+	restarted := map[string]bool{}
+	for _, restart := range restarts {
+		restarted[restart.Host] = true
+	}
+	var names []string
+	for _, spec := range results.Specs {
+		if spec.Status != SpecFailed {
+			continue
+		}
+		affected := true
+		junitName := filepath.Base(spec.File)
+		for _, host := range hosts {
+			if strings.Contains(junitName, host) {
+				affected = restarted[host]
+				break
+			}
+		}
+		if affected {
+			names = append(names, spec.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// reportKubeletRestarts surfaces the kubelet restarts during the run in
+// artifactsDir and the failed specs they may have affected
+func (t *Tester) reportKubeletRestarts(artifactsDir string) {
+	hosts, restarts, err := detectKubeletRestarts(artifactsDir)
+	if err != nil {
+		klog.Warningf("failed to detect kubelet restarts: %v", err)
+		return
+	}
+	report := kubeletRestartReport{Restarts: restarts}
+	if len(restarts) > 0 {
+		for _, restart := range restarts {
+			klog.Warningf("kubelet restarted on %s at %s during the run", restart.Host, restart.Time)
+		}
+		results, err := t.results(artifactsDir)
+		if err != nil {
+			klog.Warningf("failed to parse test results: %v", err)
+		} else {
+			report.AffectedSpecs = affectedSpecs(results, hosts, restarts)
+		}
+		for _, name := range report.AffectedSpecs {
+			klog.Warningf("failed spec may have been affected by a kubelet restart: %s", name)
+		}
+	} else {
+		klog.V(1).Info("no kubelet restarts detected")
+	}
+	if err := writeJSON(filepath.Join(artifactsDir, kubeletRestartsFileName), report); err != nil {
+		klog.Warningf("failed to write kubelet restarts: %v", err)
+	}
+}
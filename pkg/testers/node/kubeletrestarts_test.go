@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const (
+	kubeletLogStart = `I0102 03:04:05.000000    1234 server.go:487] "Kubelet version" kubeletVersion="v1.31.0"
+I0102 03:04:05.100000    1234 server.go:489] "Golang settings" GOGC="" GOMAXPROCS=""
+`
+	kubeletLogRestart = `I0102 03:04:05.000000    1234 server.go:487] "Kubelet version" kubeletVersion="v1.31.0"
+E0102 03:10:00.000000    1234 kubelet.go:1000] "Unhandled Error" err="panic: runtime error"
+I0102 03:10:07.500000    2345 server.go:487] "Kubelet version" kubeletVersion="v1.31.0"
+`
+)
+
+func TestKubeletRestarts(t *testing.T) {
+	testCases := []struct {
+		name             string
+		logs             map[string]string
+		expectedRestarts []kubeletRestart
+		expectedAffected []string
+	}{
+		{
+			name: "no restarts",
+			logs: map[string]string{
+				"tmp-node-e2e-cos": kubeletLogStart,
+			},
+			expectedRestarts: nil,
+			expectedAffected: nil,
+		},
+		{
+			name: "restart on one node",
+			logs: map[string]string{
+				"tmp-node-e2e-cos":    kubeletLogRestart,
+				"tmp-node-e2e-ubuntu": kubeletLogStart,
+			},
+			expectedRestarts: []kubeletRestart{{Host: "tmp-node-e2e-cos", Time: "0102 03:10:07.500000"}},
+			// the failure on the node without a restart is not affected,
+			// the one in the junit without a node name may have been
+			expectedAffected: []string{"[It] cos failure", "[It] unknown node failure"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for host, log := range tc.logs {
+				writeArtifact(t, dir, filepath.Join(host, kubeletLogName), log)
+			}
+			writeArtifact(t, dir, "junit_tmp-node-e2e-cos_01.xml", `<testsuite><testcase name="[It] cos failure"><failure message="failed"/></testcase><testcase name="[It] cos pass"/></testsuite>`)
+			writeArtifact(t, dir, "junit_tmp-node-e2e-ubuntu_01.xml", `<testsuite><testcase name="[It] ubuntu failure"><failure message="failed"/></testcase></testsuite>`)
+			writeArtifact(t, dir, "junit_02.xml", `<testsuite><testcase name="[It] unknown node failure"><failure message="failed"/></testcase></testsuite>`)
+
+			tester := NewDefaultTester()
+			tester.DetectKubeletRestarts = true
+			tester.reportKubeletRestarts(dir)
+
+			data, err := os.ReadFile(filepath.Join(dir, kubeletRestartsFileName))
+			if err != nil {
+				t.Fatalf("failed to read the report: %v", err)
+			}
+			var report kubeletRestartReport
+			if err := json.Unmarshal(data, &report); err != nil {
+				t.Fatalf("failed to parse the report: %v", err)
+			}
+			if !reflect.DeepEqual(report.Restarts, tc.expectedRestarts) {
+				t.Errorf("expected restarts %+v, but got %+v", tc.expectedRestarts, report.Restarts)
+			}
+			if !reflect.DeepEqual(report.AffectedSpecs, tc.expectedAffected) {
+				t.Errorf("expected affected specs %v, but got %v", tc.expectedAffected, report.AffectedSpecs)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// kubeletVersionSource detects the version of the kubelet under test
+type kubeletVersionSource interface {
+	KubeletVersion() (string, error)
+}
+
+// workspaceKubeletVersionSource reads the version of the kubelet from the
+// workspace status of the repository, node e2e runs build the kubelet under
+// test from it
+type workspaceKubeletVersionSource struct {
+	cmder    exec.Cmder
+	repoRoot string
+}
+
+var _ kubeletVersionSource = &workspaceKubeletVersionSource{}
+
+func (w *workspaceKubeletVersionSource) KubeletVersion() (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := w.cmder.Command("hack/print-workspace-status.sh")
+	cmd.SetDir(w.repoRoot)
+	cmd.SetStdout(&stdout)
+	cmd.SetStderr(&stderr)
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to print the workspace status of %s: %w: %s", w.repoRoot, err, strings.TrimSpace(stderr.String()))
+	}
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		if key, value, ok := strings.Cut(scanner.Text(), " "); ok && key == "gitVersion" {
+			return strings.TrimSpace(value), nil
+		}
+	}
+	return "", fmt.Errorf("no gitVersion in the workspace status of %s", w.repoRoot)
+}
+
+// parseKubeletVersion parses a kubelet version such as v1.31.0-alpha.1.123+abc,
+// keeping only its major, minor and patch versions so that the pre-releases of
+// a version are not older than the minimum version
+func parseKubeletVersion(version string) (semver.Version, error) {
+	v, err := semver.ParseTolerant(version)
+	if err != nil {
+		return semver.Version{}, err
+	}
+	return semver.Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}, nil
+}
+
+// checkMinKubeletVersion fails if the kubelet version detected by source is
+// older than min
+func checkMinKubeletVersion(source kubeletVersionSource, min semver.Version) error {
+	detected, err := source.KubeletVersion()
+	if err != nil {
+		return fmt.Errorf("failed to detect the kubelet version: %w", err)
+	}
+	version, err := parseKubeletVersion(detected)
+	if err != nil {
+		return fmt.Errorf("failed to parse the kubelet version %q: %w", detected, err)
+	}
+	if version.LT(min) {
+		return fmt.Errorf("kubelet version %s is older than --min-kubelet-version %s", detected, min)
+	}
+	klog.V(1).Infof("kubelet version %s is at least %s", detected, min)
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+// fakeKubeletVersionSource returns a fixed version or error
+type fakeKubeletVersionSource struct {
+	version string
+	err     error
+}
+
+func (f *fakeKubeletVersionSource) KubeletVersion() (string, error) {
+	return f.version, f.err
+}
+
+func TestCheckMinKubeletVersion(t *testing.T) {
+	testCases := []struct {
+		name      string
+		version   string
+		err       error
+		min       string
+		expectErr bool
+	}{
+		{
+			name:    "above the minimum",
+			version: "v1.31.2",
+			min:     "1.30",
+		},
+		{
+			name:    "equal to the minimum",
+			version: "v1.30.0",
+			min:     "v1.30.0",
+		},
+		{
+			name:    "pre-release of the minimum",
+			version: "v1.31.0-alpha.1.123+0123456789abcd",
+			min:     "1.31",
+		},
+		{
+			name:      "below the minimum",
+			version:   "v1.29.5",
+			min:       "1.30",
+			expectErr: true,
+		},
+		{
+			name:      "undetectable version",
+			err:       errors.New("not a kubernetes repository"),
+			min:       "1.30",
+			expectErr: true,
+		},
+		{
+			name:      "unparsable version",
+			version:   "unknown",
+			min:       "1.30",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			min, err := parseKubeletVersion(tc.min)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err = checkMinKubeletVersion(&fakeKubeletVersionSource{version: tc.version, err: tc.err}, min)
+			if tc.expectErr != (err != nil) {
+				t.Errorf("expected error %v, but got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestWorkspaceKubeletVersionSource(t *testing.T) {
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		_, _ = io.WriteString(cmd.stdout, "STABLE_BUILD_GIT_COMMIT 0123456789abcdef\ngitVersion v1.31.0-beta.0.12+0123456789abcd\ngitMajor 1\n")
+		return nil
+	}}
+	source := &workspaceKubeletVersionSource{cmder: cmder, repoRoot: "/kubernetes"}
+	version, err := source.KubeletVersion()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "v1.31.0-beta.0.12+0123456789abcd"; version != expected {
+		t.Errorf("expected version %q, but got %q", expected, version)
+	}
+	if cmd := cmder.cmds[0]; cmd.name != "hack/print-workspace-status.sh" || cmd.dir != "/kubernetes" {
+		t.Errorf("expected the workspace status of /kubernetes, but got %s in %s", cmd.name, cmd.dir)
+	}
+}
+
+func TestInvalidMinKubeletVersion(t *testing.T) {
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.GCPZone = "us-central1-a"
+	tester.MinKubeletVersion = "latest"
+	if err := tester.validateFlags(); err == nil {
+		t.Error("expected an invalid --min-kubelet-version to be rejected")
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const lifecycleFileName = "lifecycle.jsonl"
+
+type lifecycleEventType string
+
+const (
+	instanceCreated lifecycleEventType = "created"
+	instanceDeleted lifecycleEventType = "deleted"
+)
+
+var (
+	// gcloud reports created and deleted instances by their resource URL, e.g.
+	//   Created [https://www.googleapis.com/compute/v1/projects/p/zones/z/instances/tmp-node-e2e-1234].
+	instanceCreatedRegex = regexp.MustCompile(`Created \[\S*/instances/([^\]/]+)\]`)
+	instanceDeletedRegex = regexp.MustCompile(`Deleted \[\S*/instances/([^\]/]+)\]`)
+)
+
+// lifecycleEvent records when an instance was created or confirmed deleted
+type lifecycleEvent struct {
+	Instance  string             `json:"instance"`
+	Event     lifecycleEventType `json:"event"`
+	Timestamp time.Time          `json:"timestamp"`
+}
+
+// parseLifecycleEvent parses an event from a line of output, the caller
+// is responsible for setting the timestamp
+func parseLifecycleEvent(line string) (lifecycleEvent, bool) {
+	if match := instanceCreatedRegex.FindStringSubmatch(line); match != nil {
+		return lifecycleEvent{Instance: match[1], Event: instanceCreated}, true
+	}
+	if match := instanceDeletedRegex.FindStringSubmatch(line); match != nil {
+		return lifecycleEvent{Instance: match[1], Event: instanceDeleted}, true
+	}
+	return lifecycleEvent{}, false
+}
+
+// undeletedInstances returns the instances that were created but never confirmed deleted
+func undeletedInstances(events []lifecycleEvent) []string {
+	deleted := map[string]bool{}
+	for _, event := range events {
+		if event.Event == instanceDeleted {
+			deleted[event.Instance] = true
+		}
+	}
+	var instances []string
+	for _, event := range events {
+		if event.Event == instanceCreated && !deleted[event.Instance] {
+			instances = append(instances, event.Instance)
+		}
+	}
+	return instances
+}
+
+// createdInstances returns the instances that were created, deleted or not
+func createdInstances(events []lifecycleEvent) []string {
+	var instances []string
+	for _, event := range events {
+		if event.Event == instanceCreated {
+			instances = append(instances, event.Instance)
+		}
+	}
+	return instances
+}
+
+// writeLifecycle writes the lifecycle events as json lines to dir
+func writeLifecycle(dir string, events []lifecycleEvent) error {
+	path := filepath.Join(dir, lifecycleFileName)
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer f.Close()
+	encoder := json.NewEncoder(f)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return fmt.Errorf("failed to write lifecycle event: %w", err)
+		}
+	}
+	return f.Close()
+}
+
+// recordLifecycle writes the instance lifecycle observed during a run to
+// artifactsDir and warns about instances that were not confirmed deleted
+// although the outcome of the run, runErr, means they should have been,
+// the kept instances were deliberately not deleted
+func (t *Tester) recordLifecycle(artifactsDir string, output *runOutput, runErr error, kept []string) {
+	if len(output.lifecycle) == 0 {
+		return
+	}
+	if err := writeLifecycle(artifactsDir, output.lifecycle); err != nil {
+		klog.Warningf("failed to record instance lifecycle: %v", err)
+	}
+	if !t.keepInstances(runErr) {
+		keptSet := map[string]bool{}
+		for _, instance := range kept {
+			keptSet[instance] = true
+		}
+		for _, instance := range undeletedInstances(output.lifecycle) {
+			if keptSet[instance] {
+				continue
+			}
+			klog.Warningf("instance %s was created but its deletion was not confirmed", instance)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const lifecycleOutput = `I0102 03:04:05.000000 runner.go:42] Creating instance tmp-node-e2e-cos-1234
+Created [https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/tmp-node-e2e-cos-1234].
+Created [https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/tmp-node-e2e-ubuntu-1234].
+Running Suite: E2eNode Suite
+Deleted [https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/tmp-node-e2e-cos-1234].
+`
+
+func TestLifecycle(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ARTIFACTS", dir)
+	tester := NewDefaultTester()
+	tester.clock = newFakeClock(time.Minute)
+	tester.cmder = &fakeCmder{run: func(cmd *fakeCmd) error {
+		_, _ = io.WriteString(cmd.stderr, lifecycleOutput)
+		return nil
+	}}
+	if err := tester.Test(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, lifecycleFileName))
+	if err != nil {
+		t.Fatalf("failed to read lifecycle: %v", err)
+	}
+	expected := `{"instance":"tmp-node-e2e-cos-1234","event":"created","timestamp":"2026-01-02T03:04:05Z"}
+{"instance":"tmp-node-e2e-ubuntu-1234","event":"created","timestamp":"2026-01-02T03:05:05Z"}
+{"instance":"tmp-node-e2e-cos-1234","event":"deleted","timestamp":"2026-01-02T03:06:05Z"}
+`
+	if string(data) != expected {
+		t.Errorf("expected lifecycle:\n%s\nbut got:\n%s", expected, data)
+	}
+}
+
+func TestUndeletedInstances(t *testing.T) {
+	var events []lifecycleEvent
+	for _, line := range []string{
+		"Created [https://compute/instances/a].",
+		"Created [https://compute/instances/b].",
+		"Deleted [https://compute/instances/a].",
+	} {
+		if event, ok := parseLifecycleEvent(line); ok {
+			events = append(events, event)
+		}
+	}
+	expected := []string{"b"}
+	if actual := undeletedInstances(events); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected undeleted instances %v, but got %v", expected, actual)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"strings"
+)
+
+// listFlag is a flag taking a comma-separated list of values
+type listFlag struct {
+	name  string
+	value *string
+}
+
+// listFlags returns the comma-separated list flags of the tester
+func (t *Tester) listFlags() []listFlag {
+	return []listFlag{
+		{name: "images", value: &t.Images},
+		{name: "node-count-per-image", value: &t.NodeCountPerImage},
+		{name: "instance-metadata", value: &t.InstanceMetadata},
+		{name: "node-env", value: &t.NodeEnv},
+		{name: "node-sysctls", value: &t.NodeSysctls},
+		{name: "feature-gates", value: &t.FeatureGates},
+		{name: "container-runtime-endpoint", value: &t.ContainerRuntimeEndpoint},
+	}
+}
+
+// normalizeList trims the whitespace around each entry of a comma-separated
+// list and drops the empty entries
+func normalizeList(list string) string {
+	var entries []string
+	for _, entry := range strings.Split(list, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return strings.Join(entries, ",")
+}
+
+// normalizeListFlags normalizes every list flag in place, a flag that was set
+// but has no entries left is an error
+func (t *Tester) normalizeListFlags() error {
+	for _, flag := range t.listFlags() {
+		if *flag.value == "" {
+			continue
+		}
+		normalized := normalizeList(*flag.value)
+		if normalized == "" {
+			return fmt.Errorf("--%s was set to %q which contains no values", flag.name, *flag.value)
+		}
+		*flag.value = normalized
+	}
+	return nil
+}
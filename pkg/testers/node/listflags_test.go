@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"testing"
+)
+
+func TestNormalizeListFlags(t *testing.T) {
+	testCases := []struct {
+		name           string
+		images         string
+		featureGates   string
+		expectedImages string
+		expectedGates  string
+		expectErr      bool
+	}{
+		{
+			name: "unset flags are left alone",
+		},
+		{
+			name:           "clean lists are unchanged",
+			images:         "cos-stable,ubuntu-2204",
+			featureGates:   "GateA=true,GateB=false",
+			expectedImages: "cos-stable,ubuntu-2204",
+			expectedGates:  "GateA=true,GateB=false",
+		},
+		{
+			name:           "whitespace is trimmed",
+			images:         " cos-stable , ubuntu-2204\t",
+			featureGates:   "GateA=true ,  GateB=false",
+			expectedImages: "cos-stable,ubuntu-2204",
+			expectedGates:  "GateA=true,GateB=false",
+		},
+		{
+			name:           "empty entries are dropped",
+			images:         ",cos-stable,,ubuntu-2204,",
+			featureGates:   "GateA=true, ,",
+			expectedImages: "cos-stable,ubuntu-2204",
+			expectedGates:  "GateA=true",
+		},
+		{
+			name:      "images without entries",
+			images:    " , ,",
+			expectErr: true,
+		},
+		{
+			name:         "feature gates without entries",
+			images:       "cos-stable",
+			featureGates: ",",
+			expectErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.GCPZone = "us-central1-a"
+			tester.Images = tc.images
+			tester.FeatureGates = tc.featureGates
+			err := tester.validateFlags()
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error for images %q and feature gates %q but got none", tc.images, tc.featureGates)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tester.Images != tc.expectedImages {
+				t.Errorf("expected images %q, but got %q", tc.expectedImages, tester.Images)
+			}
+			if tester.FeatureGates != tc.expectedGates {
+				t.Errorf("expected feature gates %q, but got %q", tc.expectedGates, tester.FeatureGates)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// localProvider runs the node e2e tests on the node the tester runs on, e.g.
+// a kind node or a development machine, without creating any instances
+const localProvider = "local"
+
+// local reports whether the tests run on the machine the tester runs on,
+// with the local provider or with --remote=false
+func (t *Tester) local() bool {
+	return t.Provider == localProvider || !t.Remote
+}
+
+// localArgs returns the make arguments of a local run, which only takes the
+// arguments selecting and configuring the tests, not the instance ones
+func (t *Tester) localArgs() []string {
+	args := []string{
+		"REMOTE=false",
+		"SKIP=" + t.SkipRegex,
+		"FOCUS=" + t.FocusRegex,
+		"TEST_ARGS=" + t.testArgs(),
+		"PARALLELISM=" + strconv.Itoa(t.Parallelism),
+		"FLAKE_ATTEMPTS=" + strconv.Itoa(t.flakeAttempts()),
+		"TIMEOUT=" + t.Timeout.String(),
+		"LABEL_FILTER=" + t.LabelFilter,
+	}
+	if t.RuntimeConfig != "" {
+		args = append(args, "RUNTIME_CONFIG="+t.RuntimeConfig)
+	}
+	args = append(args, t.runtimeEndpointArgs()...)
+	args = append(args, t.buildOutputArgs()...)
+	return append(args, t.MakeVars...)
+}
+
+// validateLocal rejects the flags that configure instances in a local run
+func (t *Tester) validateLocal() error {
+	instanceFlags := []struct {
+		name string
+		set  bool
+	}{
+		{name: "images", set: t.Images != ""},
+		{name: "image-config-file", set: t.ImageConfigFile != ""},
+		{name: "instance-metadata", set: t.InstanceMetadata != ""},
+		{name: "user-data-file", set: t.UserDataFile != ""},
+		{name: "node-startup-script", set: t.NodeStartupScript != ""},
+		{name: "node-sysctls", set: t.NodeSysctls != ""},
+		{name: "cni-plugin", set: t.CNIPlugin != ""},
+		{name: "cni-config", set: t.CNIConfig != ""},
+		{name: "collect-events", set: t.CollectEvents},
+		{name: "collect-kubelet-pprof", set: t.CollectKubeletPprof},
+		{name: "max-parallel-instance-creation", set: t.MaxParallelInstanceCreation > 0},
+		{name: "max-parallel-instance-deletion", set: t.MaxParallelInstanceDeletion > 0},
+		{name: "node-count-per-image", set: t.NodeCountPerImage != ""},
+		{name: "report-quota-usage", set: t.ReportQuotaUsage},
+		{name: "gcp-zones", set: len(t.GCPZones) > 0},
+		{name: "upload-file", set: len(t.UploadFile) > 0},
+		{name: "post-failure-ssh-hold", set: t.PostFailureSSHHold > 0},
+		{name: "ssh-key-path", set: t.SSHKeyPath != ""},
+		{name: "ssh-key-dir", set: t.SSHKeyDir != ""},
+		{name: "ssh-exec-retries", set: t.SSHExecRetries > 0},
+		{name: "reuse-instances", set: t.ReuseInstances},
+		{name: "instance-name-prefix", set: t.InstanceNamePrefix != ""},
+		{name: "project-output-file", set: t.ProjectOutputFile != ""},
+		{name: "azure-resource-group", set: t.AzureResourceGroup != ""},
+		{name: "azure-location", set: t.AzureLocation != ""},
+		{name: "azure-vm-size", set: t.AzureVMSize != ""},
+	}
+	for _, flag := range instanceFlags {
+		if flag.set {
+			return fmt.Errorf("--%s cannot be used with a local run, which creates no instances", flag.name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLocalProviderArgs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		provider string
+		remote   bool
+	}{
+		{name: "local provider", provider: localProvider, remote: true},
+		{name: "remote disabled", provider: "gce", remote: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.Provider = tc.provider
+			tester.Remote = tc.remote
+			tester.FocusRegex = `\[NodeConformance\]`
+			tester.ContainerRuntimeEndpoint = "unix:///run/containerd/containerd.sock"
+			tester.Parallelism = 4
+			tester.Timeout = time.Hour
+			if err := tester.validateFlags(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			expected := []string{
+				"REMOTE=false",
+				"SKIP=" + tester.SkipRegex,
+				`FOCUS=\[NodeConformance\]`,
+				"TEST_ARGS=--container-runtime-endpoint=unix:///run/containerd/containerd.sock",
+				"PARALLELISM=4",
+				"FLAKE_ATTEMPTS=1",
+				"TIMEOUT=1h0m0s",
+				"LABEL_FILTER=",
+			}
+			if args := tester.constructArgs(); !reflect.DeepEqual(args, expected) {
+				t.Errorf("expected args %v, but got %v", expected, args)
+			}
+		})
+	}
+}
+
+func TestLocalProviderSkipsSetup(t *testing.T) {
+	t.Setenv(ciPrivateKeyEnv, "private")
+	t.Setenv(ciPublicKeyEnv, "public")
+	t.Setenv("HOME", t.TempDir())
+	cmder := &fakeCmder{}
+	tester := NewDefaultTester()
+	tester.Remote = false
+	tester.cmder = cmder
+
+	if err := tester.setupProvider(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tester.boskos != nil || tester.GCPProject != "" {
+		t.Errorf("expected no project to be acquired from boskos, but got %q", tester.GCPProject)
+	}
+	if tester.privateKey != "" || tester.sshUser != "" {
+		t.Errorf("expected no ssh setup, but got key %q and user %q", tester.privateKey, tester.sshUser)
+	}
+	if len(cmder.cmds) != 0 {
+		t.Errorf("expected no commands to be run, but got %s %v", cmder.cmds[0].name, cmder.cmds[0].args)
+	}
+}
+
+func TestLocalProviderRejectsInstanceFlags(t *testing.T) {
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.Provider = localProvider
+	tester.Images = "cos-stable"
+	if err := tester.validateFlags(); err == nil {
+		t.Error("expected --images to be rejected with the local provider")
+	}
+
+	tester = NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.Remote = false
+	tester.ReportQuotaUsage = true
+	if err := tester.validateFlags(); err == nil {
+		t.Error("expected --report-quota-usage to be rejected with --remote=false")
+	}
+
+	tester = NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.Provider = localProvider
+	tester.AzureLocation = "eastus"
+	if err := tester.validateFlags(); err == nil {
+		t.Error("expected --azure-location to be rejected with the local provider")
+	}
+}
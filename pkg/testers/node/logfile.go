@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"k8s.io/klog/v2"
+)
+
+// lockedWriter serializes the writes to w, so that the stdout and stderr of
+// the tests can be combined without interleaving within a write
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+// openLogFile creates --log-file, truncating it if it exists, and tees the
+// output of the tests to it until the returned function is called
+func (t *Tester) openLogFile() (closeLogFile func(), err error) {
+	if err := os.MkdirAll(filepath.Dir(t.LogFile), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create the directory of the log file: %w", err)
+	}
+	f, err := os.Create(t.LogFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the log file: %w", err)
+	}
+	t.logFile = &lockedWriter{w: f}
+	return func() {
+		t.logFile = nil
+		if err := f.Close(); err != nil {
+			klog.Warningf("failed to close the log file %s: %v", t.LogFile, err)
+		}
+	}, nil
+}
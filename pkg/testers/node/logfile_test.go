@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLogFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ARTIFACTS", filepath.Join(dir, "artifacts"))
+	logFile := filepath.Join(dir, "logs", "node-e2e", "run.log")
+	writeArtifact(t, dir, "logs/node-e2e/run.log", "output of a previous run\n")
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		_, _ = io.WriteString(cmd.stdout, "Running Suite: E2eNode Suite\n")
+		_, _ = io.WriteString(cmd.stderr, "W0102 03:04:05.000000 runner.go:42] a warning\n")
+		return nil
+	}}
+	tester := NewDefaultTester()
+	tester.LogFile = logFile
+	tester.cmder = cmder
+
+	if err := tester.Test(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	contents, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("failed to read the log file: %v", err)
+	}
+	expected := "Running Suite: E2eNode Suite\nW0102 03:04:05.000000 runner.go:42] a warning\n"
+	if string(contents) != expected {
+		t.Errorf("expected log file %q, but got %q", expected, string(contents))
+	}
+	if tester.logFile != nil {
+		t.Errorf("expected the log file to no longer be written to once the tests completed")
+	}
+}
+
+func TestLogFileCreateError(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ARTIFACTS", filepath.Join(dir, "artifacts"))
+	writeArtifact(t, dir, "not-a-dir", "")
+	cmder := &fakeCmder{}
+	tester := NewDefaultTester()
+	tester.LogFile = filepath.Join(dir, "not-a-dir", "run.log")
+	tester.cmder = cmder
+
+	if err := tester.Test(); err == nil {
+		t.Errorf("expected an error creating the log file")
+	}
+	if len(cmder.cmds) != 0 {
+		t.Errorf("expected the tests not to run, but got %v", cmder.cmds)
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"strings"
+)
+
+// validateMakeVars checks that each --make-var is a KEY=VALUE entry
+func validateMakeVars(vars []string) error {
+	for _, v := range vars {
+		if strings.Count(v, "=") != 1 {
+			return fmt.Errorf("%q must contain exactly one =", v)
+		}
+		if key, _, _ := strings.Cut(v, "="); strings.TrimSpace(key) == "" {
+			return fmt.Errorf("%q has an empty key", v)
+		}
+	}
+	return nil
+}
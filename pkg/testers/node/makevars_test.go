@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"testing"
+)
+
+func TestValidateMakeVars(t *testing.T) {
+	testCases := []struct {
+		name      string
+		vars      []string
+		expectErr bool
+	}{
+		{name: "valid", vars: []string{"KUBE_VERBOSE=5", "GOFLAGS="}},
+		{name: "no =", vars: []string{"KUBE_VERBOSE"}, expectErr: true},
+		{name: "more than one =", vars: []string{"TEST_ARGS=--v=4"}, expectErr: true},
+		{name: "empty key", vars: []string{"=5"}, expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.GCPZone = "us-central1-a"
+			tester.MakeVars = tc.vars
+			err := tester.validateFlags()
+			if tc.expectErr && err == nil {
+				t.Errorf("expected %v to be rejected", tc.vars)
+			}
+			if !tc.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestMakeVarsOverrideArgs(t *testing.T) {
+	for _, provider := range []string{"gce", localProvider} {
+		tester := NewDefaultTester()
+		tester.RepoRoot = fakeRepoRoot(t)
+		tester.GCPZone = "us-central1-a"
+		tester.Provider = provider
+		tester.MakeVars = []string{"KUBE_VERBOSE=5", "PARALLELISM=2"}
+		if err := tester.validateFlags(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		args := tester.constructArgs()
+		if actual := argValue(t, args, "KUBE_VERBOSE"); actual != "5" {
+			t.Errorf("expected KUBE_VERBOSE=5 to be passed with the %s provider, but got %q", provider, actual)
+		}
+		if actual := argValue(t, args, "PARALLELISM"); actual != "2" {
+			t.Errorf("expected PARALLELISM=2 to override the built-in value with the %s provider, but got %q", provider, actual)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kubetest2/pkg/boskos"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/testers"
+)
+
+const (
+	runManifestFileName = "run-manifest.yaml"
+	unknownVersion      = "unknown"
+)
+
+// runManifest captures what is needed to reproduce a run
+type runManifest struct {
+	RunID string `json:"runID"`
+	// Config is the value of every tester flag after defaulting and validation
+	Config   map[string]string `json:"config"`
+	Versions map[string]string `json:"versions"`
+	RepoRoot repoRootState     `json:"repoRoot"`
+	// Command is the make invocation of the tests, run in RepoRoot
+	Command []string `json:"command"`
+}
+
+// repoRootState is the git checkout the tests were built from
+type repoRootState struct {
+	Path   string `json:"path"`
+	Commit string `json:"commit"`
+	// Dirty is set when the checkout has uncommitted changes
+	Dirty bool `json:"dirty"`
+}
+
+// commandOutput runs name with args and returns the first line of its output
+func (t *Tester) commandOutput(name string, args ...string) (string, error) {
+	lines, err := exec.CombinedOutputLines(t.cmder.Command(name, args...))
+	if err != nil {
+		return "", fmt.Errorf("%s %s failed: %w", name, strings.Join(args, " "), err)
+	}
+	if len(lines) == 0 {
+		return "", nil
+	}
+	return strings.TrimSpace(lines[0]), nil
+}
+
+// toolVersions returns the versions of the tester and the tools the run uses
+func (t *Tester) toolVersions() map[string]string {
+	versions := map[string]string{
+		"kubetest2-tester-node": GitTag,
+		"go":                    runtime.Version(),
+	}
+	tools := map[string][]string{"make": {"--version"}}
+	switch t.Provider {
+	case "gce":
+		tools["gcloud"] = []string{"--version"}
+	case "ec2":
+		tools["aws"] = []string{"--version"}
+	}
+	for tool, args := range tools {
+		version, err := t.commandOutput(tool, args...)
+		if err != nil || version == "" {
+			klog.V(1).Infof("failed to find the version of %s: %v", tool, err)
+			version = unknownVersion
+		}
+		versions[tool] = version
+	}
+	return versions
+}
+
+// repoRootState returns the commit RepoRoot is checked out at
+func (t *Tester) repoRootState() repoRootState {
+	state := repoRootState{Path: t.RepoRoot, Commit: unknownVersion}
+	commit, err := t.commandOutput("git", "-C", t.RepoRoot, "rev-parse", "HEAD")
+	if err != nil || commit == "" {
+		klog.Warningf("failed to find the commit of %s: %v", t.RepoRoot, err)
+		return state
+	}
+	state.Commit = commit
+	status, err := t.commandOutput("git", "-C", t.RepoRoot, "status", "--porcelain")
+	if err != nil {
+		klog.Warningf("failed to find whether %s has uncommitted changes: %v", t.RepoRoot, err)
+	}
+	state.Dirty = status != ""
+	return state
+}
+
+// flagValues returns the current value of every tester flag, with the values
+// that may be credentials redacted
+func (t *Tester) flagValues() (map[string]string, error) {
+	fs, err := testers.ParseFlags(t)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]string{}
+	fs.VisitAll(func(f *pflag.Flag) {
+		values[f.Name] = f.Value.String()
+	})
+	// the boskos headers may carry credentials
+	if len(t.BoskosHeader) > 0 {
+		values["boskos-header"] = boskos.RedactHeaders(t.boskosHeaders)
+	}
+	return values, nil
+}
+
+// writeRunManifest writes run-manifest.yaml to artifactsDir
+func (t *Tester) writeRunManifest(artifactsDir string) error {
+	config, err := t.flagValues()
+	if err != nil {
+		return err
+	}
+	manifest := runManifest{
+		RunID:    t.runID,
+		Config:   config,
+		Versions: t.toolVersions(),
+		RepoRoot: t.repoRootState(),
+		Command:  append([]string{"make", t.makeTarget()}, t.constructArgs()...),
+	}
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to encode the run manifest: %w", err)
+	}
+	if err := os.MkdirAll(artifactsDir, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create artifacts directory %s: %w", artifactsDir, err)
+	}
+	path := filepath.Join(artifactsDir, runManifestFileName)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+func TestWriteRunManifest(t *testing.T) {
+	dir := t.TempDir()
+	repoRoot := fakeRepoRoot(t)
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		switch cmd.name + " " + strings.Join(cmd.args, " ") {
+		case "git -C " + repoRoot + " rev-parse HEAD":
+			_, _ = io.WriteString(cmd.stdout, "0123456789abcdef0123456789abcdef01234567\n")
+		case "git -C " + repoRoot + " status --porcelain":
+			_, _ = io.WriteString(cmd.stdout, " M test/e2e_node/node_test.go\n")
+		case "make --version":
+			_, _ = io.WriteString(cmd.stdout, "GNU Make 4.3\nBuilt for x86_64-pc-linux-gnu\n")
+		default:
+			return errors.New("command not found")
+		}
+		return nil
+	}}
+	tester := NewDefaultTester()
+	tester.RepoRoot = repoRoot
+	tester.GCPProject = "p"
+	tester.GCPZone = "us-central1-a"
+	tester.FocusRegex = `\[NodeConformance\]`
+	tester.runID = "run"
+	tester.cmder = cmder
+	if err := tester.validateFlags(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := tester.writeRunManifest(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, runManifestFileName))
+	if err != nil {
+		t.Fatalf("failed to read the run manifest: %v", err)
+	}
+	var manifest runManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("failed to parse the run manifest: %v", err)
+	}
+	if manifest.RunID != "run" {
+		t.Errorf("expected run ID run, but got %q", manifest.RunID)
+	}
+	expectedRepoRoot := repoRootState{Path: repoRoot, Commit: "0123456789abcdef0123456789abcdef01234567", Dirty: true}
+	if manifest.RepoRoot != expectedRepoRoot {
+		t.Errorf("expected repo root %+v, but got %+v", expectedRepoRoot, manifest.RepoRoot)
+	}
+	for name, expected := range map[string]string{"focus-regex": `\[NodeConformance\]`, "gcp-zone": "us-central1-a", "parallelism": "8"} {
+		if actual := manifest.Config[name]; actual != expected {
+			t.Errorf("expected config %s=%s, but got %q", name, expected, actual)
+		}
+	}
+	for tool, expected := range map[string]string{"make": "GNU Make 4.3", "gcloud": unknownVersion} {
+		if actual := manifest.Versions[tool]; actual != expected {
+			t.Errorf("expected %s version %q, but got %q", tool, expected, actual)
+		}
+	}
+	if len(manifest.Command) < 2 || manifest.Command[0] != "make" || manifest.Command[1] != defaultTarget {
+		t.Fatalf("expected the make command, but got %v", manifest.Command)
+	}
+	if actual := argValue(t, manifest.Command, "FOCUS"); actual != `\[NodeConformance\]` {
+		t.Errorf("expected FOCUS in the command, but got %q", actual)
+	}
+	if actual := argValue(t, manifest.Command, "ZONE"); actual != "us-central1-a" {
+		t.Errorf("expected ZONE in the command, but got %q", actual)
+	}
+}
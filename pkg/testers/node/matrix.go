@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/klog/v2"
+)
+
+const compatibilityMatrixFileName = "compatibility-matrix.json"
+
+const (
+	matrixPass = "pass"
+	matrixFail = "fail"
+)
+
+// compatibilityMatrix is the outcome of the run on each image, the rows, with
+// each container runtime, the columns. A cell passes if every spec that ran on
+// the image with the runtime passed, a combination without specs has no cell.
+type compatibilityMatrix struct {
+	Images   []string                     `json:"images"`
+	Runtimes []string                     `json:"runtimes"`
+	Cells    map[string]map[string]string `json:"cells"`
+}
+
+// matrixRun reports whether the run covers more than one image or container runtime
+func (t *Tester) matrixRun() bool {
+	return strings.Count(t.Images, ",") > 0 || len(t.runtimeEndpoints) > 1
+}
+
+// compatibilityMatrix aggregates the results of the specs in artifactsDir by
+// the image and container runtime they ran on, in the order of --images and
+// --container-runtime-endpoint
+func (t *Tester) compatibilityMatrix(artifactsDir string, specs []SpecResult) compatibilityMatrix {
+	matrix := compatibilityMatrix{Cells: map[string]map[string]string{}}
+	seenImages, seenRuntimes := map[string]bool{}, map[string]bool{}
+	for _, spec := range specs {
+		if spec.Status == SpecSkipped {
+			continue
+		}
+		image, runtime := t.specImage(spec.File), t.specRuntime(artifactsDir, spec.File)
+		if image == "" {
+			image = "unknown"
+		}
+		if runtime == "" {
+			runtime = "unknown"
+		}
+		seenImages[image], seenRuntimes[runtime] = true, true
+		if matrix.Cells[image] == nil {
+			matrix.Cells[image] = map[string]string{}
+		}
+		if spec.Status == SpecFailed {
+			matrix.Cells[image][runtime] = matrixFail
+		} else if matrix.Cells[image][runtime] == "" {
+			matrix.Cells[image][runtime] = matrixPass
+		}
+	}
+	var images, runtimes []string
+	for _, image := range strings.Split(t.Images, ",") {
+		if image != "" {
+			images = append(images, image)
+		}
+	}
+	for _, endpoint := range t.runtimeEndpoints {
+		runtimes = append(runtimes, endpoint.label)
+	}
+	matrix.Images = orderedLabels(images, seenImages)
+	matrix.Runtimes = orderedLabels(runtimes, seenRuntimes)
+	return matrix
+}
+
+// orderedLabels returns the configured labels, followed by the other seen
+// labels sorted
+func orderedLabels(configured []string, seen map[string]bool) []string {
+	labels := append([]string{}, configured...)
+	known := map[string]bool{}
+	for _, label := range configured {
+		known[label] = true
+	}
+	var others []string
+	for label := range seen {
+		if !known[label] {
+			others = append(others, label)
+		}
+	}
+	sort.Strings(others)
+	return append(labels, others...)
+}
+
+// formatTable formats the matrix as a table with a row per image and a
+// column per runtime, combinations without specs are shown as -
+func (m compatibilityMatrix) formatTable() string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "IMAGE\t%s\n", strings.Join(m.Runtimes, "\t"))
+	for _, image := range m.Images {
+		row := []string{image}
+		for _, runtime := range m.Runtimes {
+			cell := m.Cells[image][runtime]
+			if cell == "" {
+				cell = "-"
+			}
+			row = append(row, cell)
+		}
+		fmt.Fprintf(w, "%s\n", strings.Join(row, "\t"))
+	}
+	_ = w.Flush()
+	return buf.String()
+}
+
+// writeCompatibilityMatrix writes the compatibility matrix of the results in
+// artifactsDir to compatibility-matrix.json and logs it as a table
+func (t *Tester) writeCompatibilityMatrix(artifactsDir string) error {
+	results, err := t.results(artifactsDir)
+	if err != nil {
+		return err
+	}
+	matrix := t.compatibilityMatrix(artifactsDir, results.Specs)
+	klog.V(0).Infof("compatibility matrix:\n%s", matrix.formatTable())
+	return writeJSON(filepath.Join(artifactsDir, compatibilityMatrixFileName), matrix)
+}
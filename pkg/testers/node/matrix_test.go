@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWriteCompatibilityMatrix(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, dir, "containerd/junit_tmp-node-e2e-1234-cos-stable_01.xml", sampleJUnit)
+	writeArtifact(t, dir, "crio/junit_tmp-node-e2e-5678-cos-stable_01.xml",
+		`<testsuite name="E2eNode Suite"><testcase name="[It] other" time="1"></testcase></testsuite>`)
+	writeArtifact(t, dir, "crio/junit_tmp-node-e2e-5678-ubuntu_01.xml",
+		`<testsuite name="E2eNode Suite"><testcase name="[It] other" time="3.25"></testcase>`+
+			`<testcase name="[It] not run" time="0"><skipped/></testcase></testsuite>`)
+	writeArtifact(t, dir, "crio/junit_tmp-node-e2e-5678-ubuntu_02.xml",
+		`<testsuite name="E2eNode Suite"><testcase name="[It] broken" time="2"><failure>boom</failure></testcase></testsuite>`)
+	endpoints, err := parseRuntimeEndpoints("containerd=unix:///run/containerd/containerd.sock,crio=unix:///run/crio/crio.sock")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tester := NewDefaultTester()
+	tester.Images = "cos-stable,ubuntu"
+	tester.runtimeEndpoints = endpoints
+	if !tester.matrixRun() {
+		t.Fatalf("expected a run over two images and runtimes to produce a compatibility matrix")
+	}
+
+	if err := tester.writeCompatibilityMatrix(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	matrix := readJSON(t, filepath.Join(dir, compatibilityMatrixFileName))
+	expected := map[string]interface{}{
+		"images":   []interface{}{"cos-stable", "ubuntu"},
+		"runtimes": []interface{}{"containerd", "crio"},
+		"cells": map[string]interface{}{
+			"cos-stable": map[string]interface{}{"containerd": "fail", "crio": "pass"},
+			"ubuntu":     map[string]interface{}{"crio": "fail"},
+		},
+	}
+	if !reflect.DeepEqual(matrix, expected) {
+		t.Errorf("expected compatibility matrix %v, but got %v", expected, matrix)
+	}
+}
+
+func TestCompatibilityMatrixTable(t *testing.T) {
+	tester := NewDefaultTester()
+	tester.Images = "cos-stable,ubuntu"
+	specs := []SpecResult{
+		{Name: "[It] a", Status: SpecPassed, File: "/artifacts/junit_tmp-node-e2e-1-cos-stable_01.xml"},
+		{Name: "[It] b", Status: SpecSkipped, File: "/artifacts/junit_tmp-node-e2e-1-ubuntu_01.xml"},
+		{Name: "[It] c", Status: SpecFailed, File: "/artifacts/junit_tmp-node-e2e-1-fedora_01.xml"},
+	}
+
+	matrix := tester.compatibilityMatrix("/artifacts", specs)
+	expected := "IMAGE                  unknown\n" +
+		"cos-stable             pass\n" +
+		"ubuntu                 -\n" +
+		"tmp-node-e2e-1-fedora  fail\n"
+	if actual := matrix.formatTable(); actual != expected {
+		t.Errorf("expected table\n%s\nbut got\n%s", expected, actual)
+	}
+}
+
+func TestMatrixRun(t *testing.T) {
+	tester := NewDefaultTester()
+	tester.Images = "cos-stable"
+	if tester.matrixRun() {
+		t.Errorf("expected a run over a single image and runtime not to produce a compatibility matrix")
+	}
+}
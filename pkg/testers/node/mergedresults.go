@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// mergedJUnitFileName is outside the junit*.xml pattern, so neither the
+	// tester nor the CI tooling reading the artifacts count the specs twice
+	mergedJUnitFileName = "merged-results.xml"
+	summaryTextFileName = "summary.txt"
+	mergedSuiteName     = "E2eNode Suite"
+)
+
+// Results returns the results of the last call to Test, merged across the
+// junit files of every instance and sub-run, or nil if it didn't get to read them
+func (t *Tester) Results() *Summary {
+	return t.mergedResults
+}
+
+// mergedJUnitSuite is the single testsuite merged-results.xml consists of
+type mergedJUnitSuite struct {
+	XMLName  xml.Name          `xml:"testsuite"`
+	Name     string            `xml:"name,attr"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Skipped  int               `xml:"skipped,attr"`
+	Time     float64           `xml:"time,attr"`
+	Cases    []mergedJUnitCase `xml:"testcase"`
+}
+
+type mergedJUnitCase struct {
+	Name    string        `xml:"name,attr"`
+	Time    float64       `xml:"time,attr"`
+	Failure *junitMessage `xml:"failure,omitempty"`
+	Skipped *junitMessage `xml:"skipped,omitempty"`
+}
+
+// mergeJUnit merges the spec results into a single testsuite
+func mergeJUnit(results *Summary) mergedJUnitSuite {
+	suite := mergedJUnitSuite{
+		Name:     mergedSuiteName,
+		Tests:    results.Total(),
+		Failures: results.Failed,
+		Skipped:  results.Skipped,
+	}
+	for _, spec := range results.Specs {
+		c := mergedJUnitCase{Name: spec.Name, Time: spec.Duration.Seconds()}
+		switch spec.Status {
+		case SpecFailed:
+			c.Failure = &junitMessage{Message: spec.Message}
+		case SpecSkipped:
+			c.Skipped = &junitMessage{Message: spec.Message}
+		}
+		suite.Time += c.Time
+		suite.Cases = append(suite.Cases, c)
+	}
+	return suite
+}
+
+// formatResults formats the results as the human readable summary.txt
+func formatResults(results *Summary) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "total: %d, passed: %d, failed: %d, skipped: %d\n", results.Total(), results.Passed, results.Failed, results.Skipped)
+	if failed := results.failedSpecs(); len(failed) > 0 {
+		buf.WriteString("failed specs:\n")
+		for _, name := range failed {
+			fmt.Fprintf(&buf, "  %s\n", name)
+		}
+	}
+	return buf.String()
+}
+
+// mergeResults reads the results in artifactsDir, merges them into
+// merged-results.xml and writes a human readable summary to summary.txt
+func (t *Tester) mergeResults(artifactsDir string) (*Summary, error) {
+	results, err := t.results(artifactsDir)
+	if err != nil {
+		return nil, err
+	}
+	t.mergedResults = results
+	if results.Total() == 0 {
+		return results, nil
+	}
+
+	data, err := xml.MarshalIndent(mergeJUnit(results), "", "  ")
+	if err != nil {
+		return results, fmt.Errorf("failed to encode the merged junit results: %w", err)
+	}
+	data = append([]byte(xml.Header), data...)
+	if err := os.WriteFile(filepath.Join(artifactsDir, mergedJUnitFileName), append(data, '\n'), 0644); err != nil {
+		return results, fmt.Errorf("failed to write the merged junit results: %w", err)
+	}
+	formatted := formatResults(results)
+	if err := os.WriteFile(filepath.Join(artifactsDir, summaryTextFileName), []byte(formatted), 0644); err != nil {
+		return results, fmt.Errorf("failed to write the summary: %w", err)
+	}
+	klog.V(0).Infof("merged results:\n%s", formatted)
+	return results, nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMergeResults(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ARTIFACTS", dir)
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		writeArtifact(t, dir, "junit_tmp-node-e2e-1234-cos-stable_01.xml", sampleJUnit)
+		writeArtifact(t, dir, "junit_tmp-node-e2e-1234-cos-stable_02.xml",
+			`<testsuite name="E2eNode Suite"><testcase name="[It] other" time="3.25"></testcase></testsuite>`)
+		writeArtifact(t, dir, "ubuntu/junit_tmp-node-e2e-5678-ubuntu_01.xml",
+			`<testsuite name="E2eNode Suite"><testcase name="[It] broken" time="1"><failure message="boom"></failure></testcase></testsuite>`)
+		return errors.New("specs failed")
+	}}
+	tester := NewDefaultTester()
+	tester.Parallelism = 2
+	tester.cmder = cmder
+
+	if err := tester.Test(); err == nil {
+		t.Fatalf("expected the failed specs to fail the run")
+	}
+
+	actual := tester.Results()
+	if actual == nil {
+		t.Fatalf("expected the merged results")
+	}
+	if actual.Passed != 2 || actual.Failed != 3 || actual.Skipped != 1 {
+		t.Errorf("expected 2 passed, 3 failed and 1 skipped, but got %d, %d and %d", actual.Passed, actual.Failed, actual.Skipped)
+	}
+	expectedFailed := []string{"[It] broken", "[It] known flake", "[It] regression"}
+	if failed := actual.failedSpecs(); !reflect.DeepEqual(failed, expectedFailed) {
+		t.Errorf("expected failed specs %v, but got %v", expectedFailed, failed)
+	}
+	if isJUnitResultsFile(mergedJUnitFileName) {
+		t.Errorf("expected %s not to match the junit pattern", mergedJUnitFileName)
+	}
+
+	merged, err := parseJUnitFile(filepath.Join(dir, mergedJUnitFileName))
+	if err != nil {
+		t.Fatalf("failed to parse the merged junit results: %v", err)
+	}
+	statuses := map[string]SpecStatus{}
+	for _, spec := range merged {
+		statuses[spec.Name] = spec.Status
+	}
+	expectedStatuses := map[string]SpecStatus{
+		"[It] known flake": SpecFailed,
+		"[It] fixed bug":   SpecPassed,
+		"[It] regression":  SpecFailed,
+		"[It] not run":     SpecSkipped,
+		"[It] other":       SpecPassed,
+		"[It] broken":      SpecFailed,
+	}
+	if !reflect.DeepEqual(statuses, expectedStatuses) {
+		t.Errorf("expected merged specs %v, but got %v", expectedStatuses, statuses)
+	}
+
+	summary, err := os.ReadFile(filepath.Join(dir, summaryTextFileName))
+	if err != nil {
+		t.Fatalf("failed to read the summary: %v", err)
+	}
+	expectedSummary := `total: 6, passed: 2, failed: 3, skipped: 1
+failed specs:
+  [It] broken
+  [It] known flake
+  [It] regression
+`
+	if string(summary) != expectedSummary {
+		t.Errorf("expected summary:\n%s\nbut got:\n%s", expectedSummary, summary)
+	}
+
+	// the merged results are not counted again
+	results, err := tester.results(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results.Total() != 6 {
+		t.Errorf("expected 6 specs in the results, but got %d", results.Total())
+	}
+}
+
+func TestMergeResultsWithoutSpecs(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ARTIFACTS", dir)
+	tester := NewDefaultTester()
+	tester.cmder = &fakeCmder{}
+
+	if err := tester.Test(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, name := range []string{mergedJUnitFileName, summaryTextFileName} {
+		if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+			t.Errorf("expected no %s without specs, but got %v", name, err)
+		}
+	}
+}
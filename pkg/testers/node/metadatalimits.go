@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GCE instance metadata limits, see
+// https://cloud.google.com/compute/docs/metadata/setting-custom-metadata#limitations
+const (
+	gceMetadataKeyMax   = 128
+	gceMetadataValueMax = 256 * 1024
+	gceMetadataTotalMax = 512 * 1024
+)
+
+// validateMetadataLimits checks the instance metadata, including the NodeEnv
+// entries which are also added as metadata, against the GCE limits so that
+// an oversized entry fails before any instance is created. The size of the
+// values read from files is checked if the file exists.
+func (t *Tester) validateMetadataLimits() error {
+	if t.Provider != "gce" {
+		return nil
+	}
+	values, fromFile := splitInstanceMetadata(t.instanceMetadata())
+	nodeEnv, _ := splitInstanceMetadata(t.nodeEnv())
+	values = append(values, nodeEnv...)
+
+	total := 0
+	check := func(key string, size int) error {
+		if len(key) > gceMetadataKeyMax {
+			return fmt.Errorf("metadata key %q is %d bytes, longer than the %d bytes allowed", key, len(key), gceMetadataKeyMax)
+		}
+		if size > gceMetadataValueMax {
+			return fmt.Errorf("metadata value of %q is %d bytes, larger than the %d bytes allowed", key, size, gceMetadataValueMax)
+		}
+		total += len(key) + size
+		return nil
+	}
+	for _, entry := range values {
+		key, value, _ := strings.Cut(entry, "=")
+		if err := check(key, len(value)); err != nil {
+			return err
+		}
+	}
+	for _, entry := range fromFile {
+		key, file, _ := strings.Cut(entry, "=")
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(t.RepoRoot, file)
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		if err := check(key, int(info.Size())); err != nil {
+			return err
+		}
+	}
+	if total > gceMetadataTotalMax {
+		return fmt.Errorf("metadata is %d bytes in total, larger than the %d bytes allowed", total, gceMetadataTotalMax)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMetadataLimits(t *testing.T) {
+	dir := fakeRepoRoot(t)
+	writeArtifact(t, dir, "cloud-init.yaml", strings.Repeat("a", 1024))
+	writeArtifact(t, dir, "large.yaml", strings.Repeat("a", gceMetadataValueMax+1))
+	writeArtifact(t, dir, "half.yaml", strings.Repeat("a", gceMetadataValueMax))
+
+	testCases := []struct {
+		name             string
+		provider         string
+		instanceMetadata string
+		nodeEnv          string
+		expectedErr      string
+	}{
+		{
+			name:             "within limits",
+			provider:         "gce",
+			instanceMetadata: "user-data<cloud-init.yaml,cos-update-strategy=update_disabled",
+			nodeEnv:          "PATH=/usr/bin",
+		},
+		{
+			name:             "missing file is not checked",
+			provider:         "gce",
+			instanceMetadata: "user-data<missing.yaml",
+		},
+		{
+			name:             "key too long",
+			provider:         "gce",
+			instanceMetadata: strings.Repeat("k", gceMetadataKeyMax+1) + "=v",
+			expectedErr:      strings.Repeat("k", gceMetadataKeyMax+1),
+		},
+		{
+			name:        "node env value too long",
+			provider:    "gce",
+			nodeEnv:     "LARGE=" + strings.Repeat("v", gceMetadataValueMax+1),
+			expectedErr: `"LARGE"`,
+		},
+		{
+			name:             "file too large",
+			provider:         "gce",
+			instanceMetadata: "user-data<large.yaml",
+			expectedErr:      `"user-data"`,
+		},
+		{
+			name:             "total too large",
+			provider:         "gce",
+			instanceMetadata: "user-data<half.yaml,other-data<half.yaml",
+			expectedErr:      "in total",
+		},
+		{
+			name:             "not checked on ec2",
+			provider:         "ec2",
+			instanceMetadata: strings.Repeat("k", gceMetadataKeyMax+1) + "=v",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.RepoRoot = dir
+			tester.GCPZone = "us-central1-a"
+			tester.InstanceType = "m5.large"
+			tester.UserDataFile = "user-data.sh"
+			tester.Provider = tc.provider
+			tester.InstanceMetadata = tc.instanceMetadata
+			tester.NodeEnv = tc.nodeEnv
+			err := tester.validateFlags()
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+				t.Errorf("expected an error naming %s, but got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
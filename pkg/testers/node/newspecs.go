@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// listSpecsDirName is the artifacts subdirectory of the dry run listing the specs
+const listSpecsDirName = "list-specs"
+
+// newSpecs returns the sorted specs in current that are not in baseline
+func newSpecs(current []string, baseline map[string]bool) []string {
+	var names []string
+	for _, name := range current {
+		if !baseline[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// writeSpecList writes the spec names to path, one per line, in the format read by loadSpecList
+func writeSpecList(path string, names []string) error {
+	sorted := append([]string{}, names...)
+	sort.Strings(sorted)
+	data := strings.Join(sorted, "\n")
+	if len(sorted) > 0 {
+		data += "\n"
+	}
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// listSpecs lists the specs selected by the focus and skip regexes with a dry
+// run of the suite, which reports every spec it would run without running it
+func (t *Tester) listSpecs(artifactsDir string) ([]string, error) {
+	sub := *t
+	sub.TestArgs = strings.TrimSpace(t.TestArgs + " --ginkgo.dry-run")
+	sub.knownFailures = nil
+	sub.DetectKubeletRestarts = false
+	sub.Warmup = false
+	dir := filepath.Join(artifactsDir, listSpecsDirName)
+	klog.V(0).Infof("listing specs with a dry run")
+	if err := sub.runOnce(dir); err != nil {
+		return nil, fmt.Errorf("failed to list specs: %w", err)
+	}
+	results, err := sub.results(dir)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(results.Specs))
+	for _, spec := range results.Specs {
+		if spec.Status != SpecSkipped {
+			names = append(names, spec.Name)
+		}
+	}
+	return names, nil
+}
+
+// focusOnNewSpecs focuses the run on the specs that are not in the
+// FocusOnNewSpecsSince baseline, returning false if there are none
+func (t *Tester) focusOnNewSpecs(artifactsDir string) (bool, error) {
+	baseline := map[string]bool{}
+	if _, err := os.Stat(t.FocusOnNewSpecsSince); err == nil || !t.UpdateSpecBaseline {
+		if baseline, err = loadSpecList(t.FocusOnNewSpecsSince); err != nil {
+			return false, err
+		}
+	}
+	current, err := t.listSpecs(artifactsDir)
+	if err != nil {
+		return false, err
+	}
+	if t.UpdateSpecBaseline {
+		if err := writeSpecList(t.FocusOnNewSpecsSince, current); err != nil {
+			return false, err
+		}
+		klog.V(0).Infof("updated the spec baseline %s with %d specs", t.FocusOnNewSpecsSince, len(current))
+	}
+
+	added := newSpecs(current, baseline)
+	if len(added) == 0 {
+		return false, nil
+	}
+	for _, name := range added {
+		klog.V(0).Infof("new spec: %s", name)
+	}
+	t.FocusRegex = specFocusRegex(added)
+	return true, nil
+}
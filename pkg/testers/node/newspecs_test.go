@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const dryRunJUnit = `<testsuite>
+  <testcase name="[It] [sig-node] Pods should start"/>
+  <testcase name="[It] [sig-node] Pods should stop"/>
+  <testcase name="[It] [sig-node] Probes should restart (liveness)"/>
+  <testcase name="[It] [sig-node] Slow thing"><skipped message="skipped"/></testcase>
+</testsuite>`
+
+func TestFocusOnNewSpecs(t *testing.T) {
+	testCases := []struct {
+		name             string
+		baseline         *string
+		update           bool
+		expectedFocuses  []string
+		expectedBaseline string
+		expectErr        bool
+	}{
+		{
+			name:     "new specs are focused",
+			baseline: stringPtr("# specs as of v1.30\n[It] [sig-node] Pods should start\n"),
+			expectedFocuses: []string{
+				`\[sig-node\] Pods should stop|\[sig-node\] Probes should restart \(liveness\)`,
+			},
+		},
+		{
+			name:     "no new specs",
+			baseline: stringPtr("[It] [sig-node] Pods should start\n[It] [sig-node] Pods should stop\n[It] [sig-node] Probes should restart (liveness)\n"),
+		},
+		{
+			name:      "missing baseline",
+			expectErr: true,
+		},
+		{
+			name:   "missing baseline is created",
+			update: true,
+			expectedFocuses: []string{
+				`\[sig-node\] Pods should start|\[sig-node\] Pods should stop|\[sig-node\] Probes should restart \(liveness\)`,
+			},
+			expectedBaseline: "[It] [sig-node] Pods should start\n[It] [sig-node] Pods should stop\n[It] [sig-node] Probes should restart (liveness)\n",
+		},
+		{
+			name:     "baseline is updated",
+			baseline: stringPtr("[It] [sig-node] Pods should start\n[It] [sig-node] Removed spec\n"),
+			update:   true,
+			expectedFocuses: []string{
+				`\[sig-node\] Pods should stop|\[sig-node\] Probes should restart \(liveness\)`,
+			},
+			expectedBaseline: "[It] [sig-node] Pods should start\n[It] [sig-node] Pods should stop\n[It] [sig-node] Probes should restart (liveness)\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("ARTIFACTS", filepath.Join(dir, "artifacts"))
+			baselinePath := filepath.Join(dir, "baseline.txt")
+			if tc.baseline != nil {
+				writeArtifact(t, dir, "baseline.txt", *tc.baseline)
+			}
+			var focuses []string
+			cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+				artifactsDir := argValue(t, cmd.env, "ARTIFACTS")
+				if strings.Contains(argValue(t, cmd.args, "TEST_ARGS"), "--ginkgo.dry-run") {
+					if filepath.Base(artifactsDir) != listSpecsDirName {
+						t.Errorf("expected the dry run in %s, but got %s", listSpecsDirName, artifactsDir)
+					}
+					writeArtifact(t, artifactsDir, "junit_01.xml", dryRunJUnit)
+					return nil
+				}
+				focuses = append(focuses, argValue(t, cmd.args, "FOCUS"))
+				return nil
+			}}
+			tester := NewDefaultTester()
+			tester.FocusRegex = `\[sig-node\]`
+			tester.FocusOnNewSpecsSince = baselinePath
+			tester.UpdateSpecBaseline = tc.update
+			tester.cmder = cmder
+
+			err := tester.Test()
+			if tc.expectErr {
+				if err == nil {
+					t.Error("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if argValue(t, cmder.cmds[0].args, "FOCUS") != `\[sig-node\]` {
+				t.Errorf("expected the dry run to use the original focus, but got %v", cmder.cmds[0].args)
+			}
+			if !reflect.DeepEqual(focuses, tc.expectedFocuses) {
+				t.Errorf("expected focuses %q, but got %q", tc.expectedFocuses, focuses)
+			}
+			if tc.expectedBaseline != "" {
+				data, err := os.ReadFile(baselinePath)
+				if err != nil {
+					t.Fatalf("failed to read the baseline: %v", err)
+				}
+				if string(data) != tc.expectedBaseline {
+					t.Errorf("expected baseline:\n%s\nbut got:\n%s", tc.expectedBaseline, data)
+				}
+			}
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
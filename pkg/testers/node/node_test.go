@@ -0,0 +1,710 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/testers"
+)
+
+// fakeClock starts at a fixed time and advances by step every time Now is called,
+// After fires once the clock is advanced past the deadline
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	step    time.Duration
+	waiters []fakeClockWaiter
+}
+
+type fakeClockWaiter struct {
+	deadline time.Time
+	c        chan time.Time
+}
+
+var _ clock = &fakeClock{}
+
+func newFakeClock(step time.Duration) *fakeClock {
+	return &fakeClock{now: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), step: step}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now
+	c.now = c.now.Add(c.step)
+	return now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	c.waiters = append(c.waiters, fakeClockWaiter{deadline: c.now.Add(d), c: ch})
+	return ch
+}
+
+// Advance moves the clock forward, firing any waiters whose deadline has passed
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	var pending []fakeClockWaiter
+	for _, w := range c.waiters {
+		if !c.now.Before(w.deadline) {
+			w.c <- c.now
+		} else {
+			pending = append(pending, w)
+		}
+	}
+	c.waiters = pending
+}
+
+// HasWaiters reports whether anything is waiting on the clock
+func (c *fakeClock) HasWaiters() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters) > 0
+}
+
+// fakeCmder records the commands created by the tester, running them
+// calls run (if set) instead of executing anything. Commands may be
+// created from several goroutines, e.g. when creating instances.
+type fakeCmder struct {
+	mu   sync.Mutex
+	cmds []*fakeCmd
+	run  func(cmd *fakeCmd) error
+}
+
+var _ exec.Cmder = &fakeCmder{}
+
+func (f *fakeCmder) Command(name string, args ...string) exec.Cmd {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cmd := &fakeCmd{name: name, args: args, cmder: f}
+	f.cmds = append(f.cmds, cmd)
+	return cmd
+}
+
+func (f *fakeCmder) CommandContext(ctx context.Context, name string, args ...string) exec.Cmd {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cmd := &fakeCmd{ctx: ctx, name: name, args: args, cmder: f}
+	f.cmds = append(f.cmds, cmd)
+	return cmd
+}
+
+// count returns the number of commands created so far
+func (f *fakeCmder) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.cmds)
+}
+
+// sortedCmds returns the commands created so far sorted by name and args,
+// for assertions that shouldn't depend on the goroutine scheduling
+func (f *fakeCmder) sortedCmds() []*fakeCmd {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cmds := append([]*fakeCmd(nil), f.cmds...)
+	sort.SliceStable(cmds, func(i, j int) bool {
+		a := strings.Join(append([]string{cmds[i].name}, cmds[i].args...), " ")
+		b := strings.Join(append([]string{cmds[j].name}, cmds[j].args...), " ")
+		return a < b
+	})
+	return cmds
+}
+
+type fakeCmd struct {
+	cmder  *fakeCmder
+	ctx    context.Context
+	name   string
+	args   []string
+	env    []string
+	dir    string
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+var _ exec.Cmd = &fakeCmd{}
+
+func (c *fakeCmd) Run() error {
+	if c.cmder.run == nil {
+		return nil
+	}
+	return c.cmder.run(c)
+}
+
+func (c *fakeCmd) SetEnv(env ...string) exec.Cmd {
+	c.env = env
+	return c
+}
+
+func (c *fakeCmd) SetStdin(r io.Reader) exec.Cmd {
+	c.stdin = r
+	return c
+}
+
+func (c *fakeCmd) SetStdout(w io.Writer) exec.Cmd {
+	c.stdout = w
+	return c
+}
+
+func (c *fakeCmd) SetStderr(w io.Writer) exec.Cmd {
+	c.stderr = w
+	return c
+}
+
+func (c *fakeCmd) SetDir(dir string) exec.Cmd {
+	c.dir = dir
+	return c
+}
+
+// argValue returns the value of the last KEY=value pair for key in args,
+// matching how both make and the environment resolve duplicates
+func argValue(t *testing.T, args []string, key string) string {
+	t.Helper()
+	for i := len(args) - 1; i >= 0; i-- {
+		if strings.HasPrefix(args[i], key+"=") {
+			return strings.TrimPrefix(args[i], key+"=")
+		}
+	}
+	t.Fatalf("expected %s to be set in args %v", key, args)
+	return ""
+}
+
+func TestNodeImagePullPolicy(t *testing.T) {
+	testCases := []struct {
+		name            string
+		nodeEnv         string
+		pullPolicy      string
+		expectErr       bool
+		expectedNodeEnv string
+	}{
+		{
+			name:            "unset",
+			expectedNodeEnv: " ",
+		},
+		{
+			name:            "valid policy",
+			pullPolicy:      "Never",
+			expectedNodeEnv: " IMAGE_PULL_POLICY=Never",
+		},
+		{
+			name:            "valid policy with existing node env",
+			nodeEnv:         "FOO=bar",
+			pullPolicy:      "Always",
+			expectedNodeEnv: " FOO=bar,IMAGE_PULL_POLICY=Always",
+		},
+		{
+			name:       "invalid policy",
+			pullPolicy: "Sometimes",
+			expectErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.GCPZone = "us-central1-a"
+			tester.NodeEnv = tc.nodeEnv
+			tester.NodeImagePullPolicy = tc.pullPolicy
+			err := tester.validateFlags()
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error for policy %q but got none", tc.pullPolicy)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := argValue(t, tester.constructArgs(), "NODE_ENV"); actual != tc.expectedNodeEnv {
+				t.Errorf("expected NODE_ENV=%q, but got %q", tc.expectedNodeEnv, actual)
+			}
+		})
+	}
+}
+
+func TestValidateProvider(t *testing.T) {
+	testCases := []struct {
+		name         string
+		provider     string
+		zone         string
+		instanceType string
+		userDataFile string
+		imageConfig  string
+		azure        []string
+		expectedErr  string
+	}{
+		{
+			name:     "gce",
+			provider: "gce",
+			zone:     "us-central1-a",
+		},
+		{
+			name:        "gce without a zone",
+			provider:    "gce",
+			expectedErr: "required --gcp-zone",
+		},
+		{
+			name:         "ec2 with user data",
+			provider:     "ec2",
+			instanceType: "m5.large",
+			userDataFile: "user-data.sh",
+		},
+		{
+			name:         "ec2 with an image config",
+			provider:     "ec2",
+			instanceType: "m5.large",
+			imageConfig:  "image-config.yaml",
+		},
+		{
+			name:         "ec2 without an instance type",
+			provider:     "ec2",
+			userDataFile: "user-data.sh",
+			expectedErr:  "required --instance-type",
+		},
+		{
+			name:         "ec2 without user data or image config",
+			provider:     "ec2",
+			instanceType: "m5.large",
+			expectedErr:  "--user-data-file or --image-config-file",
+		},
+		{
+			name:     "azure",
+			provider: "azure",
+			azure:    []string{"node-e2e", "eastus", "Standard_D4s_v5"},
+		},
+		{
+			name:        "azure without a vm size",
+			provider:    "azure",
+			azure:       []string{"node-e2e", "eastus", ""},
+			expectedErr: "required --azure-vm-size",
+		},
+		{
+			name:        "azure flags with gce",
+			provider:    "gce",
+			zone:        "us-central1-a",
+			azure:       []string{"node-e2e", "", ""},
+			expectedErr: "--azure-resource-group is only supported with the azure provider",
+		},
+		{
+			name:     "local",
+			provider: "local",
+		},
+		{
+			name:        "unknown provider",
+			provider:    "aws",
+			zone:        "us-central1-a",
+			expectedErr: `invalid --provider "aws", valid options are gce, ec2, azure, local`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.Provider = tc.provider
+			tester.GCPZone = tc.zone
+			tester.InstanceType = tc.instanceType
+			tester.UserDataFile = tc.userDataFile
+			tester.ImageConfigFile = tc.imageConfig
+			if tc.azure != nil {
+				tester.AzureResourceGroup, tester.AzureLocation, tester.AzureVMSize = tc.azure[0], tc.azure[1], tc.azure[2]
+			}
+			err := tester.validateFlags()
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+				t.Errorf("expected an error containing %q, but got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestCancelledRun(t *testing.T) {
+	t.Setenv("ARTIFACTS", t.TempDir())
+	ctx, cancel := context.WithCancel(context.Background())
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		cancel()
+		<-cmd.ctx.Done()
+		return cmd.ctx.Err()
+	}}
+	tester := NewDefaultTester()
+	tester.cmder = cmder
+	tester.ctx = ctx
+	tester.featureGateMatrix = []featureGateCombination{
+		{label: "first", gates: "GateA=true"},
+		{label: "second", gates: "GateA=false"},
+	}
+
+	err := tester.Test()
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a cancellation error, but got %v", err)
+	}
+	if len(cmder.cmds) != 1 {
+		t.Errorf("expected the remaining sub-runs to be skipped, but got %d runs", len(cmder.cmds))
+	}
+}
+
+func TestHardTimeout(t *testing.T) {
+	testCases := []struct {
+		name      string
+		timeout   time.Duration
+		expectErr bool
+	}{
+		{
+			name:      "hung test process is killed",
+			timeout:   time.Millisecond,
+			expectErr: true,
+		},
+		{
+			name: "no timeout",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var deadline bool
+			cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+				_, deadline = cmd.ctx.Deadline()
+				if !deadline {
+					return nil
+				}
+				<-cmd.ctx.Done()
+				return cmd.ctx.Err()
+			}}
+			tester := NewDefaultTester()
+			tester.Timeout = tc.timeout
+			tester.TimeoutGracePeriod = time.Millisecond
+			tester.cmder = cmder
+
+			err := tester.runOnce(t.TempDir())
+			if !tc.expectErr {
+				if err != nil || deadline {
+					t.Errorf("expected the test process to run unbounded, but got deadline %v and error %v", deadline, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), "timed out after 2ms") {
+				t.Errorf("expected a timeout error, but got %v", err)
+			}
+			if tester.context().Err() != nil {
+				t.Errorf("expected the timeout not to cancel the run")
+			}
+		})
+	}
+}
+
+func TestFlags(t *testing.T) {
+	fs, err := testers.ParseFlags(NewDefaultTester())
+	if err != nil {
+		t.Fatalf("unexpected error parsing the tester flags: %v", err)
+	}
+	for _, name := range []string{"repo-root", "focus-regex", "image-config-overlay", "max-boskos-hold"} {
+		if fs.Lookup(name) == nil {
+			t.Errorf("expected flag --%s to be generated", name)
+		}
+	}
+}
+
+func TestBoskosAcquireRetries(t *testing.T) {
+	tester := NewDefaultTester()
+	if tester.BoskosAcquireRetries != 3 {
+		t.Errorf("expected 3 boskos acquire retries by default, but got %d", tester.BoskosAcquireRetries)
+	}
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.GCPZone = "us-central1-a"
+	tester.BoskosAcquireRetries = -1
+	if err := tester.validateFlags(); err == nil {
+		t.Errorf("expected --boskos-acquire-retries=-1 to be rejected")
+	}
+}
+
+func TestBoskosHeartbeatOptionsRequireHeartbeat(t *testing.T) {
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.GCPZone = "us-central1-a"
+	tester.BoskosLeaseExtension = time.Hour
+	tester.BoskosHeartbeatIntervalSeconds = 0
+	if err := tester.validateFlags(); err == nil {
+		t.Errorf("expected --boskos-lease-extension to be rejected without a heartbeat")
+	}
+	tester.BoskosHeartbeatIntervalSeconds = 60
+	tester.BoskosHeartbeatRefreshOwner = true
+	if err := tester.validateFlags(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestBoskosHeader(t *testing.T) {
+	testCases := []struct {
+		name      string
+		headers   []string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "bearer token",
+			headers:  []string{"Authorization: Bearer abc"},
+			expected: "Bearer abc",
+		},
+		{
+			name:      "malformed header",
+			headers:   []string{"Authorization=Bearer abc"},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.GCPZone = "us-central1-a"
+			tester.BoskosHeader = tc.headers
+			err := tester.validateFlags()
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error for %q but got none", tc.headers)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := tester.boskosHeaders.Get("Authorization"); actual != tc.expected {
+				t.Errorf("expected Authorization header %q, but got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestProcsPerNode(t *testing.T) {
+	testCases := []struct {
+		name             string
+		procsPerNode     int
+		testArgs         string
+		expectedTestArgs string
+		expectErr        bool
+	}{
+		{
+			name: "unset",
+		},
+		{
+			name:             "fewer procs per node",
+			procsPerNode:     2,
+			expectedTestArgs: "--procs=2",
+		},
+		{
+			name:             "already in the test args",
+			procsPerNode:     2,
+			testArgs:         "--procs=4",
+			expectedTestArgs: "--procs=4",
+		},
+		{
+			name:         "negative",
+			procsPerNode: -1,
+			expectErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.GCPZone = "us-central1-a"
+			tester.ProcsPerNode = tc.procsPerNode
+			tester.TestArgs = tc.testArgs
+			err := tester.validateFlags()
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error for --procs-per-node=%d but got none", tc.procsPerNode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			args := tester.constructArgs()
+			if actual := argValue(t, args, "PARALLELISM"); actual != "8" {
+				t.Errorf("expected PARALLELISM=8 to be unchanged, but got %s", actual)
+			}
+			if actual := argValue(t, args, "TEST_ARGS"); actual != tc.expectedTestArgs {
+				t.Errorf("expected TEST_ARGS=%q, but got %q", tc.expectedTestArgs, actual)
+			}
+		})
+	}
+}
+
+func TestGinkgoParallelism(t *testing.T) {
+	testCases := []struct {
+		name              string
+		parallelism       int
+		ginkgoParallelism int
+		procsPerNode      int
+		testArgs          string
+		expectedTestArgs  string
+		expectErr         bool
+	}{
+		{
+			name:        "unset",
+			parallelism: 8,
+		},
+		{
+			name:              "ginkgo nodes",
+			parallelism:       8,
+			ginkgoParallelism: 4,
+			expectedTestArgs:  "--nodes=4",
+		},
+		{
+			name:              "already in the test args",
+			parallelism:       8,
+			ginkgoParallelism: 4,
+			testArgs:          "--nodes=2",
+			expectedTestArgs:  "--nodes=2",
+		},
+		{
+			name:              "negative ginkgo parallelism",
+			parallelism:       8,
+			ginkgoParallelism: -1,
+			expectErr:         true,
+		},
+		{
+			name:              "with procs per node",
+			parallelism:       8,
+			ginkgoParallelism: 4,
+			procsPerNode:      2,
+			expectErr:         true,
+		},
+		{
+			name:        "zero parallelism",
+			parallelism: 0,
+			expectErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.GCPZone = "us-central1-a"
+			tester.Parallelism = tc.parallelism
+			tester.GinkgoParallelism = tc.ginkgoParallelism
+			tester.ProcsPerNode = tc.procsPerNode
+			tester.TestArgs = tc.testArgs
+			err := tester.validateFlags()
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			args := tester.constructArgs()
+			if actual, expected := argValue(t, args, "PARALLELISM"), strconv.Itoa(tc.parallelism); actual != expected {
+				t.Errorf("expected PARALLELISM=%s to be unchanged, but got %s", expected, actual)
+			}
+			if actual := argValue(t, args, "TEST_ARGS"); actual != tc.expectedTestArgs {
+				t.Errorf("expected TEST_ARGS=%q, but got %q", tc.expectedTestArgs, actual)
+			}
+		})
+	}
+}
+
+func TestFlakeAttempts(t *testing.T) {
+	testCases := []struct {
+		name             string
+		flakeAttempts    int
+		testArgs         string
+		expectedTestArgs string
+		expectErr        bool
+	}{
+		{
+			name:          "default",
+			flakeAttempts: 1,
+		},
+		{
+			name:             "retried",
+			flakeAttempts:    3,
+			expectedTestArgs: "--ginkgo.flake-attempts=3",
+		},
+		{
+			name:             "already in the test args",
+			flakeAttempts:    3,
+			testArgs:         "--ginkgo.flake-attempts=2",
+			expectedTestArgs: "--ginkgo.flake-attempts=2",
+		},
+		{
+			name:          "zero",
+			flakeAttempts: 0,
+			expectErr:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.GCPZone = "us-central1-a"
+			tester.FlakeAttempts = tc.flakeAttempts
+			tester.TestArgs = tc.testArgs
+			err := tester.validateFlags()
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error for --flake-attempts=%d but got none", tc.flakeAttempts)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			args := tester.constructArgs()
+			if actual, expected := argValue(t, args, "FLAKE_ATTEMPTS"), strconv.Itoa(tc.flakeAttempts); actual != expected {
+				t.Errorf("expected FLAKE_ATTEMPTS=%s, but got %s", expected, actual)
+			}
+			if actual := argValue(t, args, "TEST_ARGS"); actual != tc.expectedTestArgs {
+				t.Errorf("expected TEST_ARGS=%q, but got %q", tc.expectedTestArgs, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultNodeCount is the number of nodes created for an image not in --node-count-per-image
+const defaultNodeCount = 1
+
+// parseNodeCountPerImage parses a comma-separated list of image=count entries,
+// every image must be one of images and every count must be positive
+func parseNodeCountPerImage(value string, images []string) (map[string]int, error) {
+	known := map[string]bool{}
+	for _, image := range images {
+		known[strings.TrimSpace(image)] = true
+	}
+	counts := map[string]int{}
+	for _, entry := range strings.Split(value, ",") {
+		image, countValue, ok := strings.Cut(entry, "=")
+		image = strings.TrimSpace(image)
+		if !ok || image == "" {
+			return nil, fmt.Errorf("%q is not in the image=count format", entry)
+		}
+		if !known[image] {
+			return nil, fmt.Errorf("image %q is not one of --images", image)
+		}
+		if _, ok := counts[image]; ok {
+			return nil, fmt.Errorf("image %q is set more than once", image)
+		}
+		count, err := strconv.Atoi(strings.TrimSpace(countValue))
+		if err != nil || count < 1 {
+			return nil, fmt.Errorf("count %q of image %q must be a positive integer", countValue, image)
+		}
+		counts[image] = count
+	}
+	return counts, nil
+}
+
+// resolveNodeCountPerImage parses NodeCountPerImage against the images of the
+// run, after the default images are resolved. A dry run does not look up the
+// default images, so there is nothing to resolve the counts against.
+func (t *Tester) resolveNodeCountPerImage() error {
+	if t.NodeCountPerImage == "" || t.Images == "" {
+		return nil
+	}
+	counts, err := parseNodeCountPerImage(t.NodeCountPerImage, strings.Split(t.Images, ","))
+	if err != nil {
+		return fmt.Errorf("invalid --node-count-per-image: %v", err)
+	}
+	t.nodeCountPerImage = counts
+	return nil
+}
+
+// nodeCount returns the number of nodes to create for image
+func (t *Tester) nodeCount(image string) int {
+	if count, ok := t.nodeCountPerImage[strings.TrimSpace(image)]; ok {
+		return count
+	}
+	return defaultNodeCount
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestParseNodeCountPerImage(t *testing.T) {
+	images := []string{"cos-stable", " ubuntu-2204"}
+	testCases := []struct {
+		value     string
+		expected  map[string]int
+		expectErr bool
+	}{
+		{value: "cos-stable=3", expected: map[string]int{"cos-stable": 3}},
+		{value: "cos-stable=3, ubuntu-2204=2", expected: map[string]int{"cos-stable": 3, "ubuntu-2204": 2}},
+		{value: "cos-stable=0", expectErr: true},
+		{value: "cos-stable=-1", expectErr: true},
+		{value: "cos-stable=two", expectErr: true},
+		{value: "cos-stable", expectErr: true},
+		{value: "=2", expectErr: true},
+		{value: "fedora-coreos=2", expectErr: true},
+		{value: "cos-stable=2,cos-stable=3", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		actual, err := parseNodeCountPerImage(tc.value, images)
+		if tc.expectErr {
+			if err == nil {
+				t.Errorf("expected an error for %q but got none", tc.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", tc.value, err)
+		}
+		if !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("expected %q to be parsed as %v, but got %v", tc.value, tc.expected, actual)
+		}
+	}
+}
+
+func TestNodeCountPerImage(t *testing.T) {
+	dir := t.TempDir()
+	var hosts []string
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		if cmd.name == "gcloud" && cmd.args[2] == "create" {
+			_, _ = fmt.Fprintf(cmd.stderr, "Created [https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/%s].\n", cmd.args[3])
+		}
+		return nil
+	}}
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.GCPProject = "p"
+	tester.GCPZone = "us-central1-a"
+	tester.Images = "cos-stable, ubuntu-2204,fedora-coreos"
+	tester.NodeCountPerImage = "cos-stable=3,ubuntu-2204=1"
+	tester.runID = "8f14e45f-ceea-467f-a8b0-12c0a1b2c3d4"
+	tester.cmder = cmder
+	if err := tester.validateFlags(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := tester.resolveNodeCountPerImage(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for image, expected := range map[string]int{"cos-stable": 3, "ubuntu-2204": 1, "fedora-coreos": defaultNodeCount} {
+		if actual := tester.nodeCount(image); actual != expected {
+			t.Errorf("expected %d nodes for %s, but got %d", expected, image, actual)
+		}
+	}
+	if actual := tester.instanceCount(); actual != 5 {
+		t.Errorf("expected 5 instances in total, but got %d", actual)
+	}
+
+	if err := tester.runOnce(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, cmd := range cmder.sortedCmds() {
+		if cmd.name == "gcloud" && cmd.args[2] == "create" {
+			hosts = append(hosts, cmd.args[3])
+		}
+	}
+	sort.Strings(hosts)
+	expected := []string{
+		"tmp-node-e2e-8f14e45f-cos-stable-1",
+		"tmp-node-e2e-8f14e45f-cos-stable-2",
+		"tmp-node-e2e-8f14e45f-cos-stable-3",
+		"tmp-node-e2e-8f14e45f-fedora-coreos",
+		"tmp-node-e2e-8f14e45f-ubuntu-2204",
+	}
+	if !reflect.DeepEqual(hosts, expected) {
+		t.Errorf("expected instances %v to be created, but got %v", expected, hosts)
+	}
+}
+
+func TestNodeCountPerDefaultImage(t *testing.T) {
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		_, _ = fmt.Fprintln(cmd.stdout, "cos-121-18867-90-97")
+		return nil
+	}}
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.GCPProject = "p"
+	tester.GCPZone = "us-central1-a"
+	tester.NodeCountPerImage = "cos-121-18867-90-97=2"
+	tester.cmder = cmder
+	if err := tester.validateFlags(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := tester.defaultImages(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := tester.resolveNodeCountPerImage(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual := tester.nodeCount("cos-121-18867-90-97"); actual != 2 {
+		t.Errorf("expected 2 nodes for the default image, but got %d", actual)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"time"
+)
+
+// lineWatcher is an io.Writer that forwards everything to out and calls
+// onLine with each complete line written to it
+type lineWatcher struct {
+	mu      sync.Mutex
+	out     io.Writer
+	partial []byte
+	onLine  func(line string)
+}
+
+func (w *lineWatcher) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		w.onLine(string(bytes.TrimSuffix(w.partial[:i], []byte("\r"))))
+		w.partial = w.partial[i+1:]
+	}
+	return w.out.Write(p)
+}
+
+// Flush passes any trailing output not terminated by a newline to onLine
+func (w *lineWatcher) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.partial) > 0 {
+		w.onLine(string(w.partial))
+		w.partial = nil
+	}
+}
+
+// runOutput records what was observed in the output of a node e2e run
+type runOutput struct {
+	mu       sync.Mutex
+	watchers []*lineWatcher
+	now      func() time.Time
+	// log, if set, also receives everything written to the watchers
+	log io.Writer
+
+	hostKeyVerificationFailed bool
+	staleHostKeys             []staleHostKey
+	lifecycle                 []lifecycleEvent
+	startupScriptFailed       bool
+	// buildFinished is set once the output moves from the build to the tests
+	buildFinished bool
+	buildWarnings []string
+}
+
+// watch returns a writer forwarding to out that records observations
+// from the output written to it
+func (o *runOutput) watch(out io.Writer) io.Writer {
+	if o.log != nil {
+		out = io.MultiWriter(out, o.log)
+	}
+	w := &lineWatcher{out: out, onLine: o.observe}
+	o.watchers = append(o.watchers, w)
+	return w
+}
+
+// flush observes any trailing partial lines, it should be called
+// once the command has exited
+func (o *runOutput) flush() {
+	for _, w := range o.watchers {
+		w.Flush()
+	}
+}
+
+func (o *runOutput) observe(line string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if hostKeyVerificationFailedRegex.MatchString(line) {
+		o.hostKeyVerificationFailed = true
+	}
+	if key, ok := parseStaleHostKey(line); ok {
+		o.staleHostKeys = appendStaleHostKey(o.staleHostKeys, key)
+	}
+	if !o.buildFinished {
+		if buildFinishedRegex.MatchString(line) {
+			o.buildFinished = true
+		} else if buildWarningRegex.MatchString(line) {
+			o.buildWarnings = append(o.buildWarnings, line)
+		}
+	}
+	if startupScriptFailedRegex.MatchString(line) {
+		o.startupScriptFailed = true
+	}
+	if event, ok := parseLifecycleEvent(line); ok {
+		event.Timestamp = o.now()
+		o.lifecycle = append(o.lifecycle, event)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"k8s.io/klog/v2"
+)
+
+// validateMinPassRate checks that --min-pass-rate is a percentage
+func (t *Tester) validateMinPassRate() error {
+	if t.MinPassRate < 0 || t.MinPassRate > 100 {
+		return fmt.Errorf("--min-pass-rate must be between 0 and 100, got %g", t.MinPassRate)
+	}
+	if t.MinPassRate > 0 && t.Gating {
+		return fmt.Errorf("--min-pass-rate cannot be combined with --gating, which fails the run on any failed spec")
+	}
+	return nil
+}
+
+// applyMinPassRate decides the outcome of a run from the pass rate of the
+// specs that ran, skipped specs excluded: the run fails below --min-pass-rate
+// and tolerates its failed specs otherwise. A run that failed without any
+// failed spec, or that was aborted, keeps its error. runErr is the outcome of
+// the run so far.
+func (t *Tester) applyMinPassRate(artifactsDir string, runErr error) error {
+	results, err := t.results(artifactsDir)
+	if err != nil {
+		klog.Warningf("failed to parse test results, not applying --min-pass-rate: %v", err)
+		return runErr
+	}
+	ran := results.Passed + results.Failed
+	if ran == 0 {
+		return runErr
+	}
+	rate := 100 * float64(results.Passed) / float64(ran)
+	klog.V(0).Infof("pass rate of the run is %.2f%%, %d of %d specs passed, --min-pass-rate is %g%%", rate, results.Passed, ran, t.MinPassRate)
+	if rate < t.MinPassRate {
+		if runErr != nil {
+			return fmt.Errorf("pass rate %.2f%% is below --min-pass-rate %g%%: %w", rate, t.MinPassRate, runErr)
+		}
+		return fmt.Errorf("pass rate %.2f%% is below --min-pass-rate %g%%", rate, t.MinPassRate)
+	}
+	if runErr != nil && t.aborted(runErr) {
+		klog.Errorf("not applying --min-pass-rate to an aborted run")
+		return runErr
+	}
+	if runErr != nil && results.Failed > 0 {
+		klog.V(0).Infof("ignoring run failure, the pass rate is at least --min-pass-rate")
+		return nil
+	}
+	return runErr
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// passRateJUnit returns a junit file with passed passing and failed failing specs
+func passRateJUnit(passed, failed int) string {
+	var b strings.Builder
+	b.WriteString(`<testsuite name="E2eNode Suite">`)
+	for i := 0; i < passed; i++ {
+		fmt.Fprintf(&b, `<testcase name="[It] passed %d" time="1"></testcase>`, i)
+	}
+	for i := 0; i < failed; i++ {
+		fmt.Fprintf(&b, `<testcase name="[It] failed %d" time="1"><failure message="boom"></failure></testcase>`, i)
+	}
+	b.WriteString(`<testcase name="[It] skipped" time="0"><skipped></skipped></testcase></testsuite>`)
+	return b.String()
+}
+
+func TestMinPassRate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		passed      int
+		failed      int
+		noResults   bool
+		cancelled   bool
+		timedOut    bool
+		minPassRate float64
+		expectErr   string
+	}{
+		{
+			name:        "above the threshold",
+			passed:      9,
+			failed:      1,
+			minPassRate: 80,
+		},
+		{
+			name:        "at the threshold",
+			passed:      8,
+			failed:      2,
+			minPassRate: 80,
+		},
+		{
+			name:        "below the threshold",
+			passed:      7,
+			failed:      3,
+			minPassRate: 80,
+			expectErr:   "pass rate 70.00% is below --min-pass-rate 80%",
+		},
+		{
+			name:        "all passed",
+			passed:      10,
+			minPassRate: 100,
+		},
+		{
+			name:        "cancelled above the threshold",
+			passed:      9,
+			failed:      1,
+			cancelled:   true,
+			minPassRate: 80,
+			expectErr:   "cancelled",
+		},
+		{
+			name:        "timed out above the threshold",
+			passed:      9,
+			failed:      1,
+			timedOut:    true,
+			minPassRate: 80,
+			expectErr:   "timed out",
+		},
+		{
+			name:        "failed without results",
+			noResults:   true,
+			minPassRate: 80,
+			expectErr:   "failed to create instances",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("ARTIFACTS", t.TempDir())
+			ctx, cancel := context.WithCancelCause(context.Background())
+			defer cancel(nil)
+			cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+				if tc.noResults {
+					return errors.New("failed to create instances")
+				}
+				writeArtifact(t, argValue(t, cmd.env, "ARTIFACTS"), "junit_01.xml", passRateJUnit(tc.passed, tc.failed))
+				if tc.cancelled {
+					cancel(errors.New("received SIGTERM"))
+				}
+				if tc.cancelled || tc.timedOut {
+					<-cmd.ctx.Done()
+					return cmd.ctx.Err()
+				}
+				if tc.failed > 0 {
+					return errors.New("specs failed")
+				}
+				return nil
+			}}
+			tester := NewDefaultTester()
+			tester.MinPassRate = tc.minPassRate
+			tester.ctx = ctx
+			tester.cmder = cmder
+			if tc.timedOut {
+				tester.Timeout = time.Millisecond
+				tester.TimeoutGracePeriod = time.Millisecond
+			}
+
+			err := tester.Test()
+			if tc.expectErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectErr) {
+				t.Errorf("expected an error containing %q, but got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateMinPassRate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		minPassRate float64
+		gating      bool
+		expectErr   bool
+	}{
+		{name: "unset"},
+		{name: "percentage", minPassRate: 95.5},
+		{name: "negative", minPassRate: -1, expectErr: true},
+		{name: "above 100", minPassRate: 101, expectErr: true},
+		{name: "gating", minPassRate: 90, gating: true, expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		tester := NewDefaultTester()
+		tester.RepoRoot = fakeRepoRoot(t)
+		tester.GCPZone = "us-central1-a"
+		tester.MinPassRate = tc.minPassRate
+		tester.Gating = tc.gating
+		err := tester.validateFlags()
+		if tc.expectErr && err == nil {
+			t.Errorf("%s: expected an error but got none", tc.name)
+		}
+		if !tc.expectErr && err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/klog/v2"
+)
+
+// stdinIsTerminal reports whether the standard input of the tester is a terminal
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// pausesBeforeTeardown reports whether the run waits for the operator before tearing down
+func (t *Tester) pausesBeforeTeardown() bool {
+	return t.PauseBeforeTeardown && t.interactive()
+}
+
+// pauseBeforeTeardown waits for a line of input once the tests complete, so
+// the instances and the boskos resource can be inspected before they are
+// cleaned up. It does not wait when not running interactively, or once the
+// run is cancelled.
+func (t *Tester) pauseBeforeTeardown(w io.Writer) {
+	if !t.pausesBeforeTeardown() {
+		return
+	}
+	ctx := t.context()
+	if ctx.Err() != nil {
+		return
+	}
+	fmt.Fprintln(w, "the tests completed, press enter to delete the instances and release the boskos resource")
+	read := make(chan struct{})
+	go func() {
+		defer close(read)
+		_, _ = bufio.NewReader(t.stdin).ReadString('\n')
+	}()
+	select {
+	case <-read:
+	case <-ctx.Done():
+		klog.V(0).Infof("run cancelled, no longer pausing before teardown")
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeTerminal is the operator input, it records when the tester starts reading from it
+type fakeTerminal struct {
+	io.Reader
+	mu      sync.Mutex
+	reading bool
+}
+
+func (f *fakeTerminal) Read(p []byte) (int, error) {
+	f.mu.Lock()
+	f.reading = true
+	f.mu.Unlock()
+	return f.Reader.Read(p)
+}
+
+func (f *fakeTerminal) Reading() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.reading
+}
+
+func TestPauseBeforeTeardown(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		if cmd.name == "gcloud" {
+			if cmd.args[2] == "delete" {
+				mu.Lock()
+				deleted = append(deleted, cmd.args[6:]...)
+				mu.Unlock()
+			}
+			return nil
+		}
+		_, _ = io.WriteString(cmd.stderr, lifecycleOutput)
+		return nil
+	}}
+	input, operator := io.Pipe()
+	terminal := &fakeTerminal{Reader: input}
+	tester := NewDefaultTester()
+	tester.GCPProject = "p"
+	tester.GCPZone = "us-central1-a"
+	tester.PauseBeforeTeardown = true
+	tester.clock = newFakeClock(time.Minute)
+	tester.cmder = cmder
+	tester.stdin = terminal
+	tester.interactive = func() bool { return true }
+
+	done := make(chan error)
+	go func() {
+		done <- tester.runOnce(t.TempDir())
+	}()
+	waitFor(t, terminal.Reading)
+	mu.Lock()
+	if len(deleted) != 0 {
+		t.Errorf("expected no instances to be deleted before the operator pressed enter, but got %v", deleted)
+	}
+	mu.Unlock()
+	if _, err := io.WriteString(operator, "\n"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if actual := argValue(t, cmder.cmds[0].args, "DELETE_INSTANCES"); actual != "false" {
+		t.Errorf("expected the test process not to delete the instances, but got DELETE_INSTANCES=%s", actual)
+	}
+	expected := []string{"tmp-node-e2e-ubuntu-1234"}
+	if !reflect.DeepEqual(deleted, expected) {
+		t.Errorf("expected deleted instances %v, but got %v", expected, deleted)
+	}
+}
+
+func TestPauseBeforeTeardownNotInteractive(t *testing.T) {
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		_, _ = io.WriteString(cmd.stderr, lifecycleOutput)
+		return nil
+	}}
+	input, _ := io.Pipe()
+	terminal := &fakeTerminal{Reader: input}
+	tester := NewDefaultTester()
+	tester.GCPProject = "p"
+	tester.GCPZone = "us-central1-a"
+	tester.PauseBeforeTeardown = true
+	tester.clock = newFakeClock(time.Minute)
+	tester.cmder = cmder
+	tester.stdin = terminal
+	tester.interactive = func() bool { return false }
+
+	if err := tester.runOnce(t.TempDir()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if terminal.Reading() {
+		t.Errorf("expected no input to be read when not running interactively")
+	}
+	if actual := argValue(t, cmder.cmds[0].args, "DELETE_INSTANCES"); actual != "true" {
+		t.Errorf("expected the test process to delete the instances, but got DELETE_INSTANCES=%s", actual)
+	}
+}
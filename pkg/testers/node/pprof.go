@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	pprofDirName = "pprof"
+
+	// kubeletPprofURL is the pprof endpoint of the kubelet on the node, the
+	// node e2e kubelet serves its debugging handlers on its secure port
+	kubeletPprofURL = "https://localhost:10250/debug/pprof/"
+)
+
+// kubeletProfile is a profile collected from the kubelet, path is relative to kubeletPprofURL
+type kubeletProfile struct {
+	name string
+	path string
+}
+
+// kubeletProfiles are the profiles collected from each instance with --collect-kubelet-pprof
+var kubeletProfiles = []kubeletProfile{
+	{name: "cpu", path: "profile?seconds=30"},
+	{name: "heap", path: "heap"},
+}
+
+// pprofCollectionTimeout bounds the collection of the profiles of all the
+// instances, the run may already have been cancelled
+var pprofCollectionTimeout = 5 * time.Minute
+
+// pprofSource writes a profile of the kubelet on an instance to w
+type pprofSource interface {
+	Profile(ctx context.Context, instance string, profile kubeletProfile, w io.Writer) error
+}
+
+// sshPprofSource fetches the profiles from the kubelet debug endpoint over SSH
+type sshPprofSource struct {
+	transport SSHTransport
+}
+
+var _ pprofSource = &sshPprofSource{}
+
+func (s *sshPprofSource) Profile(ctx context.Context, instance string, profile kubeletProfile, w io.Writer) error {
+	var stderr bytes.Buffer
+	command := fmt.Sprintf("sudo curl -sSfk --max-time 60 %s", shellQuote(kubeletPprofURL+profile.path))
+	if err := s.transport.Exec(ctx, instance, command, w, &stderr); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// collectKubeletPprof writes the kubelet profiles of each instance to
+// pprof/<instance>/<profile>.pprof in artifactsDir. It is best effort,
+// failures are only logged.
+func collectKubeletPprof(artifactsDir string, source pprofSource, instances []string) {
+	if len(instances) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), pprofCollectionTimeout)
+	defer cancel()
+	for _, instance := range instances {
+		dir := filepath.Join(artifactsDir, pprofDirName, instance)
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			klog.Warningf("failed to create the pprof directory of instance %s: %v", instance, err)
+			continue
+		}
+		for _, profile := range kubeletProfiles {
+			var data bytes.Buffer
+			if err := source.Profile(ctx, instance, profile, &data); err != nil {
+				klog.Warningf("failed to collect the kubelet %s profile of instance %s: %v", profile.name, instance, err)
+				continue
+			}
+			path := filepath.Join(dir, profile.name+".pprof")
+			if err := os.WriteFile(path, data.Bytes(), 0644); err != nil {
+				klog.Warningf("failed to write the kubelet %s profile of instance %s: %v", profile.name, instance, err)
+				continue
+			}
+			klog.V(0).Infof("collected the kubelet %s profile of instance %s to %s", profile.name, instance, path)
+		}
+	}
+}
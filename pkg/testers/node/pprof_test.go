@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakePprofSource returns the profiles of each instance, or fails for
+// instances without profiles
+type fakePprofSource struct {
+	profiles map[string]map[string]string
+}
+
+func (f *fakePprofSource) Profile(ctx context.Context, instance string, profile kubeletProfile, w io.Writer) error {
+	data, ok := f.profiles[instance][profile.name]
+	if !ok {
+		return errors.New("connection refused")
+	}
+	_, err := io.WriteString(w, data)
+	return err
+}
+
+func TestCollectKubeletPprof(t *testing.T) {
+	dir := t.TempDir()
+	source := &fakePprofSource{profiles: map[string]map[string]string{
+		"tmp-node-e2e-cos-1234":    {"cpu": "cpu profile", "heap": "heap profile"},
+		"tmp-node-e2e-ubuntu-1234": {"heap": "ubuntu heap profile"},
+	}}
+	collectKubeletPprof(dir, source, []string{"tmp-node-e2e-cos-1234", "tmp-node-e2e-ubuntu-1234", "tmp-node-e2e-fedora-1234"})
+
+	expected := map[string]string{
+		"tmp-node-e2e-cos-1234/cpu.pprof":     "cpu profile",
+		"tmp-node-e2e-cos-1234/heap.pprof":    "heap profile",
+		"tmp-node-e2e-ubuntu-1234/heap.pprof": "ubuntu heap profile",
+	}
+	actual := map[string]string{}
+	pprofDir := filepath.Join(dir, pprofDirName)
+	err := filepath.Walk(pprofDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(pprofDir, path)
+		actual[filepath.ToSlash(rel)] = string(data)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to read the profiles: %v", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected profiles %v, but got %v", expected, actual)
+	}
+}
+
+func TestCollectKubeletPprofOnFailure(t *testing.T) {
+	testCases := []struct {
+		name             string
+		runErr           error
+		expectedProfiles []string
+	}{
+		{
+			name:             "failing run",
+			runErr:           errors.New("specs failed"),
+			expectedProfiles: []string{"cpu.pprof", "heap.pprof"},
+		},
+		{
+			name: "passing run",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+				switch {
+				case cmd.name == "make":
+					_, _ = io.WriteString(cmd.stderr, lifecycleOutput)
+					return tc.runErr
+				case cmd.args[1] == "ssh":
+					if !strings.HasSuffix(cmd.args[4], "@tmp-node-e2e-ubuntu-1234") || !strings.Contains(cmd.args[5], kubeletPprofURL) {
+						return errors.New("unexpected ssh")
+					}
+					_, _ = io.WriteString(cmd.stdout, "profile")
+				}
+				return nil
+			}}
+			tester := NewDefaultTester()
+			tester.GCPProject = "p"
+			tester.GCPZone = "us-central1-a"
+			tester.CollectKubeletPprof = true
+			tester.clock = newFakeClock(time.Minute)
+			tester.cmder = cmder
+
+			if err := tester.runOnce(dir); !errors.Is(err, tc.runErr) {
+				t.Fatalf("expected error %v, but got %v", tc.runErr, err)
+			}
+			if actual := argValue(t, cmder.cmds[0].args, "DELETE_INSTANCES"); actual != "false" {
+				t.Errorf("expected the instances to be kept for the profiles, but got DELETE_INSTANCES=%s", actual)
+			}
+			var collected []string
+			entries, _ := os.ReadDir(filepath.Join(dir, pprofDirName, "tmp-node-e2e-ubuntu-1234"))
+			for _, entry := range entries {
+				collected = append(collected, entry.Name())
+			}
+			if !reflect.DeepEqual(collected, tc.expectedProfiles) {
+				t.Errorf("expected the profiles %v, but got %v", tc.expectedProfiles, collected)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"k8s.io/klog/v2"
+)
+
+// builtinProfiles are named sets of flag values for common node e2e jobs,
+// keyed by profile name and then by flag name
+var builtinProfiles = map[string]map[string]string{
+	"conformance": {
+		"focus-regex": `\[NodeConformance\]`,
+		"skip-regex":  `\[Flaky\]|\[Slow\]|\[Serial\]`,
+	},
+	"serial": {
+		"focus-regex": `\[Serial\]`,
+		"skip-regex":  `\[Flaky\]|\[Benchmark\]|\[NodeSpecialFeature:.+\]|\[NodeSpecialFeature\]|\[NodeAlphaFeature:.+\]|\[NodeAlphaFeature\]|\[NodeFeature:Eviction\]`,
+		"parallelism": "1",
+	},
+	"flaky": {
+		"focus-regex": `\[Flaky\]`,
+		"skip-regex":  "",
+	},
+}
+
+func profileNames() []string {
+	names := make([]string, 0, len(builtinProfiles))
+	for name := range builtinProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// applyProfile sets the flags of the named profile, flags that were
+// explicitly set on the command line take precedence over the profile
+func applyProfile(fs *pflag.FlagSet, name string) error {
+	profile, ok := builtinProfiles[name]
+	if !ok {
+		return fmt.Errorf("unknown profile %q, valid profiles are %s", name, strings.Join(profileNames(), ", "))
+	}
+	for _, flagName := range sortedKeys(profile) {
+		if presetOverridden(fs, flagName) {
+			klog.V(1).Infof("--%s was set explicitly, ignoring the value from profile %s", flagName, name)
+			continue
+		}
+		if err := fs.Set(flagName, profile[flagName]); err != nil {
+			return fmt.Errorf("failed to set --%s from profile %s: %v", flagName, name, err)
+		}
+	}
+	return nil
+}
+
+// listProfiles writes every built-in profile with the flag values it sets
+func listProfiles(w io.Writer) error {
+	for _, name := range profileNames() {
+		if _, err := fmt.Fprintf(w, "%s:\n", name); err != nil {
+			return err
+		}
+		profile := builtinProfiles[name]
+		for _, flagName := range sortedKeys(profile) {
+			if _, err := fmt.Fprintf(w, "  --%s=%s\n", flagName, profile[flagName]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
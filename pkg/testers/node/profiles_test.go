@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/octago/sflags/gen/gpflag"
+)
+
+func TestListProfiles(t *testing.T) {
+	var out bytes.Buffer
+	if err := listProfiles(&out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `conformance:
+  --focus-regex=\[NodeConformance\]
+  --skip-regex=\[Flaky\]|\[Slow\]|\[Serial\]
+flaky:
+  --focus-regex=\[Flaky\]
+  --skip-regex=
+serial:
+  --focus-regex=\[Serial\]
+  --parallelism=1
+  --skip-regex=\[Flaky\]|\[Benchmark\]|\[NodeSpecialFeature:.+\]|\[NodeSpecialFeature\]|\[NodeAlphaFeature:.+\]|\[NodeAlphaFeature\]|\[NodeFeature:Eviction\]
+`
+	if out.String() != expected {
+		t.Errorf("expected profiles:\n%s\nbut got:\n%s", expected, out.String())
+	}
+}
+
+func TestApplyProfile(t *testing.T) {
+	testCases := []struct {
+		name                string
+		args                []string
+		profile             string
+		expectErr           bool
+		expectedFocus       string
+		expectedParallelism int
+	}{
+		{
+			name:                "profile values",
+			profile:             "serial",
+			expectedFocus:       `\[Serial\]`,
+			expectedParallelism: 1,
+		},
+		{
+			name:                "explicit flags win",
+			args:                []string{"--parallelism=4"},
+			profile:             "serial",
+			expectedFocus:       `\[Serial\]`,
+			expectedParallelism: 4,
+		},
+		{
+			name:      "unknown profile",
+			profile:   "nightly",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tester := NewDefaultTester()
+			fs, err := gpflag.Parse(tester)
+			if err != nil {
+				t.Fatalf("failed to parse tester flags: %v", err)
+			}
+			if err := fs.Parse(tc.args); err != nil {
+				t.Fatalf("failed to parse args: %v", err)
+			}
+			err = applyProfile(fs, tc.profile)
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error for profile %q but got none", tc.profile)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tester.FocusRegex != tc.expectedFocus {
+				t.Errorf("expected focus %q, but got %q", tc.expectedFocus, tester.FocusRegex)
+			}
+			if tester.Parallelism != tc.expectedParallelism {
+				t.Errorf("expected parallelism %d, but got %d", tc.expectedParallelism, tester.Parallelism)
+			}
+		})
+	}
+}
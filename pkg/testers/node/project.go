@@ -0,0 +1,38 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"os"
+
+	"k8s.io/klog/v2"
+)
+
+// writeProjectOutputFile writes the GCP project of the run to
+// --project-output-file, whether it was acquired from boskos or given, so
+// that later CI steps can target it
+func (t *Tester) writeProjectOutputFile() error {
+	if t.ProjectOutputFile == "" || t.DryRun {
+		return nil
+	}
+	if err := os.WriteFile(t.ProjectOutputFile, []byte(t.GCPProject+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write the project to %s: %w", t.ProjectOutputFile, err)
+	}
+	klog.V(1).Infof("wrote project %s to %s", t.GCPProject, t.ProjectOutputFile)
+	return nil
+}
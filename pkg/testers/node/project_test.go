@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteProjectOutputFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "project")
+	tester := NewDefaultTester()
+	tester.GCPProject = "k8s-jkns-e2e-node-1234"
+	tester.ProjectOutputFile = path
+	if err := tester.writeProjectOutputFile(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the project output file: %v", err)
+	}
+	if expected := "k8s-jkns-e2e-node-1234\n"; string(contents) != expected {
+		t.Errorf("expected the project output file to contain %q, but got %q", expected, contents)
+	}
+
+	tester.ProjectOutputFile = filepath.Join(t.TempDir(), "missing", "project")
+	if err := tester.writeProjectOutputFile(); err == nil {
+		t.Errorf("expected an error writing to a missing directory")
+	}
+}
+
+func TestProjectOutputFileRequiresGCE(t *testing.T) {
+	for _, provider := range []string{"ec2", localProvider} {
+		tester := NewDefaultTester()
+		tester.RepoRoot = fakeRepoRoot(t)
+		tester.Provider = provider
+		tester.InstanceType = "m5.large"
+		tester.UserDataFile = "user-data.sh"
+		tester.ProjectOutputFile = "project"
+		if err := tester.validateFlags(); err == nil {
+			t.Errorf("expected --project-output-file to be rejected with the %s provider", provider)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/testers"
+)
+
+const (
+	quotaUsageFileName        = "quota-usage.json"
+	peakQuotaUsageMetadataKey = "peak-quota-usage"
+)
+
+// quotaSampleInterval is how often the project quota usage is sampled with --report-quota-usage
+var quotaSampleInterval = time.Minute
+
+// trackedQuotas are the quota metrics a node e2e run consumes
+var trackedQuotas = map[string]bool{
+	"CPUS":             true,
+	"DISKS_TOTAL_GB":   true,
+	"IN_USE_ADDRESSES": true,
+	"INSTANCES":        true,
+	"SSD_TOTAL_GB":     true,
+}
+
+// quotaUsage is the usage of a quota metric and its limit
+type quotaUsage struct {
+	Usage float64 `json:"usage"`
+	Limit float64 `json:"limit"`
+}
+
+// quotaSampler samples the current quota usage of the project the run creates instances in
+type quotaSampler interface {
+	Sample() (map[string]quotaUsage, error)
+}
+
+// gceQuotaSampler samples the regional quotas of a GCP project with gcloud
+type gceQuotaSampler struct {
+	cmder   exec.Cmder
+	project string
+	region  string
+}
+
+var _ quotaSampler = &gceQuotaSampler{}
+
+func (s *gceQuotaSampler) Sample() (map[string]quotaUsage, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := s.cmder.Command("gcloud", "compute", "regions", "describe", s.region,
+		"--project="+s.project, "--format=json(quotas)")
+	cmd.SetStdout(&stdout)
+	cmd.SetStderr(&stderr)
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to describe region %s: %w: %s", s.region, err, strings.TrimSpace(stderr.String()))
+	}
+	var region struct {
+		Quotas []struct {
+			Metric string  `json:"metric"`
+			Usage  float64 `json:"usage"`
+			Limit  float64 `json:"limit"`
+		} `json:"quotas"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &region); err != nil {
+		return nil, fmt.Errorf("failed to parse the quotas of region %s: %w", s.region, err)
+	}
+	usage := map[string]quotaUsage{}
+	for _, quota := range region.Quotas {
+		if trackedQuotas[quota.Metric] {
+			usage[quota.Metric] = quotaUsage{Usage: quota.Usage, Limit: quota.Limit}
+		}
+	}
+	return usage, nil
+}
+
+// zoneRegion returns the region of a GCP zone, e.g. us-central1 for us-central1-a
+func zoneRegion(zone string) string {
+	if i := strings.LastIndex(zone, "-"); i > 0 {
+		return zone[:i]
+	}
+	return zone
+}
+
+// peakQuotaUsage records the highest usage seen for each metric in peaks
+func peakQuotaUsage(peaks, sample map[string]quotaUsage) {
+	for metric, usage := range sample {
+		if peak, ok := peaks[metric]; !ok || usage.Usage > peak.Usage {
+			peaks[metric] = usage
+		}
+	}
+}
+
+// watchQuotaUsage samples the quota usage every quotaSampleInterval until the
+// returned stop function is called, which returns the peak usage of each metric
+func (t *Tester) watchQuotaUsage(sampler quotaSampler) (stop func() map[string]quotaUsage) {
+	peaks := map[string]quotaUsage{}
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		for {
+			sample, err := sampler.Sample()
+			if err != nil {
+				klog.Warningf("failed to sample the quota usage: %v", err)
+			} else {
+				peakQuotaUsage(peaks, sample)
+			}
+			select {
+			case <-t.clock.After(quotaSampleInterval):
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() map[string]quotaUsage {
+		close(done)
+		<-exited
+		return peaks
+	}
+}
+
+// formatQuotaUsage formats usage as a sorted list of metric=usage/limit
+func formatQuotaUsage(usage map[string]quotaUsage) string {
+	var metrics []string
+	for metric := range usage {
+		metrics = append(metrics, metric)
+	}
+	sort.Strings(metrics)
+	var formatted []string
+	for _, metric := range metrics {
+		formatted = append(formatted, fmt.Sprintf("%s=%g/%g", metric, usage[metric].Usage, usage[metric].Limit))
+	}
+	return strings.Join(formatted, ",")
+}
+
+// reportQuotaUsage logs the peak quota usage of the run, writes it to
+// quota-usage.json in artifactsDir and records it in metadata.json
+func reportQuotaUsage(artifactsDir string, peaks map[string]quotaUsage) error {
+	formatted := formatQuotaUsage(peaks)
+	klog.V(0).Infof("peak quota usage of the run: %s", formatted)
+	if err := writeJSON(filepath.Join(artifactsDir, quotaUsageFileName), peaks); err != nil {
+		return err
+	}
+	return testers.WriteToMetadata(peakQuotaUsageMetadataKey, formatted)
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeQuotaSampler returns its samples in order, then keeps returning the last one
+type fakeQuotaSampler struct {
+	mu      sync.Mutex
+	samples []map[string]quotaUsage
+	errs    []error
+	calls   int
+}
+
+func (s *fakeQuotaSampler) Sample() (map[string]quotaUsage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.calls
+	if i >= len(s.samples) {
+		i = len(s.samples) - 1
+	}
+	s.calls++
+	if i < len(s.errs) && s.errs[i] != nil {
+		return nil, s.errs[i]
+	}
+	return s.samples[i], nil
+}
+
+func (s *fakeQuotaSampler) Calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+func TestWatchQuotaUsage(t *testing.T) {
+	sampler := &fakeQuotaSampler{
+		samples: []map[string]quotaUsage{
+			{"CPUS": {Usage: 2, Limit: 24}, "IN_USE_ADDRESSES": {Usage: 1, Limit: 8}},
+			{"CPUS": {Usage: 8, Limit: 24}, "IN_USE_ADDRESSES": {Usage: 4, Limit: 8}},
+			nil,
+			{"CPUS": {Usage: 4, Limit: 24}, "IN_USE_ADDRESSES": {Usage: 2, Limit: 8}, "DISKS_TOTAL_GB": {Usage: 100, Limit: 4096}},
+		},
+		errs: []error{nil, nil, errors.New("quota API unavailable"), nil},
+	}
+	clock := newFakeClock(0)
+	tester := NewDefaultTester()
+	tester.clock = clock
+
+	stop := tester.watchQuotaUsage(sampler)
+	for sampled := 1; sampled < len(sampler.samples); sampled++ {
+		waitFor(t, clock.HasWaiters)
+		clock.Advance(quotaSampleInterval)
+	}
+	waitFor(t, func() bool { return sampler.Calls() == len(sampler.samples) && clock.HasWaiters() })
+	peaks := stop()
+
+	expected := map[string]quotaUsage{
+		"CPUS":             {Usage: 8, Limit: 24},
+		"DISKS_TOTAL_GB":   {Usage: 100, Limit: 4096},
+		"IN_USE_ADDRESSES": {Usage: 4, Limit: 8},
+	}
+	if !reflect.DeepEqual(peaks, expected) {
+		t.Errorf("expected peak usage %v, but got %v", expected, peaks)
+	}
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the condition")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestGCEQuotaSampler(t *testing.T) {
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		_, _ = io.WriteString(cmd.stdout, `{"quotas": [
+  {"limit": 24.0, "metric": "CPUS", "usage": 6.0},
+  {"limit": 8.0, "metric": "IN_USE_ADDRESSES", "usage": 3.0},
+  {"limit": 100.0, "metric": "ROUTERS", "usage": 1.0}
+]}`)
+		return nil
+	}}
+	sampler := &gceQuotaSampler{cmder: cmder, project: "p", region: zoneRegion("us-central1-a")}
+
+	usage, err := sampler.Sample()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]quotaUsage{
+		"CPUS":             {Usage: 6, Limit: 24},
+		"IN_USE_ADDRESSES": {Usage: 3, Limit: 8},
+	}
+	if !reflect.DeepEqual(usage, expected) {
+		t.Errorf("expected usage %v, but got %v", expected, usage)
+	}
+	expectedArgs := []string{"compute", "regions", "describe", "us-central1", "--project=p", "--format=json(quotas)"}
+	if len(cmder.cmds) != 1 || !reflect.DeepEqual(cmder.cmds[0].args, expectedArgs) {
+		t.Errorf("expected gcloud %v, but got %v", expectedArgs, cmder.cmds)
+	}
+}
+
+func TestReportQuotaUsage(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ARTIFACTS", dir)
+	peaks := map[string]quotaUsage{
+		"IN_USE_ADDRESSES": {Usage: 4, Limit: 8},
+		"CPUS":             {Usage: 8, Limit: 24},
+	}
+	if err := reportQuotaUsage(dir, peaks); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "CPUS=8/24,IN_USE_ADDRESSES=4/8"
+	if actual := readMetadata(t, dir)[peakQuotaUsageMetadataKey]; actual != expected {
+		t.Errorf("expected peak quota usage %q in metadata, but got %q", expected, actual)
+	}
+	report := readJSON(t, filepath.Join(dir, quotaUsageFileName))
+	expectedReport := map[string]interface{}{
+		"CPUS":             map[string]interface{}{"usage": 8.0, "limit": 24.0},
+		"IN_USE_ADDRESSES": map[string]interface{}{"usage": 4.0, "limit": 8.0},
+	}
+	if !reflect.DeepEqual(report, expectedReport) {
+		t.Errorf("expected report %v, but got %v", expectedReport, report)
+	}
+}
+
+func TestReportQuotaUsageRequiresGCE(t *testing.T) {
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.Provider = "ec2"
+	tester.InstanceType = "m5.large"
+	tester.UserDataFile = "user-data.sh"
+	tester.ReportQuotaUsage = true
+	if err := tester.validateFlags(); err == nil {
+		t.Errorf("expected --report-quota-usage to be rejected with the ec2 provider")
+	}
+}
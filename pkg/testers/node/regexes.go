@@ -0,0 +1,36 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"sigs.k8s.io/kubetest2/pkg/artifacts"
+)
+
+const (
+	focusRegexMetadataKey = "focus-regex"
+	skipRegexMetadataKey  = "skip-regex"
+)
+
+// recordRegexes records the FOCUS and SKIP regexes the run passes to ginkgo in
+// metadata.json. It is called once the flags are composed and again whenever
+// the focus is narrowed at run time, so the metadata holds the final values.
+func (t *Tester) recordRegexes() error {
+	if err := setMetadata(artifacts.BaseDir(), focusRegexMetadataKey, t.FocusRegex); err != nil {
+		return err
+	}
+	return setMetadata(artifacts.BaseDir(), skipRegexMetadataKey, t.SkipRegex)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecordRegexes(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ARTIFACTS", dir)
+	var focus string
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		if strings.Contains(argValue(t, cmd.args, "TEST_ARGS"), "--ginkgo.dry-run") {
+			writeArtifact(t, argValue(t, cmd.env, "ARTIFACTS"), "junit_01.xml", dryRunJUnit)
+			return nil
+		}
+		focus = argValue(t, cmd.args, "FOCUS")
+		return nil
+	}}
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.GCPZone = "us-central1-a"
+	tester.SkipRegex = `\[Flaky\]|\[Serial\]`
+	tester.Shard = "1/1"
+	tester.cmder = cmder
+	if err := tester.validateFlags(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := tester.writeMetadata(); err != nil {
+		t.Fatalf("unexpected error writing metadata: %v", err)
+	}
+	if actual := readMetadata(t, dir)[focusRegexMetadataKey]; actual != tester.FocusRegex {
+		t.Errorf("expected focus regex %q in metadata before the run, but got %q", tester.FocusRegex, actual)
+	}
+	if err := tester.Test(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	meta := readMetadata(t, dir)
+	if focus == "" || meta[focusRegexMetadataKey] != focus {
+		t.Errorf("expected the composed focus regex %q in metadata, but got %q", focus, meta[focusRegexMetadataKey])
+	}
+	if actual := meta[skipRegexMetadataKey]; actual != tester.SkipRegex {
+		t.Errorf("expected skip regex %q in metadata, but got %q", tester.SkipRegex, actual)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// defaultSkipRegex is the skip regex of a run without --skip-regex
+const defaultSkipRegex = `\[Flaky\]|\[Slow\]|\[Serial\]`
+
+// regexFileFlags are the flags reading a regex flag from a file, keyed by the regex flag
+var regexFileFlags = map[string]string{
+	"focus-regex": "focus-file",
+	"skip-regex":  "skip-file",
+}
+
+// presetOverridden reports whether the preset of a profile or suite for the
+// flag is overridden, because the flag or the file it is read from was set
+func presetOverridden(fs *pflag.FlagSet, flagName string) bool {
+	if fs.Changed(flagName) {
+		return true
+	}
+	fileFlag, ok := regexFileFlags[flagName]
+	return ok && fs.Changed(fileFlag)
+}
+
+// loadRegexFile reads the regex in path, ignoring the surrounding whitespace
+func loadRegexFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	regex := strings.TrimSpace(string(data))
+	if regex == "" {
+		return "", fmt.Errorf("%s is empty", path)
+	}
+	if _, err := regexp.Compile(regex); err != nil {
+		return "", fmt.Errorf("%s is not a valid regex: %v", path, err)
+	}
+	return regex, nil
+}
+
+// loadRegexFiles sets the focus and skip regexes from --focus-file and
+// --skip-file, which cannot be combined with the inline flags
+func (t *Tester) loadRegexFiles() error {
+	if t.FocusFile != "" {
+		if t.FocusRegex != "" {
+			return fmt.Errorf("--focus-file cannot be combined with --focus-regex")
+		}
+		focus, err := loadRegexFile(t.FocusFile)
+		if err != nil {
+			return fmt.Errorf("invalid --focus-file: %v", err)
+		}
+		t.FocusRegex = focus
+	}
+	if t.SkipFile != "" {
+		if t.SkipRegex != "" && t.SkipRegex != defaultSkipRegex {
+			return fmt.Errorf("--skip-file cannot be combined with --skip-regex")
+		}
+		skip, err := loadRegexFile(t.SkipFile)
+		if err != nil {
+			return fmt.Errorf("invalid --skip-file: %v", err)
+		}
+		t.SkipRegex = skip
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/octago/sflags/gen/gpflag"
+)
+
+func TestRegexFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, dir, "focus.txt", "\n  \\[NodeConformance\\]|\\[NodeFeature:.+\\]\n\n")
+	writeArtifact(t, dir, "skip.txt", "\\[Flaky\\]|\\[Benchmark\\]\n")
+	writeArtifact(t, dir, "empty.txt", " \n")
+	writeArtifact(t, dir, "invalid.txt", "[Flaky\n")
+	focusFile, skipFile := filepath.Join(dir, "focus.txt"), filepath.Join(dir, "skip.txt")
+
+	testCases := []struct {
+		name          string
+		focusRegex    string
+		skipRegex     string
+		focusFile     string
+		skipFile      string
+		expectErr     bool
+		expectedFocus string
+		expectedSkip  string
+	}{
+		{
+			name:          "files",
+			focusFile:     focusFile,
+			skipFile:      skipFile,
+			expectedFocus: `\[NodeConformance\]|\[NodeFeature:.+\]`,
+			expectedSkip:  `\[Flaky\]|\[Benchmark\]`,
+		},
+		{
+			name:         "skip file replaces the default skip regex",
+			skipFile:     skipFile,
+			expectedSkip: `\[Flaky\]|\[Benchmark\]`,
+		},
+		{
+			name:       "focus file and inline focus",
+			focusRegex: `\[Serial\]`,
+			focusFile:  focusFile,
+			expectErr:  true,
+		},
+		{
+			name:      "skip file and inline skip",
+			skipRegex: `\[Slow\]`,
+			skipFile:  skipFile,
+			expectErr: true,
+		},
+		{
+			name:      "missing file",
+			focusFile: filepath.Join(dir, "missing.txt"),
+			expectErr: true,
+		},
+		{
+			name:      "empty file",
+			skipFile:  filepath.Join(dir, "empty.txt"),
+			expectErr: true,
+		},
+		{
+			name:      "invalid regex",
+			focusFile: filepath.Join(dir, "invalid.txt"),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.GCPZone = "us-central1-a"
+			tester.FocusRegex = tc.focusRegex
+			if tc.skipRegex != "" {
+				tester.SkipRegex = tc.skipRegex
+			}
+			tester.FocusFile = tc.focusFile
+			tester.SkipFile = tc.skipFile
+			err := tester.validateFlags()
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tester.FocusRegex != tc.expectedFocus {
+				t.Errorf("expected focus %q, but got %q", tc.expectedFocus, tester.FocusRegex)
+			}
+			if tester.SkipRegex != tc.expectedSkip {
+				t.Errorf("expected skip %q, but got %q", tc.expectedSkip, tester.SkipRegex)
+			}
+		})
+	}
+}
+
+func TestRegexFilesOverrideSuitePresets(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, dir, "skip.txt", `\[Benchmark\]`)
+	tester := NewDefaultTester()
+	fs, err := gpflag.Parse(tester)
+	if err != nil {
+		t.Fatalf("failed to parse tester flags: %v", err)
+	}
+	if err := fs.Parse([]string{"--suite=features", "--skip-file=" + filepath.Join(dir, "skip.txt")}); err != nil {
+		t.Fatalf("failed to parse args: %v", err)
+	}
+	if err := applySuite(fs, tester.Suite); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.GCPZone = "us-central1-a"
+	if err := tester.validateFlags(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := `\[NodeFeature:.+\]|\[NodeFeature\]`; tester.FocusRegex != expected {
+		t.Errorf("expected the focus preset %q of the suite, but got %q", expected, tester.FocusRegex)
+	}
+	if expected := `\[Benchmark\]`; tester.SkipRegex != expected {
+		t.Errorf("expected the skip regex %q of --skip-file over the suite preset, but got %q", expected, tester.SkipRegex)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// repoRootMarkers are paths every checkout of the repository the provider
+// runs the tests from has, relative to the repo root
+var repoRootMarkers = map[string]string{
+	// the ec2 provider runs the tests from provider-aws-test-infra
+	"ec2": "kubetest2-ec2",
+}
+
+// defaultRepoRootMarker is the path every kubernetes checkout has
+const defaultRepoRootMarker = "hack/make-rules/test-e2e-node.sh"
+
+// validateRepoRoot checks that --repo-root is a checkout of the repository the
+// tests are run from, with a Makefile defining the make target of the suite, so
+// that a wrong path fails before any instance is created rather than minutes
+// into the run with "no rule to make target"
+func (t *Tester) validateRepoRoot() error {
+	makefile := filepath.Join(t.RepoRoot, "Makefile")
+	data, err := os.ReadFile(makefile)
+	if err != nil {
+		return fmt.Errorf("--repo-root %s is not a kubernetes or provider-aws-test-infra checkout, failed to read %s: %w", t.RepoRoot, makefile, err)
+	}
+	targetRegex := regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(t.makeTarget()) + `\s*:`)
+	if !targetRegex.Match(data) {
+		return fmt.Errorf("--repo-root %s is not a kubernetes or provider-aws-test-infra checkout, %s does not define the %s target", t.RepoRoot, makefile, t.makeTarget())
+	}
+	marker, ok := repoRootMarkers[t.Provider]
+	if !ok {
+		marker = defaultRepoRootMarker
+	}
+	if _, err := os.Stat(filepath.Join(t.RepoRoot, marker)); err != nil {
+		return fmt.Errorf("--repo-root %s is not a checkout of the repository the %s provider runs the tests from, %s is missing", t.RepoRoot, t.Provider, filepath.Join(t.RepoRoot, marker))
+	}
+	return nil
+}
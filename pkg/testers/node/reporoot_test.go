@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeRepoRoot returns a directory passing as the repo root of every provider
+func fakeRepoRoot(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeArtifact(t, dir, "Makefile", "test-e2e-node: ginkgo\n\thack/make-rules/test-e2e-node.sh\n")
+	writeArtifact(t, dir, defaultRepoRootMarker, "#!/usr/bin/env bash\n")
+	if err := os.MkdirAll(filepath.Join(dir, repoRootMarkers["ec2"]), 0755); err != nil {
+		t.Fatalf("failed to create the ec2 marker: %v", err)
+	}
+	return dir
+}
+
+func TestValidateRepoRoot(t *testing.T) {
+	testCases := []struct {
+		name        string
+		provider    string
+		files       map[string]string
+		expectedErr string
+	}{
+		{
+			name:     "kubernetes checkout",
+			provider: "gce",
+			files: map[string]string{
+				"Makefile":            "test-e2e-node: ginkgo\n",
+				defaultRepoRootMarker: "",
+			},
+		},
+		{
+			name:        "no Makefile",
+			provider:    "gce",
+			files:       map[string]string{defaultRepoRootMarker: ""},
+			expectedErr: "Makefile",
+		},
+		{
+			name:     "Makefile without the target",
+			provider: "gce",
+			files: map[string]string{
+				"Makefile":            "all:\n\tgo build ./...\n",
+				defaultRepoRootMarker: "",
+			},
+			expectedErr: "does not define the test-e2e-node target",
+		},
+		{
+			name:        "missing marker",
+			provider:    "gce",
+			files:       map[string]string{"Makefile": "test-e2e-node:\n"},
+			expectedErr: defaultRepoRootMarker + " is missing",
+		},
+		{
+			name:     "provider-aws-test-infra checkout",
+			provider: "ec2",
+			files: map[string]string{
+				"Makefile":                "test-e2e-node: ginkgo\n",
+				"kubetest2-ec2/README.md": "",
+			},
+		},
+		{
+			name:     "kubernetes checkout with the ec2 provider",
+			provider: "ec2",
+			files: map[string]string{
+				"Makefile":            "test-e2e-node: ginkgo\n",
+				defaultRepoRootMarker: "",
+			},
+			expectedErr: "kubetest2-ec2 is missing",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, contents := range tc.files {
+				writeArtifact(t, dir, name, contents)
+			}
+			tester := NewDefaultTester()
+			tester.RepoRoot = dir
+			tester.Provider = tc.provider
+			err := tester.validateRepoRoot()
+			if tc.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+				t.Errorf("expected an error containing %q, but got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	summaryFileName       = "summary.json"
+	metadataFileName      = "metadata.json"
+	summaryMetadataKey    = "test-summary"
+	failedSpecsSummaryMax = 20
+)
+
+// runSummary is the summary.json schema, the outcome of the specs of a run
+type runSummary struct {
+	RunID       string   `json:"runID"`
+	Passed      int      `json:"passed"`
+	Failed      int      `json:"failed"`
+	Skipped     int      `json:"skipped"`
+	FailedSpecs []string `json:"failedSpecs,omitempty"`
+	PassedSpecs []string `json:"passedSpecs,omitempty"`
+}
+
+func (s runSummary) String() string {
+	return fmt.Sprintf("%d passed, %d failed, %d skipped", s.Passed, s.Failed, s.Skipped)
+}
+
+// setMetadata sets key to value in the metadata.json of artifactsDir, unlike
+// testers.WriteToMetadata it replaces the value if the key is already set
+func setMetadata(artifactsDir, key, value string) error {
+	path := filepath.Join(artifactsDir, metadataFileName)
+	meta := map[string]string{}
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &meta); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	meta[key] = value
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeSummary parses the results in artifactsDir, writes them to
+// summary.json and records the spec counts in metadata.json
+func (t *Tester) writeSummary(artifactsDir string) (runSummary, error) {
+	results, err := t.results(artifactsDir)
+	if err != nil {
+		return runSummary{}, err
+	}
+	summary := runSummary{
+		RunID:       t.runID,
+		Passed:      results.Passed,
+		Failed:      results.Failed,
+		Skipped:     results.Skipped,
+		FailedSpecs: results.failedSpecs(),
+		PassedSpecs: results.passedSpecs(),
+	}
+	if err := writeJSON(filepath.Join(artifactsDir, summaryFileName), summary); err != nil {
+		return summary, err
+	}
+	value := summary.String()
+	if len(summary.FailedSpecs) > 0 && len(summary.FailedSpecs) <= failedSpecsSummaryMax {
+		value += ": " + strings.Join(summary.FailedSpecs, ", ")
+	}
+	return summary, setMetadata(artifactsDir, summaryMetadataKey, value)
+}
+
+// regenerateReport regenerates the summary, metadata and the enabled reports
+// from the artifacts of a previous run in artifactsDir, without running any
+// tests or creating any instances. It fails if the run had failures that are
+// not known failures.
+func (t *Tester) regenerateReport(artifactsDir string) error {
+	if info, err := os.Stat(artifactsDir); err != nil || !info.IsDir() {
+		return fmt.Errorf("invalid --report-only: %s is not a directory", artifactsDir)
+	}
+	if _, ok := resultParsers[t.ResultFormat]; !ok {
+		return fmt.Errorf("invalid --result-format %q, must be one of %s", t.ResultFormat, strings.Join(resultFormats(), ", "))
+	}
+	if t.KnownFailuresFile != "" {
+		knownFailures, err := loadSpecList(t.KnownFailuresFile)
+		if err != nil {
+			return fmt.Errorf("invalid --known-failures-file: %v", err)
+		}
+		t.knownFailures = knownFailures
+	}
+
+	klog.V(0).Infof("regenerating the report of %s", artifactsDir)
+	summary, err := t.writeSummary(artifactsDir)
+	if err != nil {
+		return fmt.Errorf("failed to regenerate the summary: %w", err)
+	}
+	klog.V(0).Infof("results: %s", summary)
+	if t.DetectKubeletRestarts {
+		t.reportKubeletRestarts(artifactsDir)
+	}
+	if t.ReportSkippedSpecs {
+		t.reportSkippedSpecs(artifactsDir)
+	}
+	if t.SpecTimingsCSV {
+		if err := t.writeSpecTimings(artifactsDir); err != nil {
+			klog.Warningf("failed to write the spec timings: %v", err)
+		}
+	}
+	if t.matrixRun() {
+		if err := t.writeCompatibilityMatrix(artifactsDir); err != nil {
+			klog.Warningf("failed to write the compatibility matrix: %v", err)
+		}
+	}
+	var baselineErr error
+	if t.BaselineSummary != "" {
+		baselineErr = t.compareWithBaseline(artifactsDir, summary)
+	}
+	var runErr error
+	if summary.Failed > 0 {
+		runErr = fmt.Errorf("%d specs failed", summary.Failed)
+	}
+	if err := t.processResults(artifactsDir, runErr); err != nil {
+		return err
+	}
+	return baselineErr
+}
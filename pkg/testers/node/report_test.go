@@ -0,0 +1,184 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReportOnly(t *testing.T) {
+	testCases := []struct {
+		name             string
+		knownFailures    string
+		expectErr        bool
+		expectedSummary  runSummary
+		expectedMetadata string
+	}{
+		{
+			name:      "failures fail the report",
+			expectErr: true,
+			expectedSummary: runSummary{
+				Passed:      1,
+				Failed:      2,
+				Skipped:     1,
+				FailedSpecs: []string{"[It] known flake", "[It] regression"},
+				PassedSpecs: []string{"[It] fixed bug"},
+			},
+			expectedMetadata: "1 passed, 2 failed, 1 skipped: [It] known flake, [It] regression",
+		},
+		{
+			name:          "known failures do not fail the report",
+			knownFailures: "[It] known flake\n[It] regression\n",
+			expectedSummary: runSummary{
+				Passed:      1,
+				Failed:      2,
+				Skipped:     1,
+				FailedSpecs: []string{"[It] known flake", "[It] regression"},
+				PassedSpecs: []string{"[It] fixed bug"},
+			},
+			expectedMetadata: "1 passed, 2 failed, 1 skipped: [It] known flake, [It] regression",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			artifactsDir := filepath.Join(dir, "artifacts")
+			writeArtifact(t, artifactsDir, "junit_01.xml", sampleJUnit)
+			writeArtifact(t, artifactsDir, "metadata.json", `{"run-id": "run", "test-summary": "stale"}`)
+			cmder := &fakeCmder{}
+			tester := NewDefaultTester()
+			tester.cmder = cmder
+			if tc.knownFailures != "" {
+				writeArtifact(t, dir, "known-failures.txt", tc.knownFailures)
+				tester.KnownFailuresFile = filepath.Join(dir, "known-failures.txt")
+			}
+
+			err := tester.regenerateReport(artifactsDir)
+			if tc.expectErr && err == nil {
+				t.Error("expected an error but got none")
+			}
+			if !tc.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if len(cmder.cmds) != 0 {
+				t.Errorf("expected no commands to be run, but got %s %v", cmder.cmds[0].name, cmder.cmds[0].args)
+			}
+
+			data, err := os.ReadFile(filepath.Join(artifactsDir, summaryFileName))
+			if err != nil {
+				t.Fatalf("failed to read the summary: %v", err)
+			}
+			var summary runSummary
+			if err := json.Unmarshal(data, &summary); err != nil {
+				t.Fatalf("failed to parse the summary: %v", err)
+			}
+			if !reflect.DeepEqual(summary, tc.expectedSummary) {
+				t.Errorf("expected summary %+v, but got %+v", tc.expectedSummary, summary)
+			}
+			meta := readMetadata(t, artifactsDir)
+			if meta[summaryMetadataKey] != tc.expectedMetadata {
+				t.Errorf("expected %s %q in metadata, but got %q", summaryMetadataKey, tc.expectedMetadata, meta[summaryMetadataKey])
+			}
+			if meta["run-id"] != "run" {
+				t.Errorf("expected the other metadata to be kept, but got %v", meta)
+			}
+		})
+	}
+}
+
+func TestReportOnlyTestGridFlattened(t *testing.T) {
+	artifactsDir := filepath.Join(t.TempDir(), "artifacts")
+	writeArtifact(t, artifactsDir, "metadata.json", `{"run-id": "run"}`)
+	writeArtifact(t, artifactsDir, "gates-a/junit_01.xml", sampleJUnit)
+	if err := writeTestGridFinished(artifactsDir, time.Now(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tester := NewDefaultTester()
+	tester.cmder = &fakeCmder{}
+	if err := tester.regenerateReport(artifactsDir); err == nil {
+		t.Error("expected an error for the failed specs but got none")
+	}
+	data, err := os.ReadFile(filepath.Join(artifactsDir, summaryFileName))
+	if err != nil {
+		t.Fatalf("failed to read the summary: %v", err)
+	}
+	var summary runSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("failed to parse the summary: %v", err)
+	}
+	if summary.Passed != 1 || summary.Failed != 2 || summary.Skipped != 1 {
+		t.Errorf("expected the flattened junit files not to be counted again, but got %+v", summary)
+	}
+}
+
+func TestReportOnlyMissingDir(t *testing.T) {
+	tester := NewDefaultTester()
+	if err := tester.regenerateReport(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected an error for a missing artifacts directory")
+	}
+}
+
+func TestResultsDir(t *testing.T) {
+	testCases := []struct {
+		name        string
+		junit       string
+		expectedErr string
+	}{
+		{
+			name:        "failed specs are counted",
+			junit:       sampleJUnit,
+			expectedErr: "1 passed, 2 failed, 1 skipped: ",
+		},
+		{
+			name:        "no results",
+			expectedErr: "exit status 2",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("ARTIFACTS", dir)
+			tester := NewDefaultTester()
+			tester.cmder = &fakeCmder{run: func(cmd *fakeCmd) error {
+				if tc.junit != "" {
+					writeArtifact(t, argValue(t, cmd.env, "ARTIFACTS"), "junit_01.xml", tc.junit)
+				}
+				return errors.New("exit status 2")
+			}}
+
+			if actual := tester.ResultsDir(); actual != dir {
+				t.Errorf("expected results dir %s, but got %s", dir, actual)
+			}
+			err := tester.Test()
+			if err == nil || !strings.HasPrefix(err.Error(), tc.expectedErr) {
+				t.Errorf("expected an error starting with %q, but got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
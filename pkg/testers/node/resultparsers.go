@@ -0,0 +1,190 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	junitResultFormat      = "junit"
+	ginkgoJSONResultFormat = "ginkgo-json"
+)
+
+// ResultParser aggregates the spec results written under an artifacts
+// directory. Parsers of other formats are added with RegisterResultParser.
+type ResultParser interface {
+	Parse(dir string) (*Summary, error)
+}
+
+// resultParserFunc adapts a function to a ResultParser
+type resultParserFunc func(dir string) (*Summary, error)
+
+func (f resultParserFunc) Parse(dir string) (*Summary, error) {
+	return f(dir)
+}
+
+// resultParsers are the parsers selectable with --result-format
+var resultParsers = map[string]ResultParser{
+	junitResultFormat:      resultParserFunc(parseJUnitResults),
+	ginkgoJSONResultFormat: resultParserFunc(parseGinkgoJSONResults),
+}
+
+// RegisterResultParser makes p selectable as --result-format=name. It is meant
+// to be called from an init function of a tester embedding this one, and
+// panics if name is already registered.
+func RegisterResultParser(name string, p ResultParser) {
+	if _, ok := resultParsers[name]; ok {
+		panic(fmt.Errorf("result format %q registered twice", name))
+	}
+	resultParsers[name] = p
+}
+
+func resultFormats() []string {
+	formats := make([]string, 0, len(resultParsers))
+	for format := range resultParsers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// ginkgoReport is the subset of a ginkgo --json-report entry that the tester reads
+type ginkgoReport struct {
+	SuiteDescription string             `json:"SuiteDescription"`
+	SpecReports      []ginkgoSpecReport `json:"SpecReports"`
+}
+
+type ginkgoSpecReport struct {
+	ContainerHierarchyTexts  []string      `json:"ContainerHierarchyTexts"`
+	ContainerHierarchyLabels [][]string    `json:"ContainerHierarchyLabels"`
+	LeafNodeType             string        `json:"LeafNodeType"`
+	LeafNodeText             string        `json:"LeafNodeText"`
+	LeafNodeLabels           []string      `json:"LeafNodeLabels"`
+	State                    string        `json:"State"`
+	RunTime                  time.Duration `json:"RunTime"`
+	Failure                  struct {
+		Message string `json:"Message"`
+	} `json:"Failure"`
+}
+
+// name matches the name ginkgo gives the spec in its junit report, the
+// leaf node type, the full text and the labels of the spec
+func (r ginkgoSpecReport) name() string {
+	name := fmt.Sprintf("[%s]", r.LeafNodeType)
+	texts := append([]string{}, r.ContainerHierarchyTexts...)
+	if r.LeafNodeText != "" {
+		texts = append(texts, r.LeafNodeText)
+	}
+	if len(texts) > 0 {
+		name += " " + strings.Join(texts, " ")
+	}
+	if labels := r.labels(); len(labels) > 0 {
+		name += " [" + strings.Join(labels, ", ") + "]"
+	}
+	return name
+}
+
+// labels returns the labels of the containers and the spec without
+// duplicates, in the order ginkgo reports them
+func (r ginkgoSpecReport) labels() []string {
+	var labels []string
+	seen := map[string]bool{}
+	for _, nodeLabels := range append(append([][]string{}, r.ContainerHierarchyLabels...), r.LeafNodeLabels) {
+		for _, label := range nodeLabels {
+			if !seen[label] {
+				seen[label] = true
+				labels = append(labels, label)
+			}
+		}
+	}
+	return labels
+}
+
+func (r ginkgoSpecReport) result(file string) SpecResult {
+	result := SpecResult{
+		Name:     r.name(),
+		Duration: r.RunTime,
+		Message:  r.Failure.Message,
+		File:     file,
+	}
+	switch r.State {
+	case "passed":
+		result.Status = SpecPassed
+	case "skipped", "pending":
+		result.Status = SpecSkipped
+	default:
+		// failed, panicked, interrupted, aborted and timedout
+		result.Status = SpecFailed
+	}
+	return result
+}
+
+// isGinkgoJSONResultsFile reports whether name is a ginkgo json report
+func isGinkgoJSONResultsFile(name string) bool {
+	matched, _ := filepath.Match("*report*.json", name)
+	return matched
+}
+
+// parseGinkgoJSONFile parses the spec results from a single ginkgo json report
+func parseGinkgoJSONFile(path string) ([]SpecResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var reports []ginkgoReport
+	if err := json.Unmarshal(data, &reports); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	var results []SpecResult
+	for _, report := range reports {
+		for _, spec := range report.SpecReports {
+			// only the specs themselves are reported, not the setup nodes
+			if spec.LeafNodeType != "It" {
+				continue
+			}
+			results = append(results, spec.result(path))
+		}
+	}
+	return results, nil
+}
+
+// parseGinkgoJSONResults aggregates the results of every ginkgo json report under dir
+func parseGinkgoJSONResults(dir string) (*Summary, error) {
+	files, err := findResultFiles(dir, isGinkgoJSONResultsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Summary{}
+	for _, file := range files {
+		results, err := parseGinkgoJSONFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			s.Add(result)
+		}
+	}
+	return s, nil
+}
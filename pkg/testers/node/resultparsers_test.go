@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+const sampleGinkgoJSON = `[
+  {
+    "SuiteDescription": "E2eNode Suite",
+    "SpecReports": [
+      {"LeafNodeType": "SynchronizedBeforeSuite", "State": "passed"},
+      {"ContainerHierarchyTexts": ["[sig-node] Pods"], "LeafNodeType": "It", "LeafNodeText": "should start", "State": "passed", "RunTime": 1500000000},
+      {"ContainerHierarchyTexts": ["[sig-node] Pods"], "LeafNodeType": "It", "LeafNodeText": "should stop", "State": "failed", "RunTime": 500000000, "Failure": {"Message": "expected true"}},
+      {"ContainerHierarchyTexts": ["[sig-node] Pods"], "LeafNodeType": "It", "LeafNodeText": "should hang", "State": "timedout"},
+      {"ContainerHierarchyTexts": ["[sig-node] Pods"], "LeafNodeType": "It", "LeafNodeText": "should be skipped", "State": "skipped"}
+    ]
+  }
+]`
+
+func TestParseGinkgoJSONResults(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, dir, "ginkgo_report.json", sampleGinkgoJSON)
+	// other json artifacts and junit files are not ginkgo reports
+	writeArtifact(t, dir, "metadata.json", `{"tester-version":"v1"}`)
+	writeArtifact(t, dir, "junit_01.xml", sampleJUnit)
+	writeArtifact(t, dir, warmupDirName+"/ginkgo_report.json", sampleGinkgoJSON)
+
+	results, err := parseGinkgoJSONResults(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results.Passed != 1 || results.Failed != 2 || results.Skipped != 1 {
+		t.Errorf("expected 1 passed, 2 failed, 1 skipped, but got %+v", results)
+	}
+	expectedFailed := []string{"[It] [sig-node] Pods should hang", "[It] [sig-node] Pods should stop"}
+	if actual := results.failedSpecs(); !reflect.DeepEqual(actual, expectedFailed) {
+		t.Errorf("expected failed specs %v, but got %v", expectedFailed, actual)
+	}
+	if results.Specs[0].Duration != 1500*time.Millisecond {
+		t.Errorf("expected a duration of 1.5s, but got %v", results.Specs[0].Duration)
+	}
+	if results.Specs[1].Message != "expected true" {
+		t.Errorf("expected the failure message to be read, but got %q", results.Specs[1].Message)
+	}
+}
+
+func TestGinkgoJSONLabeledSpecName(t *testing.T) {
+	junitDir := t.TempDir()
+	writeArtifact(t, junitDir, "junit_01.xml", `<testsuite name="E2eNode Suite">
+  <testcase name="[It] [sig-node] Pods should start [NodeConformance, Feature:Pods, Slow]" time="1"></testcase>
+</testsuite>`)
+	jsonDir := t.TempDir()
+	writeArtifact(t, jsonDir, "ginkgo_report.json", `[{"SuiteDescription": "E2eNode Suite", "SpecReports": [
+  {"ContainerHierarchyTexts": ["[sig-node] Pods"], "ContainerHierarchyLabels": [["NodeConformance", "Feature:Pods"]],
+   "LeafNodeType": "It", "LeafNodeText": "should start", "LeafNodeLabels": ["Feature:Pods", "Slow"], "State": "passed"}
+]}]`)
+
+	fromJUnit, err := parseJUnitResults(junitDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fromJSON, err := parseGinkgoJSONResults(jsonDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fromJUnit.Passed != 1 || fromJSON.Passed != 1 {
+		t.Fatalf("expected the spec to pass in both reports, but got %+v and %+v", fromJUnit, fromJSON)
+	}
+	if !reflect.DeepEqual(fromJSON.passedSpecs(), fromJUnit.passedSpecs()) {
+		t.Errorf("expected the ginkgo json report to name the spec %v like the junit report, but got %v", fromJUnit.passedSpecs(), fromJSON.passedSpecs())
+	}
+}
+
+type fakeResultParser struct {
+	dirs []string
+}
+
+func (p *fakeResultParser) Parse(dir string) (*Summary, error) {
+	p.dirs = append(p.dirs, dir)
+	s := &Summary{}
+	s.Add(SpecResult{Name: "custom", Status: SpecFailed})
+	return s, nil
+}
+
+func TestResultFormat(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, dir, "junit_01.xml", sampleJUnit)
+	writeArtifact(t, dir, "ginkgo_report.json", sampleGinkgoJSON)
+
+	custom := &fakeResultParser{}
+	RegisterResultParser("custom", custom)
+	defer delete(resultParsers, "custom")
+
+	testCases := []struct {
+		format         string
+		expectedFailed []string
+		expectErr      bool
+	}{
+		{
+			format:         junitResultFormat,
+			expectedFailed: []string{"[It] known flake", "[It] regression"},
+		},
+		{
+			format:         ginkgoJSONResultFormat,
+			expectedFailed: []string{"[It] [sig-node] Pods should hang", "[It] [sig-node] Pods should stop"},
+		},
+		{
+			format:         "custom",
+			expectedFailed: []string{"custom"},
+		},
+		{
+			format:    "tap",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.format, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.GCPZone = "us-central1-a"
+			tester.ResultFormat = tc.format
+			err := tester.validateFlags()
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error for format %q but got none", tc.format)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			results, err := tester.results(dir)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := results.failedSpecs(); !reflect.DeepEqual(actual, tc.expectedFailed) {
+				t.Errorf("expected failed specs %v, but got %v", tc.expectedFailed, actual)
+			}
+		})
+	}
+
+	if !reflect.DeepEqual(custom.dirs, []string{dir}) {
+		t.Errorf("expected the custom parser to parse %s, but got %v", dir, custom.dirs)
+	}
+}
+
+func TestRegisterResultParserTwice(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected registering junit again to panic")
+		}
+	}()
+	RegisterResultParser(junitResultFormat, &fakeResultParser{})
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SpecStatus is the outcome of a spec, one of SpecPassed, SpecFailed or SpecSkipped
+type SpecStatus string
+
+const (
+	SpecPassed  SpecStatus = "passed"
+	SpecFailed  SpecStatus = "failed"
+	SpecSkipped SpecStatus = "skipped"
+)
+
+// SpecResult is the outcome of a single spec
+type SpecResult struct {
+	Name     string
+	Status   SpecStatus
+	Duration time.Duration
+	// Message is the failure or skip message, if any
+	Message string
+	// File is the junit file the result was read from
+	File string
+	// Flaky is set for a spec that passed after failing an earlier
+	// --flake-attempts attempt
+	Flaky bool
+}
+
+// Summary is the aggregated result of a node e2e run
+type Summary struct {
+	Passed  int
+	Failed  int
+	Skipped int
+	Specs   []SpecResult
+}
+
+// Add counts result towards the outcome of its status
+func (s *Summary) Add(result SpecResult) {
+	switch result.Status {
+	case SpecPassed:
+		s.Passed++
+	case SpecFailed:
+		s.Failed++
+	case SpecSkipped:
+		s.Skipped++
+	}
+	s.Specs = append(s.Specs, result)
+}
+
+// Total is the number of specs in the summary
+func (s *Summary) Total() int {
+	return s.Passed + s.Failed + s.Skipped
+}
+
+// failedSpecs returns the sorted names of the failed specs
+func (s *Summary) failedSpecs() []string {
+	return s.specNames(SpecFailed)
+}
+
+// flakySpecs returns the sorted names of the specs that only passed after failing
+func (s *Summary) flakySpecs() []string {
+	var names []string
+	for _, spec := range s.Specs {
+		if spec.Flaky {
+			names = append(names, spec.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// passedSpecs returns the sorted names of the passed specs
+func (s *Summary) passedSpecs() []string {
+	return s.specNames(SpecPassed)
+}
+
+// specNames returns the sorted names of the specs with status
+func (s *Summary) specNames(status SpecStatus) []string {
+	var names []string
+	for _, spec := range s.Specs {
+		if spec.Status == status {
+			names = append(names, spec.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// junitTestSuites is the subset of the junit format produced by ginkgo
+// that the tester reads, either a <testsuites> or a single <testsuite> root
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:""`
+	Suites  []junitTestSuite `xml:"testsuite"`
+	junitTestSuite
+}
+
+type junitTestSuite struct {
+	Name  string          `xml:"name,attr"`
+	Cases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Status    string        `xml:"status,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure"`
+	Error     *junitMessage `xml:"error"`
+	Skipped   *junitMessage `xml:"skipped"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message  string `xml:"message,attr"`
+	Contents string `xml:",chardata"`
+}
+
+func (m *junitMessage) text() string {
+	if m.Message != "" {
+		return m.Message
+	}
+	return m.Contents
+}
+
+func (c junitTestCase) result(file string) SpecResult {
+	result := SpecResult{
+		Name:     c.Name,
+		Status:   SpecPassed,
+		Duration: time.Duration(c.Time * float64(time.Second)),
+		File:     file,
+	}
+	switch {
+	case c.Status == string(SpecPassed):
+		// ginkgo keeps the failures of the earlier attempts of a
+		// spec that passed on a later --flake-attempts attempt
+		result.Flaky = c.Failure != nil || c.Error != nil
+	case c.Failure != nil:
+		result.Status = SpecFailed
+		result.Message = c.Failure.text()
+	case c.Error != nil:
+		result.Status = SpecFailed
+		result.Message = c.Error.text()
+	case c.Skipped != nil:
+		result.Status = SpecSkipped
+		result.Message = c.Skipped.text()
+	}
+	return result
+}
+
+// warmupDirName is the artifacts subdirectory of the warmup run
+const warmupDirName = "warmup"
+
+// isJUnitResultsFile reports whether name is a junit file produced by the tests
+func isJUnitResultsFile(name string) bool {
+	matched, _ := filepath.Match("junit*.xml", name)
+	return matched && !strings.HasPrefix(name, flattenedJUnitPrefix)
+}
+
+// parseJUnitFile parses the spec results from a single junit file
+func parseJUnitFile(path string) ([]SpecResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var suites junitTestSuites
+	if err := xml.Unmarshal(data, &suites); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	var results []SpecResult
+	if suites.XMLName.Local == "testsuite" {
+		suites.Suites = []junitTestSuite{suites.junitTestSuite}
+	}
+	for _, suite := range suites.Suites {
+		for _, c := range suite.Cases {
+			results = append(results, c.result(path))
+		}
+	}
+	return results, nil
+}
+
+// isAuxiliaryRunDir reports whether name is the artifacts subdirectory of a
+// run that is not part of the results, the warmup and the dry run listing the specs
+func isAuxiliaryRunDir(name string) bool {
+	return name == warmupDirName || name == listSpecsDirName
+}
+
+// findResultFiles returns the files under dir whose names match, skipping
+// the auxiliary runs which are not part of the results of this run
+func findResultFiles(dir string, match func(name string) bool) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && path != dir && isAuxiliaryRunDir(d.Name()) {
+			return filepath.SkipDir
+		}
+		if d.Type().IsRegular() && match(d.Name()) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find result files under %s: %w", dir, err)
+	}
+	return files, nil
+}
+
+// parseJUnitResults aggregates the results of every junit file under dir
+func parseJUnitResults(dir string) (*Summary, error) {
+	files, err := findResultFiles(dir, isJUnitResultsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Summary{}
+	for _, file := range files {
+		results, err := parseJUnitFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			s.Add(result)
+		}
+	}
+	return s, nil
+}
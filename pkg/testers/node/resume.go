@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// subRunCheckpointFileName is written to the artifacts directory of a sub-run
+// once it completes, so that a restarted run with --resume can skip it
+const subRunCheckpointFileName = "sub-run-complete.json"
+
+// imageSubRuns splits each of runs into a sub-run per image, so that a
+// multi-image run can be resumed from the first image that did not complete.
+// An empty runs is the single run of t.
+func (t *Tester) imageSubRuns(runs []subRun) []subRun {
+	images := strings.Split(t.Images, ",")
+	if t.Images == "" || len(images) <= 1 {
+		return runs
+	}
+	if len(runs) == 0 {
+		runs = []subRun{{tester: t}}
+	}
+	var split []subRun
+	for _, r := range runs {
+		for _, image := range images {
+			sub := *r.tester
+			sub.Images = image
+			label := image
+			if r.label != "" {
+				label = r.label + "/" + image
+			}
+			split = append(split, subRun{label: label, tester: &sub})
+		}
+	}
+	return split
+}
+
+// writeSubRunCheckpoint records that the sub-run writing its artifacts to dir completed with result
+func writeSubRunCheckpoint(dir string, result subRunResult) {
+	if err := writeJSON(filepath.Join(dir, subRunCheckpointFileName), result); err != nil {
+		klog.Warningf("failed to checkpoint sub-run %s: %v", result.Label, err)
+	}
+}
+
+// completedSubRun returns the result of the sub-run writing its artifacts to
+// dir if it completed before a restart. It is only considered complete if its
+// checkpoint is present and its results are still there and match it.
+func (t *Tester) completedSubRun(dir string) (subRunResult, bool) {
+	data, err := os.ReadFile(filepath.Join(dir, subRunCheckpointFileName))
+	if err != nil {
+		return subRunResult{}, false
+	}
+	var result subRunResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		klog.Warningf("ignoring the invalid checkpoint in %s: %v", dir, err)
+		return subRunResult{}, false
+	}
+	summary, err := t.results(dir)
+	if err != nil || summary.Passed != result.Passed || summary.Failed != result.Failed || summary.Skipped != result.Skipped {
+		klog.Warningf("ignoring the checkpoint in %s, the results it recorded are missing or changed", dir)
+		return subRunResult{}, false
+	}
+	return result, true
+}
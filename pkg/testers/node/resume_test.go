@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResume(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ARTIFACTS", dir)
+	// cos-stable completed, ubuntu-2204 was interrupted after writing
+	// some results and fedora-coreos never started
+	writeArtifact(t, dir, "cos-stable/junit_01.xml", sampleJUnit)
+	writeArtifact(t, dir, "cos-stable/"+subRunCheckpointFileName, `{"label": "cos-stable", "passed": 1, "failed": 2, "skipped": 1, "error": "specs failed"}`)
+	writeArtifact(t, dir, "ubuntu-2204/junit_01.xml", sampleJUnit)
+
+	var images []string
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		images = append(images, argValue(t, cmd.args, "IMAGES"))
+		writeArtifact(t, argValue(t, cmd.env, "ARTIFACTS"), "junit_01.xml", `<testsuite><testcase name="[It] passes"/></testsuite>`)
+		return nil
+	}}
+	tester := NewDefaultTester()
+	tester.Images = "cos-stable,ubuntu-2204,fedora-coreos"
+	tester.Resume = true
+	tester.cmder = cmder
+
+	err := tester.Test()
+	if err == nil {
+		t.Fatal("expected the failure of the completed sub-run to fail the run")
+	}
+	if expected := []string{"ubuntu-2204", "fedora-coreos"}; !reflect.DeepEqual(images, expected) {
+		t.Errorf("expected only images %v to run, but got %v", expected, images)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, subRunResultsFileName))
+	if err != nil {
+		t.Fatalf("failed to read the sub-run results: %v", err)
+	}
+	var results []subRunResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		t.Fatalf("failed to parse the sub-run results: %v", err)
+	}
+	expected := []subRunResult{
+		{Label: "cos-stable", Passed: 1, Failed: 2, Skipped: 1, Error: "specs failed"},
+		{Label: "ubuntu-2204", Passed: 1},
+		{Label: "fedora-coreos", Passed: 1},
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("expected sub-run results %+v, but got %+v", expected, results)
+	}
+	for _, image := range []string{"ubuntu-2204", "fedora-coreos"} {
+		if _, err := os.Stat(filepath.Join(dir, image, subRunCheckpointFileName)); err != nil {
+			t.Errorf("expected sub-run %s to be checkpointed: %v", image, err)
+		}
+	}
+}
+
+func TestResumeIgnoresStaleCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	// the checkpoint does not match the results left in the directory
+	writeArtifact(t, dir, "junit_01.xml", sampleJUnit)
+	writeArtifact(t, dir, subRunCheckpointFileName, `{"label": "cos-stable", "passed": 5}`)
+
+	tester := NewDefaultTester()
+	if _, ok := tester.completedSubRun(dir); ok {
+		t.Error("expected a checkpoint that does not match the results not to count as completed")
+	}
+	if _, ok := tester.completedSubRun(t.TempDir()); ok {
+		t.Error("expected a sub-run without a checkpoint not to count as completed")
+	}
+}
+
+func TestImageSubRuns(t *testing.T) {
+	tester := NewDefaultTester()
+	tester.Images = "cos-stable,ubuntu-2204"
+	crio := *tester
+	runs := tester.imageSubRuns([]subRun{{label: "containerd", tester: tester}, {label: "crio", tester: &crio}})
+
+	var labels, images []string
+	for _, r := range runs {
+		labels = append(labels, r.label)
+		images = append(images, r.tester.Images)
+	}
+	if expected := []string{"containerd/cos-stable", "containerd/ubuntu-2204", "crio/cos-stable", "crio/ubuntu-2204"}; !reflect.DeepEqual(labels, expected) {
+		t.Errorf("expected labels %v, but got %v", expected, labels)
+	}
+	if expected := []string{"cos-stable", "ubuntu-2204", "cos-stable", "ubuntu-2204"}; !reflect.DeepEqual(images, expected) {
+		t.Errorf("expected images %v, but got %v", expected, images)
+	}
+
+	tester.Images = "cos-stable"
+	if runs := tester.imageSubRuns(nil); len(runs) != 0 {
+		t.Errorf("expected a single image not to be split, but got %d sub-runs", len(runs))
+	}
+}
+
+func TestResumeWithImageConfig(t *testing.T) {
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.GCPZone = "us-central1-a"
+	tester.ImageConfigFile = "image-config.yaml"
+	tester.Resume = true
+	if err := tester.validateFlags(); err == nil || !strings.Contains(err.Error(), "--image-config-file") {
+		t.Errorf("expected --resume to be rejected with --image-config-file, but got %v", err)
+	}
+}
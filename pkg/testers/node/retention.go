@@ -0,0 +1,240 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// Instance retention depends on --delete-instances and the outcome of the run:
+//
+//	--delete-instances  --keep-instances-on-success  --keep-instances-on-failure  success  failure
+//	false               any                          any                          kept     kept
+//	true                false                        false                        deleted  deleted
+//	true                true                         false                        kept     deleted
+//	true                false                        true                         deleted  kept
+//
+// Setting both keep flags keeps the instances regardless of the outcome, the
+// same as --delete-instances=false. With --preserve-instance-for, the instances
+// that ran a failed spec matching it are also kept. When the retention depends
+// on the outcome, the test process is told not to delete the instances and the
+// tester deletes them once the outcome is known. The tester also deletes the
+// instances it created itself, see createsInstances, and those it waits
+// --cleanup-grace-period, --post-failure-ssh-hold or --pause-before-teardown
+// for before deleting. The tester can only delete gce instances, so on the
+// other providers the flags that need it are rejected with --delete-instances.
+
+// conditionalRetention reports whether the tester rather than the test process
+// deletes the instances, because keeping them depends on the outcome of the run
+// or because the tester created them, throttles their deletion or collects
+// from them after the run. Only gce instances can be deleted by the tester.
+func (t *Tester) conditionalRetention() bool {
+	return t.DeleteInstances && t.Provider == "gce" && (t.KeepInstancesOnSuccess || t.KeepInstancesOnFailure || t.preserveInstanceFor != nil || t.createsInstances() || t.CollectEvents || t.CollectKubeletPprof || t.MaxParallelInstanceDeletion > 0 || t.CleanupGracePeriod > 0 || t.PostFailureSSHHold > 0 || t.pausesBeforeTeardown())
+}
+
+// validateRetention rejects the flags that need the tester to delete the
+// instances on the providers it cannot delete them on, which would leak them
+func (t *Tester) validateRetention() error {
+	if !t.DeleteInstances || t.Provider == "gce" {
+		return nil
+	}
+	flags := []struct {
+		name string
+		set  bool
+	}{
+		{name: "keep-instances-on-success", set: t.KeepInstancesOnSuccess},
+		{name: "keep-instances-on-failure", set: t.KeepInstancesOnFailure},
+		{name: "preserve-instance-for", set: t.PreserveInstanceFor != ""},
+		{name: "collect-events", set: t.CollectEvents},
+		{name: "collect-kubelet-pprof", set: t.CollectKubeletPprof},
+		{name: "cleanup-grace-period", set: t.CleanupGracePeriod > 0},
+		{name: "post-failure-ssh-hold", set: t.PostFailureSSHHold > 0},
+		{name: "pause-before-teardown", set: t.PauseBeforeTeardown},
+	}
+	for _, flag := range flags {
+		if flag.set {
+			return fmt.Errorf("--%s with --delete-instances is only supported with the gce provider, the tester cannot delete %s instances", flag.name, t.Provider)
+		}
+	}
+	return nil
+}
+
+// deleteInstancesDuringRun reports whether the test process deletes the instances itself
+func (t *Tester) deleteInstancesDuringRun() bool {
+	return t.DeleteInstances && !t.conditionalRetention()
+}
+
+// keepInstances reports whether the instances of a run with the outcome runErr are kept
+func (t *Tester) keepInstances(runErr error) bool {
+	if !t.DeleteInstances {
+		return true
+	}
+	if runErr == nil {
+		return t.KeepInstancesOnSuccess
+	}
+	return t.KeepInstancesOnFailure
+}
+
+// cleanupInstances deletes the instances created by a run with the outcome
+// runErr when their retention depends on the outcome and they are not kept,
+// it returns the instances that were deliberately kept
+func (t *Tester) cleanupInstances(artifactsDir string, runErr error, output *runOutput) []string {
+	if !t.conditionalRetention() {
+		return nil
+	}
+	instances := undeletedInstances(output.lifecycle)
+	if len(instances) == 0 {
+		return nil
+	}
+	if t.keepInstances(runErr) {
+		klog.V(0).Infof("keeping instances %v", instances)
+		return instances
+	}
+
+	preserved := t.instancesToPreserve(artifactsDir, instances)
+	preservedSet := map[string]bool{}
+	for _, instance := range preserved {
+		preservedSet[instance] = true
+		klog.V(0).Infof("preserving instance %s for debugging, access it with: %s", instance, t.sshAccessInfo(instance))
+	}
+	var deleting []string
+	for _, instance := range instances {
+		if !preservedSet[instance] {
+			deleting = append(deleting, instance)
+		}
+	}
+	if len(deleting) == 0 {
+		return preserved
+	}
+
+	if runErr != nil {
+		t.holdForSSH(os.Stdout, deleting)
+	}
+	t.waitCleanupGracePeriod(deleting)
+	zones, byZone := t.groupByZone(deleting)
+	deleteInstances(t.instanceReaper(output), zones, byZone, t.instanceDeletionLimit())
+	output.flush()
+	return preserved
+}
+
+// instancesToPreserve returns the instances that ran a failed spec matching
+// --preserve-instance-for. The junit files are named after the instance they
+// ran on, when a junit file cannot be matched to an instance all are preserved.
+func (t *Tester) instancesToPreserve(artifactsDir string, instances []string) []string {
+	if t.preserveInstanceFor == nil {
+		return nil
+	}
+	results, err := t.results(artifactsDir)
+	if err != nil {
+		klog.Warningf("failed to parse test results, preserving all instances: %v", err)
+		return instances
+	}
+	preserve := map[string]bool{}
+	for _, spec := range results.Specs {
+		if spec.Status != SpecFailed || !t.preserveInstanceFor.MatchString(spec.Name) {
+			continue
+		}
+		junitName := filepath.Base(spec.File)
+		matched := false
+		for _, instance := range instances {
+			if strings.Contains(junitName, instance) {
+				preserve[instance] = true
+				matched = true
+			}
+		}
+		if !matched {
+			klog.Warningf("cannot tell which instance ran %s, preserving all instances", spec.Name)
+			return instances
+		}
+	}
+	var preserved []string
+	for _, instance := range instances {
+		if preserve[instance] {
+			preserved = append(preserved, instance)
+		}
+	}
+	return preserved
+}
+
+// sshAccessInfo returns the command to ssh into a preserved instance
+func (t *Tester) sshAccessInfo(instance string) string {
+	return strings.Join(t.sshTransport().Command(instance), " ")
+}
+
+// maxCleanupGracePeriod bounds --cleanup-grace-period so a run cannot hang
+// waiting to delete its instances
+const maxCleanupGracePeriod = 30 * time.Minute
+
+// validateCleanupGracePeriod checks --cleanup-grace-period is within bounds
+func validateCleanupGracePeriod(period time.Duration) error {
+	if period < 0 || period > maxCleanupGracePeriod {
+		return fmt.Errorf("must be between 0 and %s", maxCleanupGracePeriod)
+	}
+	return nil
+}
+
+// waitCleanupGracePeriod waits --cleanup-grace-period before the instances
+// are deleted, so that in-flight log and metric collection can finish. A
+// cancelled run does not wait.
+func (t *Tester) waitCleanupGracePeriod(instances []string) {
+	if t.CleanupGracePeriod <= 0 {
+		return
+	}
+	klog.V(0).Infof("waiting %s before deleting instances %v", t.CleanupGracePeriod, instances)
+	select {
+	case <-t.clock.After(t.CleanupGracePeriod):
+	case <-t.context().Done():
+	}
+}
+
+// maxPostFailureSSHHold bounds --post-failure-ssh-hold so a failed run cannot
+// hold its instances indefinitely
+const maxPostFailureSSHHold = 2 * time.Hour
+
+// validatePostFailureSSHHold checks --post-failure-ssh-hold is within bounds
+func validatePostFailureSSHHold(hold time.Duration) error {
+	if hold < 0 || hold > maxPostFailureSSHHold {
+		return fmt.Errorf("must be between 0 and %s", maxPostFailureSSHHold)
+	}
+	return nil
+}
+
+// holdForSSH keeps the instances of a failed run for --post-failure-ssh-hold
+// before they are deleted, printing the commands to ssh into them to w. A
+// cancelled run stops holding them.
+func (t *Tester) holdForSSH(w io.Writer, instances []string) {
+	if t.PostFailureSSHHold <= 0 {
+		return
+	}
+	deadline := t.clock.Now().Add(t.PostFailureSSHHold)
+	fmt.Fprintf(w, "the run failed, keeping instances %v reachable until %s before deleting them\n", instances, deadline.Format(time.RFC3339))
+	for _, instance := range instances {
+		fmt.Fprintf(w, "  %s: %s\n", instance, t.sshAccessInfo(instance))
+	}
+	select {
+	case <-t.clock.After(t.PostFailureSSHHold):
+	case <-t.context().Done():
+		klog.V(0).Infof("run cancelled, no longer holding instances %v", instances)
+	}
+}
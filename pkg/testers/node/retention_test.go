@@ -0,0 +1,474 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestInstanceRetention(t *testing.T) {
+	testCases := []struct {
+		name            string
+		keepOnSuccess   bool
+		keepOnFailure   bool
+		runErr          error
+		expectedDeleted []string
+	}{
+		{
+			name:          "keep on success, run succeeds",
+			keepOnSuccess: true,
+		},
+		{
+			name:            "keep on success, run fails",
+			keepOnSuccess:   true,
+			runErr:          errors.New("specs failed"),
+			expectedDeleted: []string{"tmp-node-e2e-ubuntu-1234"},
+		},
+		{
+			name:            "keep on failure, run succeeds",
+			keepOnFailure:   true,
+			expectedDeleted: []string{"tmp-node-e2e-ubuntu-1234"},
+		},
+		{
+			name:          "keep on failure, run fails",
+			keepOnFailure: true,
+			runErr:        errors.New("specs failed"),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+				if cmd.name == "gcloud" {
+					for _, instance := range cmd.args[6:] {
+						fmt.Fprintf(cmd.stderr, "Deleted [https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/%s].\n", instance)
+					}
+					return nil
+				}
+				_, _ = io.WriteString(cmd.stderr, lifecycleOutput)
+				return tc.runErr
+			}}
+			tester := NewDefaultTester()
+			tester.GCPProject = "p"
+			tester.GCPZone = "us-central1-a"
+			tester.KeepInstancesOnSuccess = tc.keepOnSuccess
+			tester.KeepInstancesOnFailure = tc.keepOnFailure
+			tester.clock = newFakeClock(time.Minute)
+			tester.cmder = cmder
+
+			if err := tester.runOnce(dir); !errors.Is(err, tc.runErr) {
+				t.Fatalf("expected error %v, but got %v", tc.runErr, err)
+			}
+
+			if actual := argValue(t, cmder.cmds[0].args, "DELETE_INSTANCES"); actual != "false" {
+				t.Errorf("expected the test process not to delete the instances, but got DELETE_INSTANCES=%s", actual)
+			}
+			var deleted []string
+			for _, cmd := range cmder.cmds[1:] {
+				if cmd.name == "gcloud" && cmd.args[2] == "list" {
+					continue
+				}
+				expectedArgs := []string{"compute", "instances", "delete", "--quiet", "--project=p", "--zone=us-central1-a"}
+				if cmd.name != "gcloud" || !reflect.DeepEqual(cmd.args[:6], expectedArgs) {
+					t.Fatalf("unexpected command %s %v", cmd.name, cmd.args)
+				}
+				deleted = append(deleted, cmd.args[6:]...)
+			}
+			if !reflect.DeepEqual(deleted, tc.expectedDeleted) {
+				t.Errorf("expected deleted instances %v, but got %v", tc.expectedDeleted, deleted)
+			}
+		})
+	}
+}
+
+func TestDeleteInstancesDuringRun(t *testing.T) {
+	testCases := []struct {
+		deleteInstances bool
+		keepOnSuccess   bool
+		keepOnFailure   bool
+		expected        string
+	}{
+		{deleteInstances: true, expected: "true"},
+		{deleteInstances: false, expected: "false"},
+		{deleteInstances: false, keepOnSuccess: true, expected: "false"},
+		{deleteInstances: true, keepOnSuccess: true, expected: "false"},
+		{deleteInstances: true, keepOnFailure: true, expected: "false"},
+	}
+
+	for _, tc := range testCases {
+		tester := NewDefaultTester()
+		tester.DeleteInstances = tc.deleteInstances
+		tester.KeepInstancesOnSuccess = tc.keepOnSuccess
+		tester.KeepInstancesOnFailure = tc.keepOnFailure
+		if actual := argValue(t, tester.constructArgs(), "DELETE_INSTANCES"); actual != tc.expected {
+			t.Errorf("expected DELETE_INSTANCES=%s for %+v, but got %s", tc.expected, tc, actual)
+		}
+	}
+}
+
+func TestRetentionRequiresGCE(t *testing.T) {
+	testCases := []struct {
+		name            string
+		deleteInstances bool
+		configure       func(*Tester)
+		expectErr       bool
+	}{
+		{
+			name:            "keep on success",
+			deleteInstances: true,
+			configure:       func(t *Tester) { t.KeepInstancesOnSuccess = true },
+			expectErr:       true,
+		},
+		{
+			name:            "preserve instance for",
+			deleteInstances: true,
+			configure:       func(t *Tester) { t.PreserveInstanceFor = "Pods" },
+			expectErr:       true,
+		},
+		{
+			name:            "collect events",
+			deleteInstances: true,
+			configure:       func(t *Tester) { t.CollectEvents = true },
+			expectErr:       true,
+		},
+		{
+			name:            "cleanup grace period",
+			deleteInstances: true,
+			configure:       func(t *Tester) { t.CleanupGracePeriod = time.Minute },
+			expectErr:       true,
+		},
+		{
+			name:      "instances are kept anyway",
+			configure: func(t *Tester) { t.KeepInstancesOnSuccess = true },
+		},
+		{
+			name:            "test process deletes the instances",
+			deleteInstances: true,
+			configure:       func(t *Tester) {},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.Provider = "ec2"
+			tester.InstanceType = "m5.large"
+			tester.UserDataFile = "user-data.sh"
+			tester.DeleteInstances = tc.deleteInstances
+			tc.configure(tester)
+			err := tester.validateFlags()
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected the flag to be rejected with --delete-instances on ec2")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			// the tester cannot delete ec2 instances, the test process must
+			if expected := strconv.FormatBool(tc.deleteInstances); argValue(t, tester.constructArgs(), "DELETE_INSTANCES") != expected {
+				t.Errorf("expected DELETE_INSTANCES=%s on ec2", expected)
+			}
+		})
+	}
+}
+
+func TestPreserveInstanceFor(t *testing.T) {
+	const createdOutput = `Created [https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/tmp-node-e2e-cos-1234].
+Created [https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/tmp-node-e2e-ubuntu-1234].
+`
+	testCases := []struct {
+		name              string
+		junit             map[string]string
+		runErr            error
+		expectedPreserved []string
+		expectedDeleted   []string
+	}{
+		{
+			name: "matching failure preserves its instance",
+			junit: map[string]string{
+				"junit_tmp-node-e2e-cos-1234_01.xml":    `<testsuite><testcase name="[It] [sig-node] Pods should start"/></testsuite>`,
+				"junit_tmp-node-e2e-ubuntu-1234_01.xml": `<testsuite><testcase name="[It] [sig-node] Pods should start"><failure message="failed"/></testcase></testsuite>`,
+			},
+			runErr:            errors.New("specs failed"),
+			expectedPreserved: []string{"tmp-node-e2e-ubuntu-1234"},
+			expectedDeleted:   []string{"tmp-node-e2e-cos-1234"},
+		},
+		{
+			name: "other failures do not preserve instances",
+			junit: map[string]string{
+				"junit_tmp-node-e2e-cos-1234_01.xml": `<testsuite><testcase name="[It] [sig-node] Probes should restart"><failure message="failed"/></testcase></testsuite>`,
+			},
+			runErr:          errors.New("specs failed"),
+			expectedDeleted: []string{"tmp-node-e2e-cos-1234", "tmp-node-e2e-ubuntu-1234"},
+		},
+		{
+			name: "failure that cannot be matched to an instance preserves all",
+			junit: map[string]string{
+				"junit_01.xml": `<testsuite><testcase name="[It] [sig-node] Pods should start"><failure message="failed"/></testcase></testsuite>`,
+			},
+			runErr:            errors.New("specs failed"),
+			expectedPreserved: []string{"tmp-node-e2e-cos-1234", "tmp-node-e2e-ubuntu-1234"},
+		},
+		{
+			name: "passing run deletes all",
+			junit: map[string]string{
+				"junit_tmp-node-e2e-cos-1234_01.xml": `<testsuite><testcase name="[It] [sig-node] Pods should start"/></testsuite>`,
+			},
+			expectedDeleted: []string{"tmp-node-e2e-cos-1234", "tmp-node-e2e-ubuntu-1234"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			cmder := &fakeCmder{}
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.GCPProject = "p"
+			tester.GCPZone = "us-central1-a"
+			tester.PreserveInstanceFor = `Pods should start`
+			tester.clock = newFakeClock(time.Minute)
+			tester.cmder = cmder
+			if err := tester.validateFlags(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := argValue(t, tester.constructArgs(), "DELETE_INSTANCES"); actual != "false" {
+				t.Errorf("expected the tester to delete the instances, but got DELETE_INSTANCES=%s", actual)
+			}
+
+			output := &runOutput{now: tester.clock.Now}
+			_, _ = io.WriteString(output.watch(io.Discard), createdOutput)
+			for name, contents := range tc.junit {
+				writeArtifact(t, dir, name, contents)
+			}
+			preserved := tester.cleanupInstances(dir, tc.runErr, output)
+			if !reflect.DeepEqual(preserved, tc.expectedPreserved) {
+				t.Errorf("expected preserved instances %v, but got %v", tc.expectedPreserved, preserved)
+			}
+			var deleted []string
+			for _, cmd := range cmder.cmds {
+				deleted = append(deleted, cmd.args[6:]...)
+			}
+			if !reflect.DeepEqual(deleted, tc.expectedDeleted) {
+				t.Errorf("expected deleted instances %v, but got %v", tc.expectedDeleted, deleted)
+			}
+		})
+	}
+}
+
+func TestSSHAccessInfo(t *testing.T) {
+	testCases := []struct {
+		provider   string
+		privateKey string
+		expected   string
+	}{
+		{
+			provider: "gce",
+			expected: "gcloud compute ssh --project=p --zone=us-central1-a prow@tmp-node-e2e-cos-1234",
+		},
+		{
+			provider:   "ec2",
+			privateKey: "/root/.ssh/id_rsa",
+			expected:   "ssh -i /root/.ssh/id_rsa prow@tmp-node-e2e-cos-1234",
+		},
+	}
+
+	for _, tc := range testCases {
+		tester := NewDefaultTester()
+		tester.Provider = tc.provider
+		tester.GCPProject = "p"
+		tester.GCPZone = "us-central1-a"
+		tester.sshUser = "prow"
+		tester.privateKey = tc.privateKey
+		if actual := tester.sshAccessInfo("tmp-node-e2e-cos-1234"); actual != tc.expected {
+			t.Errorf("expected access info %q, but got %q", tc.expected, actual)
+		}
+	}
+}
+
+func TestCleanupGracePeriod(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		if cmd.name == "gcloud" {
+			mu.Lock()
+			deleted = append(deleted, cmd.args[6:]...)
+			mu.Unlock()
+		}
+		return nil
+	}}
+	clock := newFakeClock(0)
+	tester := NewDefaultTester()
+	tester.GCPProject = "p"
+	tester.GCPZone = "us-central1-a"
+	tester.CleanupGracePeriod = 5 * time.Minute
+	tester.clock = clock
+	tester.cmder = cmder
+	output := &runOutput{now: clock.Now}
+	output.observe("Created [https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/tmp-node-e2e-cos-1234].")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		tester.cleanupInstances(t.TempDir(), nil, output)
+	}()
+	waitFor(t, clock.HasWaiters)
+	clock.Advance(4 * time.Minute)
+	mu.Lock()
+	if len(deleted) != 0 {
+		t.Errorf("expected no instances to be deleted before the grace period, but got %v", deleted)
+	}
+	mu.Unlock()
+	clock.Advance(time.Minute)
+	<-done
+
+	if expected := []string{"tmp-node-e2e-cos-1234"}; !reflect.DeepEqual(deleted, expected) {
+		t.Errorf("expected deleted instances %v after the grace period, but got %v", expected, deleted)
+	}
+}
+
+func TestCleanupGracePeriodBounds(t *testing.T) {
+	for _, period := range []time.Duration{-time.Second, maxCleanupGracePeriod + time.Second} {
+		tester := NewDefaultTester()
+		tester.RepoRoot = fakeRepoRoot(t)
+		tester.GCPZone = "us-central1-a"
+		tester.CleanupGracePeriod = period
+		if err := tester.validateFlags(); err == nil {
+			t.Errorf("expected --cleanup-grace-period=%s to be rejected", period)
+		}
+	}
+}
+
+func TestPostFailureSSHHold(t *testing.T) {
+	testCases := []struct {
+		name     string
+		runErr   error
+		expected []string
+	}{
+		{
+			name:     "failed run holds the instances",
+			runErr:   errors.New("tests failed"),
+			expected: []string{"tmp-node-e2e-cos-1234"},
+		},
+		{
+			name:     "successful run deletes the instances right away",
+			expected: []string{"tmp-node-e2e-cos-1234"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var deleted []string
+			cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+				if cmd.name == "gcloud" {
+					mu.Lock()
+					deleted = append(deleted, cmd.args[6:]...)
+					mu.Unlock()
+				}
+				return nil
+			}}
+			clock := newFakeClock(0)
+			tester := NewDefaultTester()
+			tester.GCPProject = "p"
+			tester.GCPZone = "us-central1-a"
+			tester.PostFailureSSHHold = 30 * time.Minute
+			tester.clock = clock
+			tester.cmder = cmder
+			output := &runOutput{now: clock.Now}
+			output.observe("Created [https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/tmp-node-e2e-cos-1234].")
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				tester.cleanupInstances(t.TempDir(), tc.runErr, output)
+			}()
+			if tc.runErr != nil {
+				waitFor(t, clock.HasWaiters)
+				clock.Advance(29 * time.Minute)
+				mu.Lock()
+				if len(deleted) != 0 {
+					t.Errorf("expected no instances to be deleted during the hold, but got %v", deleted)
+				}
+				mu.Unlock()
+				clock.Advance(time.Minute)
+			}
+			<-done
+
+			if !reflect.DeepEqual(deleted, tc.expected) {
+				t.Errorf("expected deleted instances %v, but got %v", tc.expected, deleted)
+			}
+		})
+	}
+}
+
+func TestHoldForSSH(t *testing.T) {
+	clock := newFakeClock(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	tester := NewDefaultTester()
+	tester.GCPProject = "p"
+	tester.GCPZone = "us-central1-a"
+	tester.sshUser = "prow"
+	tester.PostFailureSSHHold = time.Hour
+	tester.clock = clock
+	tester.ctx = ctx
+
+	var out bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		tester.holdForSSH(&out, []string{"tmp-node-e2e-cos-1234"})
+	}()
+	waitFor(t, clock.HasWaiters)
+	cancel()
+	<-done
+
+	expected := "the run failed, keeping instances [tmp-node-e2e-cos-1234] reachable until 2026-01-02T04:04:05Z before deleting them\n" +
+		"  tmp-node-e2e-cos-1234: gcloud compute ssh --project=p --zone=us-central1-a prow@tmp-node-e2e-cos-1234\n"
+	if out.String() != expected {
+		t.Errorf("expected access info %q, but got %q", expected, out.String())
+	}
+}
+
+func TestPostFailureSSHHoldBounds(t *testing.T) {
+	for _, hold := range []time.Duration{-time.Second, maxPostFailureSSHHold + time.Second} {
+		tester := NewDefaultTester()
+		tester.RepoRoot = fakeRepoRoot(t)
+		tester.GCPZone = "us-central1-a"
+		tester.PostFailureSSHHold = hold
+		if err := tester.validateFlags(); err == nil {
+			t.Errorf("expected --post-failure-ssh-hold=%s to be rejected", hold)
+		}
+	}
+}
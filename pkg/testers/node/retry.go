@@ -0,0 +1,53 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// specFocusRegex returns a focus regex matching exactly the given specs,
+// the junit names are prefixed by the ginkgo node type which is not part
+// of the text ginkgo focuses on
+func specFocusRegex(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, regexp.QuoteMeta(strings.TrimPrefix(name, "[It] ")))
+	}
+	return strings.Join(quoted, "|")
+}
+
+// results parses the results of the run in artifactsDir with the parser of --result-format
+func (t *Tester) results(artifactsDir string) (*Summary, error) {
+	parser, ok := resultParsers[t.ResultFormat]
+	if !ok {
+		return nil, fmt.Errorf("unknown result format %q", t.ResultFormat)
+	}
+	return parser.Parse(artifactsDir)
+}
+
+// flakeAttempts returns the attempts ginkgo makes at each spec, the retries
+// of --flake-attempts are capped by MaxRetriesPerSpec so that a spec that
+// keeps failing is a hard failure once it reaches the cap
+func (t *Tester) flakeAttempts() int {
+	if t.MaxRetriesPerSpec > 0 && t.FlakeAttempts > t.MaxRetriesPerSpec+1 {
+		return t.MaxRetriesPerSpec + 1
+	}
+	return t.FlakeAttempts
+}
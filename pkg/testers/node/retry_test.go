@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMaxRetriesPerSpec(t *testing.T) {
+	testCases := []struct {
+		name              string
+		maxRetriesPerSpec int
+		flakeAttempts     int
+		// failures is how many attempts of each spec fail before it passes, -1 never passes
+		failures         map[string]int
+		expectedAttempts int
+		expectedRuns     map[string]int
+		expectErr        bool
+	}{
+		{
+			name:              "persistently failing spec hits the cap",
+			maxRetriesPerSpec: 2,
+			flakeAttempts:     5,
+			failures:          map[string]int{"[It] broken": -1},
+			expectedAttempts:  3,
+			expectedRuns:      map[string]int{"[It] broken": 3, "[It] flaky": 1, "[It] passing": 1},
+			expectErr:         true,
+		},
+		{
+			name:              "flaky spec passes before the cap",
+			maxRetriesPerSpec: 2,
+			flakeAttempts:     5,
+			failures:          map[string]int{"[It] flaky": 2},
+			expectedAttempts:  3,
+			expectedRuns:      map[string]int{"[It] broken": 1, "[It] flaky": 3, "[It] passing": 1},
+		},
+		{
+			name:              "flaky spec failing more often than the cap is a hard failure",
+			maxRetriesPerSpec: 1,
+			flakeAttempts:     5,
+			failures:          map[string]int{"[It] flaky": 2},
+			expectedAttempts:  2,
+			expectedRuns:      map[string]int{"[It] broken": 1, "[It] flaky": 2, "[It] passing": 1},
+			expectErr:         true,
+		},
+		{
+			name:              "cap above the flake attempts",
+			maxRetriesPerSpec: 5,
+			flakeAttempts:     2,
+			failures:          map[string]int{"[It] broken": -1},
+			expectedAttempts:  2,
+			expectedRuns:      map[string]int{"[It] broken": 2, "[It] flaky": 1, "[It] passing": 1},
+			expectErr:         true,
+		},
+		{
+			name:             "flake attempts apply without a cap",
+			flakeAttempts:    4,
+			failures:         map[string]int{"[It] broken": -1},
+			expectedAttempts: 4,
+			expectedRuns:     map[string]int{"[It] broken": 4, "[It] flaky": 1, "[It] passing": 1},
+			expectErr:        true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			runs := map[string]int{}
+			var attempts []string
+			cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+				// ginkgo attempts each failing spec up to FLAKE_ATTEMPTS times
+				flakeAttempts, err := strconv.Atoi(argValue(t, cmd.args, "FLAKE_ATTEMPTS"))
+				if err != nil {
+					t.Fatalf("invalid FLAKE_ATTEMPTS: %v", err)
+				}
+				attempts = append(attempts, argValue(t, cmd.args, "FLAKE_ATTEMPTS"))
+				var cases []string
+				failed := false
+				for _, name := range []string{"[It] broken", "[It] flaky", "[It] passing"} {
+					passed := false
+					for attempt := 1; attempt <= flakeAttempts && !passed; attempt++ {
+						runs[name]++
+						limit, ok := tc.failures[name]
+						passed = !ok || (limit >= 0 && attempt > limit)
+					}
+					if passed {
+						cases = append(cases, fmt.Sprintf(`<testcase name=%q/>`, name))
+					} else {
+						failed = true
+						cases = append(cases, fmt.Sprintf(`<testcase name=%q><failure message="failed"/></testcase>`, name))
+					}
+				}
+				writeArtifact(t, argValue(t, cmd.env, "ARTIFACTS"), "junit_01.xml", "<testsuite>"+strings.Join(cases, "")+"</testsuite>")
+				if failed {
+					return errors.New("specs failed")
+				}
+				return nil
+			}}
+			tester := NewDefaultTester()
+			tester.MaxRetriesPerSpec = tc.maxRetriesPerSpec
+			tester.FlakeAttempts = tc.flakeAttempts
+			tester.cmder = cmder
+
+			err := tester.run(dir)
+			if tc.expectErr != (err != nil) {
+				t.Fatalf("expected error %v, but got %v", tc.expectErr, err)
+			}
+			if expected := []string{strconv.Itoa(tc.expectedAttempts)}; !reflect.DeepEqual(attempts, expected) {
+				t.Errorf("expected a single run with FLAKE_ATTEMPTS=%v, but got %v", expected, attempts)
+			}
+			if expected := "--ginkgo.flake-attempts=" + strconv.Itoa(tc.expectedAttempts); tc.expectedAttempts > 1 && !strings.Contains(tester.testArgs(), expected) {
+				t.Errorf("expected %s in the test args, but got %q", expected, tester.testArgs())
+			}
+			for name, expected := range tc.expectedRuns {
+				if runs[name] != expected {
+					t.Errorf("expected %s to be attempted %d times, but got %d", name, expected, runs[name])
+				}
+			}
+		})
+	}
+}
+
+func TestSpecFocusRegex(t *testing.T) {
+	focus := specFocusRegex([]string{"[It] [sig-node] Pods should run (slow) [NodeConformance]"})
+	if !regexp.MustCompile(focus).MatchString("[sig-node] Pods should run (slow) [NodeConformance]") {
+		t.Errorf("expected %q to match the spec text", focus)
+	}
+}
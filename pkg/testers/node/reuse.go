@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"regexp"
+
+	"k8s.io/klog/v2"
+)
+
+// defaultReuseInstanceNamePrefix names the instances reused across runs when
+// --instance-name-prefix is not set
+const defaultReuseInstanceNamePrefix = "tmp-node-e2e-reuse"
+
+// instanceNamePrefixRegex matches the prefixes that keep the instance names
+// valid for gce, leaving room for the image name
+var instanceNamePrefixRegex = regexp.MustCompile(`^[a-z][-a-z0-9]{0,29}$`)
+
+// validateReuseInstances checks --reuse-instances against the flags deleting
+// the instances, and keeps the instances of the run for the next one to reuse
+func (t *Tester) validateReuseInstances() error {
+	if t.InstanceNamePrefix != "" && !instanceNamePrefixRegex.MatchString(t.InstanceNamePrefix) {
+		return fmt.Errorf("invalid --instance-name-prefix %q, must start with a lowercase letter followed by at most 29 lowercase letters, digits or dashes", t.InstanceNamePrefix)
+	}
+	if !t.ReuseInstances {
+		return nil
+	}
+	deletionFlags := []struct {
+		name string
+		set  bool
+	}{
+		{name: "keep-instances-on-success", set: t.KeepInstancesOnSuccess},
+		{name: "keep-instances-on-failure", set: t.KeepInstancesOnFailure},
+		{name: "preserve-instance-for", set: t.PreserveInstanceFor != ""},
+		{name: "cleanup-grace-period", set: t.CleanupGracePeriod > 0},
+		{name: "post-failure-ssh-hold", set: t.PostFailureSSHHold > 0},
+	}
+	for _, flag := range deletionFlags {
+		if flag.set {
+			return fmt.Errorf("--%s cannot be used with --reuse-instances, which never deletes the instances", flag.name)
+		}
+	}
+	if t.DeleteInstances {
+		klog.V(1).Info("--reuse-instances keeps the instances for the next run, not deleting them")
+		t.DeleteInstances = false
+	}
+	if t.InstanceNamePrefix == "" {
+		t.InstanceNamePrefix = defaultReuseInstanceNamePrefix
+	}
+	return nil
+}
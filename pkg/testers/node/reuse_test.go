@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReuseInstances(t *testing.T) {
+	testCases := []struct {
+		name           string
+		reuse          bool
+		prefix         string
+		keepOnFailure  bool
+		gracePeriod    time.Duration
+		expectedPrefix string
+		expectedDelete string
+		expectedErr    string
+	}{
+		{
+			name:           "reuse with the default prefix",
+			reuse:          true,
+			expectedPrefix: "tmp-node-e2e-reuse",
+			expectedDelete: "false",
+		},
+		{
+			name:           "reuse with a prefix",
+			reuse:          true,
+			prefix:         "alice-cgroups",
+			expectedPrefix: "alice-cgroups",
+			expectedDelete: "false",
+		},
+		{
+			name:           "prefix without reuse",
+			prefix:         "alice-cgroups",
+			expectedPrefix: "alice-cgroups",
+			expectedDelete: "true",
+		},
+		{
+			name:           "no prefix",
+			expectedDelete: "true",
+		},
+		{
+			name:          "reuse contradicts keeping on failure",
+			reuse:         true,
+			keepOnFailure: true,
+			expectedErr:   "--keep-instances-on-failure cannot be used with --reuse-instances",
+		},
+		{
+			name:        "reuse contradicts the cleanup grace period",
+			reuse:       true,
+			gracePeriod: time.Minute,
+			expectedErr: "--cleanup-grace-period cannot be used with --reuse-instances",
+		},
+		{
+			name:        "invalid prefix",
+			reuse:       true,
+			prefix:      "Alice_Cgroups",
+			expectedErr: "invalid --instance-name-prefix",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.GCPZone = "us-central1-a"
+			tester.ReuseInstances = tc.reuse
+			tester.InstanceNamePrefix = tc.prefix
+			tester.KeepInstancesOnFailure = tc.keepOnFailure
+			tester.CleanupGracePeriod = tc.gracePeriod
+			err := tester.validateFlags()
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Errorf("expected an error containing %q, but got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			args := tester.constructArgs()
+			if actual := argValue(t, args, "DELETE_INSTANCES"); actual != tc.expectedDelete {
+				t.Errorf("expected DELETE_INSTANCES=%s, but got %s", tc.expectedDelete, actual)
+			}
+			if tc.expectedPrefix == "" {
+				for _, arg := range args {
+					if strings.HasPrefix(arg, "INSTANCE_PREFIX=") {
+						t.Errorf("expected no INSTANCE_PREFIX, but got %s", arg)
+					}
+				}
+				return
+			}
+			if actual := argValue(t, args, "INSTANCE_PREFIX"); actual != tc.expectedPrefix {
+				t.Errorf("expected INSTANCE_PREFIX=%s, but got %s", tc.expectedPrefix, actual)
+			}
+		})
+	}
+}
+
+func TestReuseExistingInstances(t *testing.T) {
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		name := cmd.args[3]
+		if strings.HasSuffix(name, "cos-stable") {
+			_, _ = fmt.Fprintf(cmd.stderr, "ERROR: (gcloud.compute.instances.create) Could not fetch resource:\n - The resource 'projects/p/zones/us-central1-a/instances/%s' already exists\n", name)
+			return errors.New("exit status 1")
+		}
+		_, _ = fmt.Fprintf(cmd.stderr, "Created [https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/%s].\n", name)
+		return nil
+	}}
+	tester := NewDefaultTester()
+	tester.GCPProject = "p"
+	tester.GCPZone = "us-central1-a"
+	tester.Images = "cos-stable,ubuntu-2204"
+	tester.ReuseInstances = true
+	tester.InstanceNamePrefix = "alice"
+	tester.runID = "8f14e45f-ceea-467f-a8b0-12c0a1b2c3d4"
+	tester.cmder = cmder
+
+	hosts, err := tester.createTestInstances(tester.context(), &runOutput{now: time.Now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"alice-cos-stable", "alice-ubuntu-2204"}
+	if !reflect.DeepEqual(hosts, expected) {
+		t.Errorf("expected the tests to run on %v, but got %v", expected, hosts)
+	}
+
+	tester.ReuseInstances = false
+	if _, err := tester.createTestInstances(tester.context(), &runOutput{now: time.Now}); err == nil {
+		t.Errorf("expected an existing instance to fail the creation without --reuse-instances")
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// runIDEnv exposes the run ID to the node e2e scripts
+	runIDEnv = "KUBETEST2_RUN_ID"
+	// runIDMetadataKey is the key the run ID is recorded under in metadata.json
+	runIDMetadataKey = "run-id"
+)
+
+// runIDLogFilter prefixes every log line with the run ID so that the
+// tester logs can be correlated with other systems
+type runIDLogFilter struct {
+	runID string
+}
+
+var _ klog.LogFilter = runIDLogFilter{}
+
+func (f runIDLogFilter) prefix() string {
+	return fmt.Sprintf("[run %s] ", f.runID)
+}
+
+func (f runIDLogFilter) Filter(args []interface{}) []interface{} {
+	return append([]interface{}{f.prefix()}, args...)
+}
+
+func (f runIDLogFilter) FilterF(format string, args []interface{}) (string, []interface{}) {
+	return f.prefix() + format, args
+}
+
+func (f runIDLogFilter) FilterS(msg string, keysAndValues []interface{}) (string, []interface{}) {
+	return msg, append(keysAndValues, "runID", f.runID)
+}
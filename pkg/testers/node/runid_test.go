@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// readMetadata reads the metadata.json written to dir
+func readMetadata(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, "metadata.json"))
+	if err != nil {
+		t.Fatalf("failed to read metadata: %v", err)
+	}
+	meta := map[string]string{}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		t.Fatalf("failed to parse metadata: %v", err)
+	}
+	return meta
+}
+
+func TestRunID(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ARTIFACTS", dir)
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		writeArtifact(t, dir, "junit_01.xml", `<testsuite><testcase name="[It] passes"/></testsuite>`)
+		return nil
+	}}
+	tester := NewDefaultTester()
+	tester.runID = "3f2b8c1e-0000-4000-8000-000000000000"
+	tester.cmder = cmder
+
+	if err := tester.writeMetadata(); err != nil {
+		t.Fatalf("unexpected error writing metadata: %v", err)
+	}
+	if err := tester.Test(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := tester.writeSummary(dir); err != nil {
+		t.Fatalf("unexpected error writing the summary: %v", err)
+	}
+
+	if actual := readMetadata(t, dir)[runIDMetadataKey]; actual != tester.runID {
+		t.Errorf("expected run ID %q in metadata, but got %q", tester.runID, actual)
+	}
+	if actual := readJSON(t, filepath.Join(dir, summaryFileName))["runID"]; actual != tester.runID {
+		t.Errorf("expected the run ID %q of metadata.json in %s, but got %v", tester.runID, summaryFileName, actual)
+	}
+	if actual := argValue(t, cmder.cmds[0].env, runIDEnv); actual != tester.runID {
+		t.Errorf("expected %s=%q in the command env, but got %q", runIDEnv, tester.runID, actual)
+	}
+
+	filter := runIDLogFilter{runID: tester.runID}
+	format, args := filter.FilterF("got project %s", []interface{}{"my-project"})
+	expected := fmt.Sprintf("[run %s] got project my-project", tester.runID)
+	if actual := fmt.Sprintf(format, args...); actual != expected {
+		t.Errorf("expected log line %q, but got %q", expected, actual)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// containerdEndpoint is the default socket of containerd
+const containerdEndpoint = "unix:///run/containerd/containerd.sock"
+
+// defaultRuntimeEndpoints are the container runtime endpoints of the default
+// images of each provider, used when --container-runtime-endpoint is unset.
+// They all run containerd with its default socket, including the Amazon Linux
+// and Ubuntu images of ec2.
+var defaultRuntimeEndpoints = map[string]string{
+	"gce":   containerdEndpoint,
+	"ec2":   containerdEndpoint,
+	"azure": containerdEndpoint,
+	"local": containerdEndpoint,
+}
+
+// runtimeEndpointSchemes are the schemes of the endpoints the kubelet connects
+// to its container runtime with, unix sockets or windows named pipes
+var runtimeEndpointSchemes = map[string]bool{
+	"unix":  true,
+	"npipe": true,
+}
+
+// runtimeEndpoint is a container runtime endpoint the suite is run against,
+// labeled by the runtime for its sub-run
+type runtimeEndpoint struct {
+	label    string
+	endpoint string
+}
+
+// runtimeLabel derives the runtime name from its endpoint,
+// e.g. unix:///run/containerd/containerd.sock is containerd
+func runtimeLabel(endpoint string) string {
+	p := endpoint
+	if _, rest, found := strings.Cut(endpoint, "://"); found {
+		p = rest
+	}
+	return strings.TrimSuffix(path.Base(p), ".sock")
+}
+
+// defaultRuntimeEndpoint returns the container runtime endpoint of the nodes
+// of the provider under test
+func (t *Tester) defaultRuntimeEndpoint() string {
+	if t.local() {
+		return defaultRuntimeEndpoints["local"]
+	}
+	return defaultRuntimeEndpoints[t.Provider]
+}
+
+// runtimeEndpointArgs returns the default endpoint of the provider as
+// CONTAINER_RUNTIME_ENDPOINT, which test-e2e-node.sh only uses when TEST_ARGS
+// has no --container-runtime-endpoint. It is left out when the images come
+// from an image config, as they may run another runtime such as CRI-O.
+func (t *Tester) runtimeEndpointArgs() []string {
+	if len(t.runtimeEndpoints) > 0 || strings.Contains(t.TestArgs, "--container-runtime-endpoint") {
+		return nil
+	}
+	if t.ImageConfigFile != "" || t.effectiveImageConfig != "" {
+		return nil
+	}
+	endpoint := t.defaultRuntimeEndpoint()
+	if endpoint == "" {
+		return nil
+	}
+	return []string{"CONTAINER_RUNTIME_ENDPOINT=" + endpoint}
+}
+
+// validateRuntimeEndpoint checks that endpoint is the URI of a unix socket
+// or a windows named pipe, e.g. unix:///run/containerd/containerd.sock
+func validateRuntimeEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint %q: %v", endpoint, err)
+	}
+	if !runtimeEndpointSchemes[u.Scheme] {
+		return fmt.Errorf("invalid endpoint %q, it must start with unix:// or npipe://", endpoint)
+	}
+	if u.Host != "" || !strings.HasPrefix(u.Path, "/") || u.RawQuery != "" {
+		return fmt.Errorf("invalid endpoint %q, it must be the absolute path of a socket, e.g. %s", endpoint, containerdEndpoint)
+	}
+	return nil
+}
+
+// parseRuntimeEndpoints parses a comma-separated list of container runtime
+// endpoints, each optionally prefixed by 'label=' to name its sub-run
+func parseRuntimeEndpoints(list string) ([]runtimeEndpoint, error) {
+	var endpoints []runtimeEndpoint
+	seen := map[string]bool{}
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		endpoint := runtimeEndpoint{endpoint: entry}
+		if label, rest, found := strings.Cut(entry, "="); found {
+			endpoint.label, endpoint.endpoint = label, rest
+		} else {
+			endpoint.label = runtimeLabel(entry)
+		}
+		if err := validateRuntimeEndpoint(endpoint.endpoint); err != nil {
+			return nil, err
+		}
+		if !subRunLabelRegex.MatchString(endpoint.label) {
+			return nil, fmt.Errorf("invalid runtime label %q for %s, prefix the endpoint with 'label='", endpoint.label, endpoint.endpoint)
+		}
+		if seen[endpoint.label] {
+			return nil, fmt.Errorf("duplicate runtime label %q, prefix the endpoints with 'label=' to tell them apart", endpoint.label)
+		}
+		seen[endpoint.label] = true
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints, nil
+}
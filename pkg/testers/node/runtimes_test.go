@@ -0,0 +1,275 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRuntimeEndpoints(t *testing.T) {
+	testCases := []struct {
+		name      string
+		list      string
+		expected  []runtimeEndpoint
+		expectErr bool
+	}{
+		{
+			name: "labels derived from the endpoints",
+			list: "unix:///run/containerd/containerd.sock,unix:///var/run/crio/crio.sock",
+			expected: []runtimeEndpoint{
+				{label: "containerd", endpoint: "unix:///run/containerd/containerd.sock"},
+				{label: "crio", endpoint: "unix:///var/run/crio/crio.sock"},
+			},
+		},
+		{
+			name: "explicit labels",
+			list: "stable=unix:///run/containerd/containerd.sock, canary=unix:///run/containerd-canary/containerd.sock",
+			expected: []runtimeEndpoint{
+				{label: "stable", endpoint: "unix:///run/containerd/containerd.sock"},
+				{label: "canary", endpoint: "unix:///run/containerd-canary/containerd.sock"},
+			},
+		},
+		{
+			name:      "duplicate labels",
+			list:      "unix:///run/containerd/containerd.sock,unix:///run/containerd-canary/containerd.sock",
+			expectErr: true,
+		},
+		{
+			name:      "invalid label",
+			list:      "bad/label=unix:///run/containerd/containerd.sock",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			endpoints, err := parseRuntimeEndpoints(tc.list)
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error for %q but got none", tc.list)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(endpoints, tc.expected) {
+				t.Errorf("expected endpoints %+v, but got %+v", tc.expected, endpoints)
+			}
+		})
+	}
+}
+
+func TestRuntimeSubRuns(t *testing.T) {
+	const (
+		containerd = "unix:///run/containerd/containerd.sock"
+		crio       = "unix:///var/run/crio/crio.sock"
+	)
+	testCases := []struct {
+		name     string
+		matrix   []featureGateCombination
+		expected map[string]string
+	}{
+		{
+			name: "one run per runtime",
+			expected: map[string]string{
+				"containerd": "--container-runtime-endpoint=" + containerd,
+				"crio":       "--container-runtime-endpoint=" + crio,
+			},
+		},
+		{
+			name:   "feature gate matrix per runtime",
+			matrix: []featureGateCombination{{label: "on", gates: "GateA=true"}, {label: "off", gates: "GateA=false"}},
+			expected: map[string]string{
+				"containerd/on":  "--feature-gates=GateA=true --container-runtime-endpoint=" + containerd,
+				"containerd/off": "--feature-gates=GateA=false --container-runtime-endpoint=" + containerd,
+				"crio/on":        "--feature-gates=GateA=true --container-runtime-endpoint=" + crio,
+				"crio/off":       "--feature-gates=GateA=false --container-runtime-endpoint=" + crio,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("ARTIFACTS", dir)
+			cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+				artifactsDir := argValue(t, cmd.env, "ARTIFACTS")
+				if strings.Contains(argValue(t, cmd.args, "TEST_ARGS"), crio) {
+					writeArtifact(t, artifactsDir, "junit_01.xml", `<testsuite><testcase name="a"><failure message="failed"/></testcase><testcase name="b"/></testsuite>`)
+					return errors.New("specs failed")
+				}
+				writeArtifact(t, artifactsDir, "junit_01.xml", `<testsuite><testcase name="a"/><testcase name="b"/></testsuite>`)
+				return nil
+			}}
+			tester := NewDefaultTester()
+			tester.runtimeEndpoints = []runtimeEndpoint{
+				{label: "containerd", endpoint: containerd},
+				{label: "crio", endpoint: crio},
+			}
+			tester.featureGateMatrix = tc.matrix
+			tester.cmder = cmder
+
+			if err := tester.Test(); err == nil {
+				t.Fatal("expected the crio sub-runs to fail the run")
+			}
+
+			actual := map[string]string{}
+			for _, cmd := range cmder.cmds {
+				label, err := filepath.Rel(dir, argValue(t, cmd.env, "ARTIFACTS"))
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				actual[label] = argValue(t, cmd.args, "TEST_ARGS")
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected sub-runs %v, but got %v", tc.expected, actual)
+			}
+
+			data, err := os.ReadFile(filepath.Join(dir, subRunResultsFileName))
+			if err != nil {
+				t.Fatalf("failed to read the sub-run results: %v", err)
+			}
+			var results []subRunResult
+			if err := json.Unmarshal(data, &results); err != nil {
+				t.Fatalf("failed to parse the sub-run results: %v", err)
+			}
+			if len(results) != len(tc.expected) {
+				t.Fatalf("expected %d sub-run results, but got %+v", len(tc.expected), results)
+			}
+			for _, result := range results {
+				failing := strings.HasPrefix(result.Label, "crio")
+				if failing != (result.Failed == 1 && result.Error != "") || result.Passed+result.Failed != 2 {
+					t.Errorf("unexpected result for sub-run %s: %+v", result.Label, result)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultRuntimeEndpoint(t *testing.T) {
+	testCases := []struct {
+		name             string
+		provider         string
+		endpoint         string
+		testArgs         string
+		imageConfig      string
+		expectErr        bool
+		expectedTestArgs string
+		expectedDefault  string
+	}{
+		{
+			name:            "gce default",
+			provider:        "gce",
+			expectedDefault: containerdEndpoint,
+		},
+		{
+			name:            "ec2 default",
+			provider:        "ec2",
+			expectedDefault: containerdEndpoint,
+		},
+		{
+			name:        "no default with an image config",
+			provider:    "gce",
+			imageConfig: "crio.yaml",
+		},
+		{
+			name:             "explicit endpoint wins",
+			provider:         "gce",
+			endpoint:         "unix:///var/run/crio/crio.sock",
+			expectedTestArgs: "--container-runtime-endpoint=unix:///var/run/crio/crio.sock",
+		},
+		{
+			name:             "endpoint in the test args wins",
+			provider:         "gce",
+			testArgs:         "--container-runtime-endpoint=unix:///var/run/crio/crio.sock",
+			expectedTestArgs: "--container-runtime-endpoint=unix:///var/run/crio/crio.sock",
+		},
+		{
+			name:             "windows named pipe",
+			provider:         "gce",
+			endpoint:         "npipe:////./pipe/containerd-containerd",
+			expectedTestArgs: "--container-runtime-endpoint=npipe:////./pipe/containerd-containerd",
+		},
+		{
+			name:      "missing scheme",
+			provider:  "gce",
+			endpoint:  "/run/containerd/containerd.sock",
+			expectErr: true,
+		},
+		{
+			name:      "tcp endpoint",
+			provider:  "gce",
+			endpoint:  "tcp://localhost:3735",
+			expectErr: true,
+		},
+		{
+			name:      "relative socket path",
+			provider:  "gce",
+			endpoint:  "unix://containerd.sock",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.Provider = tc.provider
+			tester.GCPZone = "us-central1-a"
+			if tc.provider == "ec2" {
+				tester.InstanceType = "m5.large"
+				tester.UserDataFile = "user-data.sh"
+			}
+			tester.ContainerRuntimeEndpoint = tc.endpoint
+			tester.TestArgs = tc.testArgs
+			tester.ImageConfigFile = tc.imageConfig
+			err := tester.validateFlags()
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected --container-runtime-endpoint=%q to be rejected", tc.endpoint)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			args := tester.constructArgs()
+			if actual := argValue(t, args, "TEST_ARGS"); actual != tc.expectedTestArgs {
+				t.Errorf("expected TEST_ARGS=%q, but got %q", tc.expectedTestArgs, actual)
+			}
+			var actualDefault string
+			for _, arg := range args {
+				if value, found := strings.CutPrefix(arg, "CONTAINER_RUNTIME_ENDPOINT="); found {
+					actualDefault = value
+				}
+			}
+			if actualDefault != tc.expectedDefault {
+				t.Errorf("expected CONTAINER_RUNTIME_ENDPOINT=%q, but got %q", tc.expectedDefault, actualDefault)
+			}
+		})
+	}
+}
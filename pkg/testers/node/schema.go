@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubetest2/pkg/testers"
+)
+
+// jsonSchemaDraft is the JSON Schema version of the printed config schema
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// configSchema is the JSON Schema of a file setting the tester flags, keyed
+// by flag name
+type configSchema struct {
+	Schema               string                    `json:"$schema"`
+	Title                string                    `json:"title"`
+	Type                 string                    `json:"type"`
+	Properties           map[string]schemaProperty `json:"properties"`
+	AdditionalProperties bool                      `json:"additionalProperties"`
+}
+
+// schemaProperty is the schema of a single flag
+type schemaProperty struct {
+	Type        string          `json:"type"`
+	Description string          `json:"description,omitempty"`
+	Items       *schemaProperty `json:"items,omitempty"`
+}
+
+// flagProperty returns the schema of a flag from its type, durations are
+// strings in golang duration format
+func flagProperty(f *pflag.Flag) (schemaProperty, error) {
+	property := schemaProperty{Description: f.Usage}
+	switch f.Value.Type() {
+	case "string", "duration":
+		property.Type = "string"
+	case "bool":
+		property.Type = "boolean"
+	case "int", "int32", "int64":
+		property.Type = "integer"
+	case "float32", "float64":
+		property.Type = "number"
+	case "stringSlice", "stringArray":
+		property.Type = "array"
+		property.Items = &schemaProperty{Type: "string"}
+	default:
+		return schemaProperty{}, fmt.Errorf("flag --%s has type %s which has no schema", f.Name, f.Value.Type())
+	}
+	return property, nil
+}
+
+// configSchema derives the schema of the tester flags from the fields of the
+// tester and their descriptions
+func (t *Tester) configSchema() (*configSchema, error) {
+	fs, err := testers.ParseFlags(t)
+	if err != nil {
+		return nil, err
+	}
+	schema := &configSchema{
+		Schema:     jsonSchemaDraft,
+		Title:      "kubetest2-tester-node",
+		Type:       "object",
+		Properties: map[string]schemaProperty{},
+	}
+	var errs []error
+	fs.VisitAll(func(f *pflag.Flag) {
+		property, err := flagProperty(f)
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		schema.Properties[f.Name] = property
+	})
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// printConfigSchema writes the JSON Schema of the tester flags to w
+func (t *Tester) printConfigSchema(w io.Writer) error {
+	schema, err := t.configSchema()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
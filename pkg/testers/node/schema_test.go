@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestPrintConfigSchema(t *testing.T) {
+	var out bytes.Buffer
+	if err := NewDefaultTester().printConfigSchema(&out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var schema configSchema
+	if err := json.Unmarshal(out.Bytes(), &schema); err != nil {
+		t.Fatalf("failed to parse the schema %s: %v", out.String(), err)
+	}
+	if schema.Type != "object" || schema.AdditionalProperties {
+		t.Errorf("expected a closed object schema, but got type %q with additional properties %v", schema.Type, schema.AdditionalProperties)
+	}
+
+	testCases := []struct {
+		flag     string
+		expected schemaProperty
+	}{
+		{
+			flag:     "gcp-zone",
+			expected: schemaProperty{Type: "string", Description: "GCP Zone to create VMs in."},
+		},
+		{
+			flag:     "parallelism",
+			expected: schemaProperty{Type: "integer", Description: "The number of parallel ginkgo processes on each test host, passed to test-e2e-node.sh as PARALLELISM."},
+		},
+		{
+			flag:     "use-dockerized-build",
+			expected: schemaProperty{Type: "boolean", Description: "Use dockerized build for test artifacts"},
+		},
+		{
+			flag: "timeout",
+			expected: schemaProperty{
+				Type:        "string",
+				Description: "How long (in golang duration format) to wait for ginkgo tests to complete. The test process is killed once it runs past it by --timeout-grace-period.",
+			},
+		},
+		{
+			flag: "gcp-zones",
+			expected: schemaProperty{
+				Type:        "array",
+				Description: "GCP zones to spread the VMs across, comma-separated. With more than one zone the tester creates the instances of --images itself, round-robin across the zones. --gcp-zone defaults to the first zone and is used when this is empty.",
+				Items:       &schemaProperty{Type: "string"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.flag, func(t *testing.T) {
+			property, ok := schema.Properties[tc.flag]
+			if !ok {
+				t.Fatalf("expected --%s in the schema", tc.flag)
+			}
+			if !reflect.DeepEqual(property, tc.expected) {
+				t.Errorf("expected --%s to have schema %+v, but got %+v", tc.flag, tc.expected, property)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// testShard is the 1-based index of a shard out of total shards
+type testShard struct {
+	index int
+	total int
+}
+
+func (s testShard) String() string {
+	return fmt.Sprintf("%d/%d", s.index, s.total)
+}
+
+// parseShard parses a shard in the <index>/<total> format, e.g. 2/4
+func parseShard(value string) (testShard, error) {
+	index, total, found := strings.Cut(value, "/")
+	if !found {
+		return testShard{}, fmt.Errorf("%q is not in the <index>/<total> format", value)
+	}
+	var shard testShard
+	var err error
+	if shard.index, err = strconv.Atoi(strings.TrimSpace(index)); err != nil {
+		return testShard{}, fmt.Errorf("invalid shard index %q", index)
+	}
+	if shard.total, err = strconv.Atoi(strings.TrimSpace(total)); err != nil {
+		return testShard{}, fmt.Errorf("invalid shard total %q", total)
+	}
+	if shard.total < 1 || shard.index < 1 || shard.index > shard.total {
+		return testShard{}, fmt.Errorf("shard %s must have an index between 1 and its total", value)
+	}
+	return shard, nil
+}
+
+// shardSpecs returns the sorted specs assigned to shard. Each spec is assigned
+// by a hash of its name, so a spec stays in the same shard when others are
+// added or removed, and the shards of a total are disjoint and cover every spec
+func shardSpecs(names []string, shard testShard) []string {
+	var assigned []string
+	for _, name := range names {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(name))
+		if int(h.Sum32()%uint32(shard.total)) == shard.index-1 {
+			assigned = append(assigned, name)
+		}
+	}
+	sort.Strings(assigned)
+	return assigned
+}
+
+// focusOnShard focuses the run on the specs assigned to the shard,
+// returning false if there are none
+func (t *Tester) focusOnShard(artifactsDir string) (bool, error) {
+	names, err := t.listSpecs(artifactsDir)
+	if err != nil {
+		return false, err
+	}
+	assigned := shardSpecs(names, t.shard)
+	klog.V(0).Infof("shard %s runs %d of %d specs", t.shard, len(assigned), len(names))
+	if len(assigned) == 0 {
+		return false, nil
+	}
+	t.FocusRegex = specFocusRegex(assigned)
+	return true, nil
+}
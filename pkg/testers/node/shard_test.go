@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestParseShard(t *testing.T) {
+	testCases := []struct {
+		value     string
+		expected  testShard
+		expectErr bool
+	}{
+		{value: "1/4", expected: testShard{index: 1, total: 4}},
+		{value: "4/4", expected: testShard{index: 4, total: 4}},
+		{value: "1/1", expected: testShard{index: 1, total: 1}},
+		{value: "0/4", expectErr: true},
+		{value: "5/4", expectErr: true},
+		{value: "1/0", expectErr: true},
+		{value: "4", expectErr: true},
+		{value: "a/4", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		actual, err := parseShard(tc.value)
+		if tc.expectErr {
+			if err == nil {
+				t.Errorf("expected an error for %q but got none", tc.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", tc.value, err)
+		}
+		if actual != tc.expected {
+			t.Errorf("expected %q to be parsed as %v, but got %v", tc.value, tc.expected, actual)
+		}
+	}
+}
+
+func TestShardSpecs(t *testing.T) {
+	var names []string
+	for i := 0; i < 50; i++ {
+		names = append(names, fmt.Sprintf("[It] [sig-node] spec %d", i))
+	}
+
+	for _, total := range []int{1, 2, 4, 7} {
+		seen := map[string]int{}
+		var union []string
+		for index := 1; index <= total; index++ {
+			shard := testShard{index: index, total: total}
+			assigned := shardSpecs(names, shard)
+			if !reflect.DeepEqual(assigned, shardSpecs(names, shard)) {
+				t.Errorf("expected shard %s to be deterministic", shard)
+			}
+			for _, name := range assigned {
+				seen[name]++
+			}
+			union = append(union, assigned...)
+		}
+		for name, count := range seen {
+			if count != 1 {
+				t.Errorf("expected %q to be in exactly one of %d shards, but it is in %d", name, total, count)
+			}
+		}
+		sort.Strings(union)
+		expected := append([]string{}, names...)
+		sort.Strings(expected)
+		if !reflect.DeepEqual(union, expected) {
+			t.Errorf("expected the %d shards to cover every spec, but got %q", total, union)
+		}
+	}
+
+	// adding a spec does not move the others
+	before := shardSpecs(names, testShard{index: 2, total: 4})
+	after := shardSpecs(append(names, "[It] [sig-node] added spec"), testShard{index: 2, total: 4})
+	for _, name := range before {
+		if !containsString(after, name) {
+			t.Errorf("expected %q to stay in its shard when a spec is added", name)
+		}
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestShardFocus(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ARTIFACTS", dir)
+	listed := []string{
+		"[It] [sig-node] Pods should start",
+		"[It] [sig-node] Pods should stop",
+		"[It] [sig-node] Probes should restart (liveness)",
+	}
+
+	var focuses []string
+	for index := 1; index <= 2; index++ {
+		cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+			artifactsDir := argValue(t, cmd.env, "ARTIFACTS")
+			if strings.Contains(argValue(t, cmd.args, "TEST_ARGS"), "--ginkgo.dry-run") {
+				writeArtifact(t, artifactsDir, "junit_01.xml", dryRunJUnit)
+				return nil
+			}
+			focuses = append(focuses, argValue(t, cmd.args, "FOCUS"))
+			return nil
+		}}
+		tester := NewDefaultTester()
+		tester.RepoRoot = fakeRepoRoot(t)
+		tester.GCPZone = "us-central1-a"
+		tester.Shard = fmt.Sprintf("%d/2", index)
+		tester.cmder = cmder
+		if err := tester.validateFlags(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := tester.Test(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if filepath.Base(argValue(t, cmder.cmds[0].env, "ARTIFACTS")) != listSpecsDirName {
+			t.Errorf("expected the specs to be listed with a dry run first")
+		}
+	}
+
+	var expected []string
+	for index := 1; index <= 2; index++ {
+		if assigned := shardSpecs(listed, testShard{index: index, total: 2}); len(assigned) > 0 {
+			expected = append(expected, specFocusRegex(assigned))
+		}
+	}
+	if !reflect.DeepEqual(focuses, expected) {
+		t.Errorf("expected focuses %q, but got %q", expected, focuses)
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"k8s.io/klog/v2"
+)
+
+// watchSignals aborts the run by calling cancel when one of signals is
+// received, so the make process can drain, the instances are cleaned up and
+// the boskos resource is released before exiting. The default handling is
+// restored after the first signal, so a second one exits immediately.
+// Stop watching by calling the returned stop function.
+func watchSignals(cancel context.CancelCauseFunc, signals ...os.Signal) (stop func()) {
+	received := make(chan os.Signal, 1)
+	signal.Notify(received, signals...)
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		select {
+		case sig := <-received:
+			signal.Stop(received)
+			klog.Warningf("received %v, aborting the run and cleaning up, send it again to exit immediately", sig)
+			cancel(fmt.Errorf("received %v", sig))
+		case <-done:
+		}
+	}()
+	return func() {
+		signal.Stop(received)
+		close(done)
+		<-exited
+	}
+}
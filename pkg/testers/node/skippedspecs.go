@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// skippedSpecsFileName lists the skipped specs of a run with the reason each was skipped
+const skippedSpecsFileName = "skipped-specs.txt"
+
+// reasons a spec was skipped
+const (
+	skipReasonPending    = "pending"
+	skipReasonSkipRegex  = "matched --skip-regex"
+	skipReasonNotFocused = "did not match --focus-regex"
+	skipReasonInSpec     = "skipped by the spec"
+)
+
+// skippedSpec is a skipped spec and the reason it was skipped
+type skippedSpec struct {
+	Name   string
+	Reason string
+}
+
+// classifySkippedSpecs returns the skipped specs in results sorted by name with
+// the reason each was skipped: pending specs first, then specs skipped by the
+// skip and focus regexes, which ginkgo matches against the spec text, and
+// finally specs that skipped themselves with their skip message
+func classifySkippedSpecs(results *Summary, focus, skip *regexp.Regexp) []skippedSpec {
+	var skipped []skippedSpec
+	for _, spec := range results.Specs {
+		if spec.Status != SpecSkipped {
+			continue
+		}
+		text := strings.TrimPrefix(spec.Name, "[It] ")
+		// ginkgo reports the message passed to Skip as "skipped - <message>"
+		message := strings.TrimPrefix(strings.TrimSpace(spec.Message), "skipped - ")
+		reason := skipReasonInSpec
+		switch {
+		case strings.EqualFold(message, "pending"):
+			reason = skipReasonPending
+		case skip != nil && skip.MatchString(text):
+			reason = skipReasonSkipRegex
+		case focus != nil && !focus.MatchString(text):
+			reason = skipReasonNotFocused
+		case message != "" && message != "skipped":
+			reason = fmt.Sprintf("%s: %s", skipReasonInSpec, strings.Join(strings.Fields(message), " "))
+		}
+		skipped = append(skipped, skippedSpec{Name: spec.Name, Reason: reason})
+	}
+	sort.Slice(skipped, func(i, j int) bool { return skipped[i].Name < skipped[j].Name })
+	return skipped
+}
+
+// compileOptional compiles expr, returning nil if it is empty
+func compileOptional(expr string) (*regexp.Regexp, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	return regexp.Compile(expr)
+}
+
+// reportSkippedSpecs writes the skipped specs of the run in artifactsDir and
+// why they were skipped to skipped-specs.txt, one "<spec>: <reason>" per line
+func (t *Tester) reportSkippedSpecs(artifactsDir string) {
+	focus, err := compileOptional(t.FocusRegex)
+	if err != nil {
+		klog.Warningf("failed to classify skipped specs: invalid focus regex: %v", err)
+		return
+	}
+	skip, err := compileOptional(t.SkipRegex)
+	if err != nil {
+		klog.Warningf("failed to classify skipped specs: invalid skip regex: %v", err)
+		return
+	}
+	results, err := t.results(artifactsDir)
+	if err != nil {
+		klog.Warningf("failed to parse test results: %v", err)
+		return
+	}
+	var b strings.Builder
+	for _, spec := range classifySkippedSpecs(results, focus, skip) {
+		fmt.Fprintf(&b, "%s: %s\n", spec.Name, spec.Reason)
+	}
+	path := filepath.Join(artifactsDir, skippedSpecsFileName)
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		klog.Warningf("failed to write %s: %v", path, err)
+	}
+}
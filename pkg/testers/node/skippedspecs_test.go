@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const skippedSpecsJUnit = `<testsuite>
+  <testcase name="[It] [sig-node] Pods should start"/>
+  <testcase name="[It] [sig-node] Pods should be pending"><skipped message="pending"/></testcase>
+  <testcase name="[It] [sig-node] [Serial] Eviction should evict"><skipped message="skipped"/></testcase>
+  <testcase name="[It] [sig-storage] Volumes should mount"><skipped message="skipped"/></testcase>
+  <testcase name="[It] [sig-node] GPU should attach"><skipped message="skipped - requires a GPU"/></testcase>
+  <testcase name="[It] [sig-node] Probes should restart"><skipped message="skipped"/></testcase>
+</testsuite>`
+
+func TestReportSkippedSpecs(t *testing.T) {
+	dir := t.TempDir()
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		writeArtifact(t, argValue(t, cmd.env, "ARTIFACTS"), "junit_01.xml", skippedSpecsJUnit)
+		return nil
+	}}
+	tester := NewDefaultTester()
+	tester.FocusRegex = `\[sig-node\]`
+	tester.SkipRegex = `\[Serial\]`
+	tester.ReportSkippedSpecs = true
+	tester.cmder = cmder
+	if err := tester.run(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, skippedSpecsFileName))
+	if err != nil {
+		t.Fatalf("failed to read the skipped specs: %v", err)
+	}
+	expected := `[It] [sig-node] GPU should attach: skipped by the spec: requires a GPU
+[It] [sig-node] Pods should be pending: pending
+[It] [sig-node] Probes should restart: skipped by the spec
+[It] [sig-node] [Serial] Eviction should evict: matched --skip-regex
+[It] [sig-storage] Volumes should mount: did not match --focus-regex
+`
+	if string(data) != expected {
+		t.Errorf("expected skipped specs:\n%s\nbut got:\n%s", expected, data)
+	}
+}
+
+func TestClassifySkippedSpecsWithoutRegexes(t *testing.T) {
+	results := &Summary{}
+	results.Add(SpecResult{Name: "[It] [sig-node] Pods should be pending", Status: SpecSkipped, Message: "pending"})
+	results.Add(SpecResult{Name: "[It] [sig-storage] Volumes should mount", Status: SpecSkipped, Message: "skipped"})
+	results.Add(SpecResult{Name: "[It] [sig-node] Pods should start", Status: SpecPassed})
+
+	skipped := classifySkippedSpecs(results, nil, nil)
+	expected := []skippedSpec{
+		{Name: "[It] [sig-node] Pods should be pending", Reason: skipReasonPending},
+		{Name: "[It] [sig-storage] Volumes should mount", Reason: skipReasonInSpec},
+	}
+	if len(skipped) != len(expected) {
+		t.Fatalf("expected %v, but got %v", expected, skipped)
+	}
+	for i := range expected {
+		if skipped[i] != expected[i] {
+			t.Errorf("expected %v, but got %v", expected[i], skipped[i])
+		}
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const specTimingsFileName = "spec-timings.csv"
+
+// junitHostRegex matches the junit files of a remote run, which are named
+// after the instance the specs ran on, e.g. junit_tmp-node-e2e-cos-1234_01.xml
+var junitHostRegex = regexp.MustCompile(`^junit_(.+)_[0-9]+\.xml$`)
+
+// specImage returns the image the spec whose results were read from file ran
+// on, the image of --images in the instance name if any or the instance name
+func (t *Tester) specImage(file string) string {
+	match := junitHostRegex.FindStringSubmatch(filepath.Base(file))
+	if match == nil {
+		return ""
+	}
+	host := match[1]
+	for _, image := range strings.Split(t.Images, ",") {
+		if image != "" && strings.Contains(host, image) {
+			return image
+		}
+	}
+	return host
+}
+
+// specRuntime returns the container runtime the spec whose results were read
+// from file ran with, with several runtimes their artifacts are under
+// <artifacts>/<runtime>
+func (t *Tester) specRuntime(artifactsDir, file string) string {
+	if len(t.runtimeEndpoints) == 1 {
+		return t.runtimeEndpoints[0].label
+	}
+	rel, err := filepath.Rel(artifactsDir, file)
+	if err != nil {
+		return ""
+	}
+	dir := strings.Split(filepath.ToSlash(rel), "/")[0]
+	for _, endpoint := range t.runtimeEndpoints {
+		if endpoint.label == dir {
+			return endpoint.label
+		}
+	}
+	return ""
+}
+
+// writeSpecTimings writes the duration of every spec in the results in
+// artifactsDir to spec-timings.csv, along with its status, image and runtime
+func (t *Tester) writeSpecTimings(artifactsDir string) error {
+	results, err := t.results(artifactsDir)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(artifactsDir, specTimingsFileName)
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer f.Close()
+	w := csv.NewWriter(f)
+	_ = w.Write([]string{"spec", "status", "duration_seconds", "image", "runtime"})
+	for _, spec := range results.Specs {
+		_ = w.Write([]string{
+			spec.Name,
+			string(spec.Status),
+			strconv.FormatFloat(spec.Duration.Seconds(), 'f', 3, 64),
+			t.specImage(spec.File),
+			t.specRuntime(artifactsDir, spec.File),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWriteSpecTimings(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, dir, "containerd/junit_tmp-node-e2e-1234-cos-stable_01.xml", sampleJUnit)
+	writeArtifact(t, dir, "crio/junit_tmp-node-e2e-5678-ubuntu_01.xml",
+		`<testsuite name="E2eNode Suite"><testcase name="[It] other" time="3.25"></testcase></testsuite>`)
+	endpoints, err := parseRuntimeEndpoints("containerd=unix:///run/containerd/containerd.sock,crio=unix:///run/crio/crio.sock")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tester := NewDefaultTester()
+	tester.Images = "cos-stable,ubuntu"
+	tester.runtimeEndpoints = endpoints
+
+	if err := tester.writeSpecTimings(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	f, err := os.Open(filepath.Join(dir, specTimingsFileName))
+	if err != nil {
+		t.Fatalf("failed to open the spec timings: %v", err)
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse the spec timings: %v", err)
+	}
+	expected := [][]string{
+		{"spec", "status", "duration_seconds", "image", "runtime"},
+		{"[It] known flake", "failed", "1.500", "cos-stable", "containerd"},
+		{"[It] fixed bug", "passed", "2.000", "cos-stable", "containerd"},
+		{"[It] regression", "failed", "0.500", "cos-stable", "containerd"},
+		{"[It] not run", "skipped", "0.000", "cos-stable", "containerd"},
+		{"[It] other", "passed", "3.250", "ubuntu", "crio"},
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected rows %v, but got %v", expected, rows)
+	}
+}
+
+func TestSpecImage(t *testing.T) {
+	testCases := []struct {
+		file     string
+		images   string
+		expected string
+	}{
+		{file: "/artifacts/junit_tmp-node-e2e-1234-cos-stable_01.xml", images: "cos-stable", expected: "cos-stable"},
+		{file: "/artifacts/junit_tmp-node-e2e-1234_02.xml", expected: "tmp-node-e2e-1234"},
+		{file: "/artifacts/results.json", expected: ""},
+	}
+
+	for _, tc := range testCases {
+		tester := NewDefaultTester()
+		tester.Images = tc.images
+		if actual := tester.specImage(tc.file); actual != tc.expected {
+			t.Errorf("expected image %q for %s, but got %q", tc.expected, tc.file, actual)
+		}
+	}
+}
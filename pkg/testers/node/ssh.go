@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/fs"
+)
+
+// the files of the private and public keys in --ssh-key-dir, named after the
+// keys of the secret it is mounted from
+const (
+	sshKeyDirPrivateKey = "id"
+	sshKeyDirPublicKey  = "id.pub"
+)
+
+// SSHTransport is how the tester reaches the test instances of a provider,
+// for diagnostics and log collection
+type SSHTransport interface {
+	// Command returns the command line that opens a shell on instance
+	Command(instance string) []string
+	// Exec runs command on instance, writing its output to stdout and stderr
+	Exec(ctx context.Context, instance, command string, stdout, stderr io.Writer) error
+	// Copy copies the local file to the remote path on instance
+	Copy(ctx context.Context, instance, local, remote string, stdout, stderr io.Writer) error
+}
+
+// gceSSHTransport reaches gce instances with gcloud compute ssh,
+// which manages the ssh keys and resolves the instance names
+type gceSSHTransport struct {
+	cmder   exec.Cmder
+	project string
+	zone    string
+	// zones overrides zone for the instances in other zones
+	zones map[string]string
+	user  string
+}
+
+var _ SSHTransport = &gceSSHTransport{}
+
+func (g *gceSSHTransport) Command(instance string) []string {
+	return []string{"gcloud", "compute", "ssh", "--project=" + g.project, "--zone=" + g.instanceZone(instance), g.user + "@" + instance}
+}
+
+func (g *gceSSHTransport) Exec(ctx context.Context, instance, command string, stdout, stderr io.Writer) error {
+	args := append(g.Command(instance), "--command="+command)
+	return runSSHCommand(ctx, g.cmder, args, stdout, stderr)
+}
+
+func (g *gceSSHTransport) Copy(ctx context.Context, instance, local, remote string, stdout, stderr io.Writer) error {
+	args := []string{"gcloud", "compute", "scp", "--project=" + g.project, "--zone=" + g.instanceZone(instance), local, g.user + "@" + instance + ":" + remote}
+	return runSSHCommand(ctx, g.cmder, args, stdout, stderr)
+}
+
+func (g *gceSSHTransport) instanceZone(instance string) string {
+	if zone, ok := g.zones[instance]; ok && zone != "" {
+		return zone
+	}
+	return g.zone
+}
+
+// plainSSHTransport reaches instances with ssh directly, using the private key if set
+type plainSSHTransport struct {
+	cmder      exec.Cmder
+	user       string
+	privateKey string
+}
+
+var _ SSHTransport = &plainSSHTransport{}
+
+func (p *plainSSHTransport) Command(instance string) []string {
+	args := []string{"ssh"}
+	if p.privateKey != "" {
+		args = append(args, "-i", p.privateKey)
+	}
+	return append(args, p.user+"@"+instance)
+}
+
+func (p *plainSSHTransport) Exec(ctx context.Context, instance, command string, stdout, stderr io.Writer) error {
+	args := append(p.Command(instance), "--", command)
+	return runSSHCommand(ctx, p.cmder, args, stdout, stderr)
+}
+
+func (p *plainSSHTransport) Copy(ctx context.Context, instance, local, remote string, stdout, stderr io.Writer) error {
+	args := []string{"scp"}
+	if p.privateKey != "" {
+		args = append(args, "-i", p.privateKey)
+	}
+	args = append(args, local, p.user+"@"+instance+":"+remote)
+	return runSSHCommand(ctx, p.cmder, args, stdout, stderr)
+}
+
+func runSSHCommand(ctx context.Context, cmder exec.Cmder, args []string, stdout, stderr io.Writer) error {
+	cmd := cmder.CommandContext(ctx, args[0], args[1:]...)
+	cmd.SetStdout(stdout)
+	cmd.SetStderr(stderr)
+	return cmd.Run()
+}
+
+// sshTransport returns the transport for the provider of the run
+func (t *Tester) sshTransport() SSHTransport {
+	if t.Provider == "gce" {
+		transport := &gceSSHTransport{cmder: t.cmder, project: t.GCPProject, zone: t.GCPZone, user: t.sshUser}
+		if len(t.gcpZones()) > 1 {
+			transport.zones = t.instanceZones()
+		}
+		return transport
+	}
+	return &plainSSHTransport{cmder: t.cmder, user: t.sshUser, privateKey: t.privateKey}
+}
+
+// validateSSHKeyPath checks that the private key given with --ssh-key-path, or
+// else $KUBE_SSH_KEY_PATH, is a readable file and resolves its absolute path
+func (t *Tester) validateSSHKeyPath() error {
+	path, source := t.SSHKeyPath, "--ssh-key-path"
+	if path == "" {
+		path, source = os.Getenv(sshKeyPathEnv), "$"+sshKeyPathEnv
+	}
+	if path == "" {
+		return nil
+	}
+	abs, err := resolveFile(path)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %v", source, err)
+	}
+	f, err := os.Open(abs)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %v", source, err)
+	}
+	f.Close()
+	t.sshKeyPath = abs
+	return nil
+}
+
+// validateSSHKeyDir checks that the directory given with --ssh-key-dir holds
+// both the private and the public key
+func (t *Tester) validateSSHKeyDir() error {
+	if t.SSHKeyDir == "" {
+		return nil
+	}
+	if t.Provider != "gce" {
+		return fmt.Errorf("--ssh-key-dir is only supported with the gce provider")
+	}
+	if t.sshKeyPath != "" {
+		return fmt.Errorf("--ssh-key-dir cannot be combined with --ssh-key-path or $%s", sshKeyPathEnv)
+	}
+	for _, name := range []string{sshKeyDirPrivateKey, sshKeyDirPublicKey} {
+		if _, err := resolveFile(filepath.Join(t.SSHKeyDir, name)); err != nil {
+			return fmt.Errorf("invalid --ssh-key-dir: %v", err)
+		}
+	}
+	return nil
+}
+
+// copySSHKeys copies the keys in dir to privateKey and its public key next to
+// it, the private key is made readable by its owner only as ssh requires
+func copySSHKeys(dir, privateKey string) error {
+	if err := fs.CopyFile(filepath.Join(dir, sshKeyDirPrivateKey), privateKey); err != nil {
+		return err
+	}
+	if err := os.Chmod(privateKey, 0600); err != nil {
+		return err
+	}
+	return fs.CopyFile(filepath.Join(dir, sshKeyDirPublicKey), privateKey+".pub")
+}
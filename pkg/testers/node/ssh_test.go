@@ -0,0 +1,317 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSSHTransport(t *testing.T) {
+	testCases := []struct {
+		name            string
+		provider        string
+		privateKey      string
+		expectedCommand []string
+		expectedExec    []string
+		expectedCopy    []string
+	}{
+		{
+			name:            "gce",
+			provider:        "gce",
+			expectedCommand: []string{"gcloud", "compute", "ssh", "--project=p", "--zone=us-central1-a", "prow@tmp-node-e2e-cos-1234"},
+			expectedExec:    []string{"gcloud", "compute", "ssh", "--project=p", "--zone=us-central1-a", "prow@tmp-node-e2e-cos-1234", "--command=journalctl -u kubelet"},
+			expectedCopy:    []string{"gcloud", "compute", "scp", "--project=p", "--zone=us-central1-a", "fixture.json", "prow@tmp-node-e2e-cos-1234:/tmp/fixture.json"},
+		},
+		{
+			name:            "ec2",
+			provider:        "ec2",
+			privateKey:      "/root/.ssh/id_rsa",
+			expectedCommand: []string{"ssh", "-i", "/root/.ssh/id_rsa", "prow@tmp-node-e2e-cos-1234"},
+			expectedExec:    []string{"ssh", "-i", "/root/.ssh/id_rsa", "prow@tmp-node-e2e-cos-1234", "--", "journalctl -u kubelet"},
+			expectedCopy:    []string{"scp", "-i", "/root/.ssh/id_rsa", "fixture.json", "prow@tmp-node-e2e-cos-1234:/tmp/fixture.json"},
+		},
+		{
+			name:            "ec2 without a private key",
+			provider:        "ec2",
+			expectedCommand: []string{"ssh", "prow@tmp-node-e2e-cos-1234"},
+			expectedExec:    []string{"ssh", "prow@tmp-node-e2e-cos-1234", "--", "journalctl -u kubelet"},
+			expectedCopy:    []string{"scp", "fixture.json", "prow@tmp-node-e2e-cos-1234:/tmp/fixture.json"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+				_, _ = io.WriteString(cmd.stdout, "kubelet logs")
+				_, _ = io.WriteString(cmd.stderr, "warning")
+				return nil
+			}}
+			tester := NewDefaultTester()
+			tester.Provider = tc.provider
+			tester.GCPProject = "p"
+			tester.GCPZone = "us-central1-a"
+			tester.sshUser = "prow"
+			tester.privateKey = tc.privateKey
+			tester.cmder = cmder
+			transport := tester.sshTransport()
+
+			if actual := transport.Command("tmp-node-e2e-cos-1234"); !reflect.DeepEqual(actual, tc.expectedCommand) {
+				t.Errorf("expected command %q, but got %q", tc.expectedCommand, actual)
+			}
+
+			ctx := context.Background()
+			var stdout, stderr bytes.Buffer
+			if err := transport.Exec(ctx, "tmp-node-e2e-cos-1234", "journalctl -u kubelet", &stdout, &stderr); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(cmder.cmds) != 1 {
+				t.Fatalf("expected one command to be run, but got %d", len(cmder.cmds))
+			}
+			cmd := cmder.cmds[0]
+			if actual := append([]string{cmd.name}, cmd.args...); !reflect.DeepEqual(actual, tc.expectedExec) {
+				t.Errorf("expected exec %q, but got %q", tc.expectedExec, actual)
+			}
+			if cmd.ctx != ctx {
+				t.Errorf("expected the command to be bound to the context")
+			}
+			if stdout.String() != "kubelet logs" || stderr.String() != "warning" {
+				t.Errorf("expected the command output to be written to stdout and stderr, but got %q and %q", stdout.String(), stderr.String())
+			}
+
+			if err := transport.Copy(ctx, "tmp-node-e2e-cos-1234", "fixture.json", "/tmp/fixture.json", &stdout, &stderr); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			cmd = cmder.cmds[1]
+			if actual := append([]string{cmd.name}, cmd.args...); !reflect.DeepEqual(actual, tc.expectedCopy) {
+				t.Errorf("expected copy %q, but got %q", tc.expectedCopy, actual)
+			}
+		})
+	}
+}
+
+func TestSSHTransportExecError(t *testing.T) {
+	expected := errors.New("connection refused")
+	tester := NewDefaultTester()
+	tester.Provider = "ec2"
+	tester.cmder = &fakeCmder{run: func(cmd *fakeCmd) error { return expected }}
+
+	err := tester.sshTransport().Exec(context.Background(), "instance", "true", io.Discard, io.Discard)
+	if !errors.Is(err, expected) {
+		t.Errorf("expected the ssh error to be returned, but got %v", err)
+	}
+}
+
+func TestSSHKeyPath(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, dir, "id_ed25519", "private key")
+	writeArtifact(t, dir, "other_key", "private key")
+	key, otherKey := filepath.Join(dir, "id_ed25519"), filepath.Join(dir, "other_key")
+
+	testCases := []struct {
+		name        string
+		provider    string
+		sshKeyPath  string
+		env         string
+		expectedKey string
+		expectErr   bool
+	}{
+		{
+			name:        "flag",
+			provider:    "gce",
+			sshKeyPath:  key,
+			expectedKey: key,
+		},
+		{
+			name:        "environment variable",
+			provider:    "ec2",
+			env:         key,
+			expectedKey: key,
+		},
+		{
+			name:        "flag takes precedence over the environment variable",
+			provider:    "gce",
+			sshKeyPath:  key,
+			env:         otherKey,
+			expectedKey: key,
+		},
+		{
+			name:       "missing key",
+			provider:   "gce",
+			sshKeyPath: filepath.Join(dir, "missing"),
+			expectErr:  true,
+		},
+		{
+			name:      "key is a directory",
+			provider:  "gce",
+			env:       dir,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(sshKeyPathEnv, tc.env)
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.Provider = tc.provider
+			tester.GCPProject = "p"
+			tester.GCPZone = "us-central1-a"
+			tester.InstanceType = "m5.large"
+			tester.UserDataFile = "user-data.sh"
+			tester.SSHKeyPath = tc.sshKeyPath
+			err := tester.validateFlags()
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := tester.setupProvider(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tester.privateKey != tc.expectedKey {
+				t.Errorf("expected private key %q, but got %q", tc.expectedKey, tester.privateKey)
+			}
+			if actual := argValue(t, tester.constructArgs(), "SSH_KEY"); actual != tc.expectedKey {
+				t.Errorf("expected SSH_KEY=%q, but got %q", tc.expectedKey, actual)
+			}
+		})
+	}
+}
+
+func TestSSHKeyDir(t *testing.T) {
+	secret := t.TempDir()
+	writeArtifact(t, secret, sshKeyDirPrivateKey, "private key")
+	writeArtifact(t, secret, sshKeyDirPublicKey, "public key")
+	partial := t.TempDir()
+	writeArtifact(t, partial, sshKeyDirPrivateKey, "private key")
+
+	testCases := []struct {
+		name       string
+		provider   string
+		sshKeyDir  string
+		sshKeyPath string
+		existing   bool
+		expectErr  bool
+		expectCopy bool
+	}{
+		{
+			name:       "keys are copied into place",
+			provider:   "gce",
+			sshKeyDir:  secret,
+			expectCopy: true,
+		},
+		{
+			name:      "existing keys are kept",
+			provider:  "gce",
+			sshKeyDir: secret,
+			existing:  true,
+		},
+		{
+			name:      "missing public key",
+			provider:  "gce",
+			sshKeyDir: partial,
+			expectErr: true,
+		},
+		{
+			name:       "combined with --ssh-key-path",
+			provider:   "gce",
+			sshKeyDir:  secret,
+			sshKeyPath: filepath.Join(secret, sshKeyDirPrivateKey),
+			expectErr:  true,
+		},
+		{
+			name:      "ec2",
+			provider:  "ec2",
+			sshKeyDir: secret,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			t.Setenv(sshKeyPathEnv, "")
+			t.Setenv(ciPrivateKeyEnv, "")
+			t.Setenv(ciPublicKeyEnv, "")
+			privateKey := filepath.Join(home, ".ssh", "google_compute_engine")
+			if tc.existing {
+				writeArtifact(t, home, ".ssh/google_compute_engine", "existing key")
+			}
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.Provider = tc.provider
+			tester.GCPZone = "us-central1-a"
+			tester.InstanceType = "m5.large"
+			tester.UserDataFile = "user-data.sh"
+			tester.SSHKeyDir = tc.sshKeyDir
+			tester.SSHKeyPath = tc.sshKeyPath
+			err := tester.validateFlags()
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			tester.maybeSetupSSHKeys()
+			if tester.privateKey != privateKey {
+				t.Errorf("expected private key %q, but got %q", privateKey, tester.privateKey)
+			}
+			expected := map[string]string{privateKey: "existing key"}
+			if tc.expectCopy {
+				expected = map[string]string{privateKey: "private key", privateKey + ".pub": "public key"}
+			}
+			for path, content := range expected {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatalf("failed to read %s: %v", path, err)
+				}
+				if string(data) != content {
+					t.Errorf("expected %s to hold %q, but got %q", path, content, data)
+				}
+			}
+			if !tc.expectCopy {
+				return
+			}
+			info, err := os.Stat(privateKey)
+			if err != nil {
+				t.Fatalf("failed to stat the private key: %v", err)
+			}
+			if mode := info.Mode().Perm(); mode != 0600 {
+				t.Errorf("expected the private key to be readable by its owner only, but its mode is %v", mode)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// sshUnreachableExitCode is the exit code of ssh, and of gcloud compute ssh,
+// when the connection to the host fails rather than the remote command
+const sshUnreachableExitCode = 255
+
+// sshExecRetryBackoff is the initial wait between the attempts of an SSH
+// command of the diagnostics, doubled after each failure
+var sshExecRetryBackoff = 5 * time.Second
+
+// hostUnreachable reports whether err is an SSH command failing to reach the
+// host, which may be transient, rather than the remote command exiting non-zero
+func hostUnreachable(err error) bool {
+	var exitErr interface{ ExitCode() int }
+	return errors.As(err, &exitErr) && exitErr.ExitCode() == sshUnreachableExitCode
+}
+
+// retryingSSHTransport retries the commands run with Exec when the host is
+// unreachable, up to retries times with an exponential backoff. Each attempt
+// is buffered so that only the output of the last one is written.
+type retryingSSHTransport struct {
+	SSHTransport
+	retries int
+	clock   clock
+}
+
+var _ SSHTransport = &retryingSSHTransport{}
+
+func (r *retryingSSHTransport) Exec(ctx context.Context, instance, command string, stdout, stderr io.Writer) error {
+	backoff := sshExecRetryBackoff
+	for attempt := 0; ; attempt++ {
+		var out, errOut bytes.Buffer
+		err := r.SSHTransport.Exec(ctx, instance, command, &out, &errOut)
+		if err == nil || attempt >= r.retries || !hostUnreachable(err) || ctx.Err() != nil {
+			if _, writeErr := out.WriteTo(stdout); writeErr != nil && err == nil {
+				err = writeErr
+			}
+			_, _ = errOut.WriteTo(stderr)
+			return err
+		}
+		klog.Warningf("instance %s is unreachable over SSH, retrying in %s (attempt %d of %d): %v", instance, backoff, attempt+1, r.retries, err)
+		select {
+		case <-r.clock.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+// diagnosticsTransport returns the transport the diagnostics are collected
+// over, retrying unreachable hosts up to SSHExecRetries times
+func (t *Tester) diagnosticsTransport() SSHTransport {
+	transport := t.sshTransport()
+	if t.SSHExecRetries <= 0 {
+		return transport
+	}
+	return &retryingSSHTransport{SSHTransport: transport, retries: t.SSHExecRetries, clock: t.clock}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// exitError is a command exiting with code, like an *exec.ExitError
+type exitError struct {
+	code int
+}
+
+func (e exitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
+func (e exitError) ExitCode() int {
+	return e.code
+}
+
+// instantClock fires every wait right away and records how long they were
+type instantClock struct {
+	realClock
+	waits []time.Duration
+}
+
+func (c *instantClock) After(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+	ch := make(chan time.Time, 1)
+	ch <- time.Now()
+	return ch
+}
+
+func TestRetryingSSHTransport(t *testing.T) {
+	unreachable := exitError{code: sshUnreachableExitCode}
+	testCases := []struct {
+		name           string
+		retries        int
+		errs           []error
+		expectErr      bool
+		expectedCalls  int
+		expectedWaits  []time.Duration
+		expectedOutput string
+	}{
+		{
+			name:           "unreachable then reachable",
+			retries:        3,
+			errs:           []error{unreachable, unreachable, nil},
+			expectedCalls:  3,
+			expectedWaits:  []time.Duration{sshExecRetryBackoff, 2 * sshExecRetryBackoff},
+			expectedOutput: "attempt 3",
+		},
+		{
+			name:           "command exited non-zero",
+			retries:        3,
+			errs:           []error{exitError{code: 1}},
+			expectErr:      true,
+			expectedCalls:  1,
+			expectedOutput: "attempt 1",
+		},
+		{
+			name:           "unreachable after the retries",
+			retries:        2,
+			errs:           []error{unreachable, unreachable, unreachable, nil},
+			expectErr:      true,
+			expectedCalls:  3,
+			expectedWaits:  []time.Duration{sshExecRetryBackoff, 2 * sshExecRetryBackoff},
+			expectedOutput: "attempt 3",
+		},
+		{
+			name:           "no retries",
+			errs:           []error{unreachable, nil},
+			expectErr:      true,
+			expectedCalls:  1,
+			expectedOutput: "attempt 1",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+				attempt := cmd.cmder.count()
+				_, _ = io.WriteString(cmd.stdout, fmt.Sprintf("attempt %d", attempt))
+				return tc.errs[attempt-1]
+			}}
+			clock := &instantClock{}
+			tester := NewDefaultTester()
+			tester.Provider = "ec2"
+			tester.sshUser = "ec2-user"
+			tester.SSHExecRetries = tc.retries
+			tester.cmder = cmder
+			tester.clock = clock
+
+			var events bytes.Buffer
+			source := &sshEventSource{transport: tester.diagnosticsTransport()}
+			err := source.Events(context.Background(), "10.0.0.1", &events)
+			if tc.expectErr != (err != nil) {
+				t.Errorf("expected an error=%v, but got %v", tc.expectErr, err)
+			}
+			if len(cmder.cmds) != tc.expectedCalls {
+				t.Errorf("expected %d ssh commands, but got %d", tc.expectedCalls, len(cmder.cmds))
+			}
+			if !reflect.DeepEqual(clock.waits, tc.expectedWaits) {
+				t.Errorf("expected the retries to wait %v, but got %v", tc.expectedWaits, clock.waits)
+			}
+			if events.String() != tc.expectedOutput {
+				t.Errorf("expected only the output %q of the last attempt, but got %q", tc.expectedOutput, events.String())
+			}
+		})
+	}
+}
+
+func TestHostUnreachable(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected bool
+	}{
+		{err: exitError{code: sshUnreachableExitCode}, expected: true},
+		{err: fmt.Errorf("collecting events: %w", exitError{code: sshUnreachableExitCode}), expected: true},
+		{err: exitError{code: 1}},
+		{err: context.DeadlineExceeded},
+	}
+
+	for _, tc := range testCases {
+		if actual := hostUnreachable(tc.err); actual != tc.expected {
+			t.Errorf("expected %v to be unreachable=%v, but got %v", tc.err, tc.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// The node startup script is handed to the instance through the provider's
+// boot mechanism, the startup-script metadata on gce and the user data on ec2.
+// This is best-effort: the script runs while the test runner sets up the node
+// over ssh, so it is not guaranteed to finish before the kubelet starts, and
+// its failure is only detected when the serial console output of the node is
+// part of the run output.
+var startupScriptFailedRegex = regexp.MustCompile(`startup-script exit status [1-9][0-9]*|Failed to run module scripts[-_]user`)
+
+// resolveNodeStartupScript checks that NodeStartupScript is an existing file and
+// returns its absolute path, make does not run in the current directory
+func (t *Tester) resolveNodeStartupScript() (string, error) {
+	path, err := resolveFile(t.NodeStartupScript)
+	if err != nil {
+		return "", err
+	}
+	if t.Provider == "ec2" && t.UserDataFile != "" {
+		return "", fmt.Errorf("cannot be combined with --user-data-file on ec2")
+	}
+	return path, nil
+}
+
+// instanceMetadata returns the gce instance metadata, including the node startup script if any
+func (t *Tester) instanceMetadata() string {
+	if t.nodeStartupScript == "" || t.Provider != "gce" {
+		return t.InstanceMetadata
+	}
+	entry := "startup-script<" + t.nodeStartupScript
+	if t.InstanceMetadata == "" {
+		return entry
+	}
+	return t.InstanceMetadata + "," + entry
+}
+
+// userDataFile returns the ec2 user data file, the node startup script if any
+func (t *Tester) userDataFile() string {
+	if t.nodeStartupScript != "" && t.Provider == "ec2" {
+		return t.nodeStartupScript
+	}
+	return t.UserDataFile
+}
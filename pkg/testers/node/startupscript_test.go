@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+)
+
+func TestNodeStartupScript(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "setup.sh")
+	writeArtifact(t, dir, "setup.sh", "#!/bin/bash\nsysctl -w vm.max_map_count=262144\n")
+
+	testCases := []struct {
+		name                     string
+		provider                 string
+		script                   string
+		instanceMetadata         string
+		userDataFile             string
+		expectedInstanceMetadata string
+		expectedUserDataFile     string
+		expectErr                bool
+	}{
+		{
+			name:                     "gce startup script metadata",
+			provider:                 "gce",
+			script:                   script,
+			expectedInstanceMetadata: "startup-script<" + script,
+		},
+		{
+			name:                     "appended to existing gce metadata",
+			provider:                 "gce",
+			script:                   script,
+			instanceMetadata:         "user-data<cloud-init.yaml",
+			expectedInstanceMetadata: "user-data<cloud-init.yaml,startup-script<" + script,
+		},
+		{
+			name:                 "ec2 user data",
+			provider:             "ec2",
+			script:               script,
+			expectedUserDataFile: script,
+		},
+		{
+			name:         "ec2 user data conflict",
+			provider:     "ec2",
+			script:       script,
+			userDataFile: "user-data.sh",
+			expectErr:    true,
+		},
+		{
+			name:      "missing script",
+			provider:  "gce",
+			script:    filepath.Join(dir, "missing.sh"),
+			expectErr: true,
+		},
+		{
+			name:      "directory",
+			provider:  "gce",
+			script:    dir,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.GCPZone = "us-central1-a"
+			tester.InstanceType = "m5.large"
+			tester.Provider = tc.provider
+			tester.NodeStartupScript = tc.script
+			tester.InstanceMetadata = tc.instanceMetadata
+			tester.UserDataFile = tc.userDataFile
+			err := tester.validateFlags()
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error for script %q but got none", tc.script)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			args := tester.constructArgs()
+			if actual := argValue(t, args, "INSTANCE_METADATA"); actual != tc.expectedInstanceMetadata {
+				t.Errorf("expected INSTANCE_METADATA=%q, but got %q", tc.expectedInstanceMetadata, actual)
+			}
+			if actual := argValue(t, args, "USER_DATA_FILE"); actual != tc.expectedUserDataFile {
+				t.Errorf("expected USER_DATA_FILE=%q, but got %q", tc.expectedUserDataFile, actual)
+			}
+		})
+	}
+}
+
+func TestNodeStartupScriptFailure(t *testing.T) {
+	testCases := []struct {
+		name      string
+		output    string
+		expectErr bool
+	}{
+		{
+			name:   "script succeeded",
+			output: "tmp-node-e2e-cos-1234 google_metadata_script_runner[512]: startup-script exit status 0\n",
+		},
+		{
+			name:      "gce script failed",
+			output:    "tmp-node-e2e-cos-1234 google_metadata_script_runner[512]: startup-script exit status 1\n",
+			expectErr: true,
+		},
+		{
+			name:      "ec2 script failed",
+			output:    "cloud-init[1042]: util.py[WARNING]: Failed to run module scripts-user (scripts in /var/lib/cloud/instance/scripts)\n",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.NodeStartupScript = "setup.sh"
+			tester.cmder = &fakeCmder{run: func(cmd *fakeCmd) error {
+				_, _ = io.WriteString(cmd.stdout, tc.output)
+				return nil
+			}}
+			err := tester.runOnce(t.TempDir())
+			if tc.expectErr != (err != nil) {
+				t.Errorf("expected error %v, but got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
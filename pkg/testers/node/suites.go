@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"k8s.io/klog/v2"
+)
+
+// defaultTarget is the make target of the node e2e tests
+const defaultTarget = "test-e2e-node"
+
+// makeTargetRegex matches the make targets --make-target accepts, which are
+// passed to make as is and must not be interpreted by a shell
+var makeTargetRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+
+// suite is a named set of tests in the repo root, run with a make target and
+// selected by default with the flag values of presets
+type suite struct {
+	target  string
+	presets map[string]string
+}
+
+// builtinSuites are the suites --suite selects, keyed by suite name. The
+// presets are keyed by flag name.
+var builtinSuites = map[string]suite{
+	"node-e2e": {
+		target: defaultTarget,
+	},
+	"conformance": {
+		target: defaultTarget,
+		presets: map[string]string{
+			"focus-regex": `\[NodeConformance\]`,
+			"skip-regex":  `\[Flaky\]|\[Slow\]|\[Serial\]`,
+		},
+	},
+	"features": {
+		target: defaultTarget,
+		presets: map[string]string{
+			"focus-regex": `\[NodeFeature:.+\]|\[NodeFeature\]`,
+			"skip-regex":  `\[Flaky\]|\[Serial\]`,
+		},
+	},
+}
+
+func suiteNames() []string {
+	names := make([]string, 0, len(builtinSuites))
+	for name := range builtinSuites {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applySuite sets the make target and the flags preset by the named suite,
+// flags that were set explicitly or by --profile take precedence over them
+func applySuite(fs *pflag.FlagSet, name string) error {
+	s, ok := builtinSuites[name]
+	if !ok {
+		return fmt.Errorf("unknown suite %q, valid suites are %s", name, strings.Join(suiteNames(), ", "))
+	}
+	if fs.Changed("make-target") {
+		klog.V(1).Infof("--make-target was already set, ignoring the target of suite %s", name)
+	} else if err := fs.Set("make-target", s.target); err != nil {
+		return fmt.Errorf("failed to set --make-target from suite %s: %v", name, err)
+	}
+	for _, flagName := range sortedKeys(s.presets) {
+		if presetOverridden(fs, flagName) {
+			klog.V(1).Infof("--%s was already set, ignoring the preset of suite %s", flagName, name)
+			continue
+		}
+		if err := fs.Set(flagName, s.presets[flagName]); err != nil {
+			return fmt.Errorf("failed to set --%s from suite %s: %v", flagName, name, err)
+		}
+	}
+	return nil
+}
+
+// makeTarget returns the make target the tests are run with
+func (t *Tester) makeTarget() string {
+	return t.MakeTarget
+}
+
+// validateMakeTarget checks that --make-target is a plain make target name
+func (t *Tester) validateMakeTarget() error {
+	if t.MakeTarget == "" {
+		return fmt.Errorf("--make-target must not be empty")
+	}
+	if !makeTargetRegex.MatchString(t.MakeTarget) {
+		return fmt.Errorf("invalid --make-target %q, it may only contain letters, digits and '.', '_', '-' or '/'", t.MakeTarget)
+	}
+	return nil
+}
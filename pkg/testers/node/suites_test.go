@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/octago/sflags/gen/gpflag"
+)
+
+func TestApplySuite(t *testing.T) {
+	testCases := []struct {
+		name           string
+		args           []string
+		suite          string
+		expectErr      bool
+		expectedTarget string
+		expectedFocus  string
+		expectedSkip   string
+	}{
+		{
+			name:           "default",
+			expectedTarget: "test-e2e-node",
+			expectedSkip:   `\[Flaky\]|\[Slow\]|\[Serial\]`,
+		},
+		{
+			name:           "node e2e",
+			suite:          "node-e2e",
+			expectedTarget: "test-e2e-node",
+			expectedSkip:   `\[Flaky\]|\[Slow\]|\[Serial\]`,
+		},
+		{
+			name:           "conformance",
+			suite:          "conformance",
+			expectedTarget: "test-e2e-node",
+			expectedFocus:  `\[NodeConformance\]`,
+			expectedSkip:   `\[Flaky\]|\[Slow\]|\[Serial\]`,
+		},
+		{
+			name:           "features",
+			suite:          "features",
+			expectedTarget: "test-e2e-node",
+			expectedFocus:  `\[NodeFeature:.+\]|\[NodeFeature\]`,
+			expectedSkip:   `\[Flaky\]|\[Serial\]`,
+		},
+		{
+			name:           "explicit flags win",
+			args:           []string{`--focus-regex=\[NodeConformance\].*Pods`},
+			suite:          "conformance",
+			expectedTarget: "test-e2e-node",
+			expectedFocus:  `\[NodeConformance\].*Pods`,
+			expectedSkip:   `\[Flaky\]|\[Slow\]|\[Serial\]`,
+		},
+		{
+			name:           "explicit make target wins",
+			args:           []string{"--make-target=test-e2e-node-containerd"},
+			suite:          "conformance",
+			expectedTarget: "test-e2e-node-containerd",
+			expectedFocus:  `\[NodeConformance\]`,
+			expectedSkip:   `\[Flaky\]|\[Slow\]|\[Serial\]`,
+		},
+		{
+			name:      "unknown suite",
+			suite:     "integration",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tester := NewDefaultTester()
+			fs, err := gpflag.Parse(tester)
+			if err != nil {
+				t.Fatalf("failed to parse tester flags: %v", err)
+			}
+			if err := fs.Parse(append(tc.args, "--suite="+tc.suite)); err != nil {
+				t.Fatalf("failed to parse args: %v", err)
+			}
+			if tc.suite != "" {
+				err = applySuite(fs, tc.suite)
+			}
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error for suite %q but got none", tc.suite)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := tester.makeTarget(); actual != tc.expectedTarget {
+				t.Errorf("expected target %q, but got %q", tc.expectedTarget, actual)
+			}
+			if tester.FocusRegex != tc.expectedFocus {
+				t.Errorf("expected focus %q, but got %q", tc.expectedFocus, tester.FocusRegex)
+			}
+			if tester.SkipRegex != tc.expectedSkip {
+				t.Errorf("expected skip %q, but got %q", tc.expectedSkip, tester.SkipRegex)
+			}
+		})
+	}
+}
+
+func TestUnknownSuiteRejected(t *testing.T) {
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.GCPZone = "us-central1-a"
+	tester.Suite = "integration"
+	if err := tester.validateFlags(); err == nil {
+		t.Errorf("expected --suite=integration to be rejected")
+	}
+}
+
+func TestMakeTarget(t *testing.T) {
+	testCases := []struct {
+		target    string
+		expectErr bool
+	}{
+		{target: "test-e2e-node"},
+		{target: "test-e2e-node-containerd"},
+		{target: "e2e/node_v1.30"},
+		{target: "", expectErr: true},
+		{target: "test-e2e-node; rm -rf /", expectErr: true},
+		{target: "test-e2e-node $(id)", expectErr: true},
+		{target: "-n", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Setenv("ARTIFACTS", t.TempDir())
+		repoRoot := fakeRepoRoot(t)
+		writeArtifact(t, repoRoot, "Makefile", tc.target+": ginkgo\n")
+		tester := NewDefaultTester()
+		tester.RepoRoot = repoRoot
+		tester.GCPZone = "us-central1-a"
+		tester.MakeTarget = tc.target
+		cmder := &fakeCmder{}
+		tester.cmder = cmder
+		err := tester.validateFlags()
+		if tc.expectErr {
+			if err == nil {
+				t.Errorf("expected --make-target=%q to be rejected", tc.target)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for --make-target=%q: %v", tc.target, err)
+			continue
+		}
+		if err := tester.Test(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		if len(cmder.cmds) != 1 || cmder.cmds[0].args[0] != tc.target {
+			t.Errorf("expected make to be run with target %q, but got %v", tc.target, cmder.cmds)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// The sysctls are applied by a generated node startup script, so a sysctl the
+// node rejects is detected the same best-effort way as a failing
+// --node-startup-script.
+// The script also installs --cni-config.
+const nodeSysctlsScriptFileName = "node-startup-script.sh"
+
+// sysctlKeyRegex matches a sysctl name such as net.ipv4.ip_forward or
+// net/ipv4/conf/all/rp_filter
+var sysctlKeyRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+([./][a-zA-Z0-9_-]+)*$`)
+
+// sysctl is a single sysctl set on the test nodes
+type sysctl struct {
+	key   string
+	value string
+}
+
+// parseNodeSysctls parses a comma-separated list of key=value sysctls
+func parseNodeSysctls(value string) ([]sysctl, error) {
+	var sysctls []sysctl
+	seen := map[string]bool{}
+	for _, entry := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(entry, "=")
+		key, val = strings.TrimSpace(key), strings.TrimSpace(val)
+		if !ok || val == "" {
+			return nil, fmt.Errorf("%q is not of the form key=value", entry)
+		}
+		if !sysctlKeyRegex.MatchString(key) {
+			return nil, fmt.Errorf("%q is not a valid sysctl name", key)
+		}
+		if strings.ContainsAny(val, "\n\r") {
+			return nil, fmt.Errorf("value of %s must be on a single line", key)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("sysctl %s is set more than once", key)
+		}
+		seen[key] = true
+		sysctls = append(sysctls, sysctl{key: key, value: val})
+	}
+	return sysctls, nil
+}
+
+// shellQuote quotes s as a single word for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// nodeSysctlsScript returns a startup script setting sysctls, followed by the
+// contents of the user supplied startup script if any
+func nodeSysctlsScript(sysctls []sysctl, startupScript []byte) []byte {
+	var script bytes.Buffer
+	script.WriteString("#!/bin/bash\nset -o errexit\n")
+	for _, s := range sysctls {
+		fmt.Fprintf(&script, "sysctl -w %s\n", shellQuote(s.key+"="+s.value))
+	}
+	if len(startupScript) > 0 {
+		script.WriteString("\n")
+		script.Write(startupScript)
+	}
+	return script.Bytes()
+}
+
+// writeNodeSysctlsScript writes the startup script setting --node-sysctls and
+// installing --cni-config to dir and makes it the node startup script,
+// --node-startup-script then runs after the sysctls are set
+func (t *Tester) writeNodeSysctlsScript(dir string) error {
+	var startupScript []byte
+	if len(t.cniConfig) > 0 {
+		startupScript = cniConfigScript(t.CNIPlugin, t.cniConfig)
+	}
+	if t.nodeStartupScript != "" {
+		contents, err := os.ReadFile(t.nodeStartupScript)
+		if err != nil {
+			return fmt.Errorf("failed to read the node startup script: %w", err)
+		}
+		if len(startupScript) > 0 {
+			startupScript = append(startupScript, '\n')
+		}
+		startupScript = append(startupScript, contents...)
+	}
+	path := filepath.Join(dir, nodeSysctlsScriptFileName)
+	if err := os.WriteFile(path, nodeSysctlsScript(t.nodeSysctls, startupScript), 0755); err != nil {
+		return fmt.Errorf("failed to write the node sysctls script: %w", err)
+	}
+	t.nodeStartupScript = path
+	return nil
+}
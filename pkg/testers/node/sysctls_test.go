@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseNodeSysctls(t *testing.T) {
+	testCases := []struct {
+		name      string
+		value     string
+		expected  []sysctl
+		expectErr bool
+	}{
+		{
+			name:     "single sysctl",
+			value:    "net.ipv4.ip_forward=1",
+			expected: []sysctl{{key: "net.ipv4.ip_forward", value: "1"}},
+		},
+		{
+			name:  "multiple sysctls",
+			value: "vm.max_map_count=262144, net/ipv4/conf/all/rp_filter = 0,net.ipv4.ip_local_port_range=32768 60999",
+			expected: []sysctl{
+				{key: "vm.max_map_count", value: "262144"},
+				{key: "net/ipv4/conf/all/rp_filter", value: "0"},
+				{key: "net.ipv4.ip_local_port_range", value: "32768 60999"},
+			},
+		},
+		{
+			name:      "missing value",
+			value:     "net.ipv4.ip_forward",
+			expectErr: true,
+		},
+		{
+			name:      "empty value",
+			value:     "net.ipv4.ip_forward=",
+			expectErr: true,
+		},
+		{
+			name:      "empty key",
+			value:     "=1",
+			expectErr: true,
+		},
+		{
+			name:      "invalid key",
+			value:     "net.ipv4..ip_forward=1",
+			expectErr: true,
+		},
+		{
+			name:      "shell in key",
+			value:     "net.ipv4.ip_forward;reboot=1",
+			expectErr: true,
+		},
+		{
+			name:      "duplicate key",
+			value:     "vm.swappiness=10,vm.swappiness=60",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			sysctls, err := parseNodeSysctls(tc.value)
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error for %q but got none", tc.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(sysctls, tc.expected) {
+				t.Errorf("expected sysctls %v, but got %v", tc.expected, sysctls)
+			}
+		})
+	}
+}
+
+func TestNodeSysctls(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, dir, "setup.sh", "#!/bin/bash\necho setup\n")
+
+	testCases := []struct {
+		name           string
+		provider       string
+		startupScript  string
+		userDataFile   string
+		expectedArg    string
+		expectedScript string
+		expectErr      bool
+	}{
+		{
+			name:           "gce startup script metadata",
+			provider:       "gce",
+			expectedArg:    "INSTANCE_METADATA",
+			expectedScript: "#!/bin/bash\nset -o errexit\nsysctl -w 'net.ipv4.ip_forward=1'\nsysctl -w 'vm.max_map_count=262144'\n",
+		},
+		{
+			name:           "ec2 user data",
+			provider:       "ec2",
+			expectedArg:    "USER_DATA_FILE",
+			expectedScript: "#!/bin/bash\nset -o errexit\nsysctl -w 'net.ipv4.ip_forward=1'\nsysctl -w 'vm.max_map_count=262144'\n",
+		},
+		{
+			name:          "followed by the node startup script",
+			provider:      "gce",
+			startupScript: filepath.Join(dir, "setup.sh"),
+			expectedArg:   "INSTANCE_METADATA",
+			expectedScript: "#!/bin/bash\nset -o errexit\nsysctl -w 'net.ipv4.ip_forward=1'\nsysctl -w 'vm.max_map_count=262144'\n" +
+				"\n#!/bin/bash\necho setup\n",
+		},
+		{
+			name:         "ec2 user data conflict",
+			provider:     "ec2",
+			userDataFile: "user-data.sh",
+			expectErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.GCPZone = "us-central1-a"
+			tester.InstanceType = "m5.large"
+			tester.Provider = tc.provider
+			tester.NodeStartupScript = tc.startupScript
+			tester.UserDataFile = tc.userDataFile
+			tester.NodeSysctls = "net.ipv4.ip_forward=1,vm.max_map_count=262144"
+			err := tester.validateFlags()
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			artifactsDir := t.TempDir()
+			if err := tester.writeNodeSysctlsScript(artifactsDir); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			script := filepath.Join(artifactsDir, nodeSysctlsScriptFileName)
+			expectedArg := script
+			if tc.provider == "gce" {
+				expectedArg = "startup-script<" + script
+			}
+			if actual := argValue(t, tester.constructArgs(), tc.expectedArg); actual != expectedArg {
+				t.Errorf("expected %s=%q, but got %q", tc.expectedArg, expectedArg, actual)
+			}
+			contents, err := os.ReadFile(script)
+			if err != nil {
+				t.Fatalf("failed to read the script: %v", err)
+			}
+			if string(contents) != tc.expectedScript {
+				t.Errorf("expected script %q, but got %q", tc.expectedScript, string(contents))
+			}
+		})
+	}
+}
+
+func TestNodeSysctlsValueQuoting(t *testing.T) {
+	script := string(nodeSysctlsScript([]sysctl{{key: "kernel.core_pattern", value: "|/bin/sh -c 'echo $1'"}}, nil))
+	expected := "#!/bin/bash\nset -o errexit\nsysctl -w 'kernel.core_pattern=|/bin/sh -c '\\''echo $1'\\'''\n"
+	if script != expected {
+		t.Errorf("expected script %q, but got %q", expected, script)
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// TestGrid reads a build from a directory holding started.json and
+// finished.json next to an artifacts directory with the junit files at its top
+const (
+	testGridStartedName  = "started.json"
+	testGridFinishedName = "finished.json"
+	// flattenedJUnitPrefix names the copies of the junit files of sub-runs at
+	// the top of the artifacts directory. They are not matched by
+	// isJUnitResultsFile, so the results are not counted twice when read again.
+	flattenedJUnitPrefix = "junit_subrun_"
+)
+
+// testGridStarted is the started.json schema read by TestGrid
+type testGridStarted struct {
+	Timestamp int64 `json:"timestamp"`
+}
+
+// testGridFinished is the finished.json schema read by TestGrid
+type testGridFinished struct {
+	Timestamp int64             `json:"timestamp"`
+	Passed    bool              `json:"passed"`
+	Result    string            `json:"result"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+// testGridDir is the build directory TestGrid reads, the parent of the artifacts directory
+func testGridDir(artifactsDir string) string {
+	return filepath.Dir(filepath.Clean(artifactsDir))
+}
+
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeTestGridStarted writes started.json for a run writing its artifacts to artifactsDir
+func writeTestGridStarted(artifactsDir string, start time.Time) error {
+	path := filepath.Join(testGridDir(artifactsDir), testGridStartedName)
+	return writeJSON(path, testGridStarted{Timestamp: start.Unix()})
+}
+
+// writeTestGridFinished writes finished.json for a run with the outcome runErr,
+// including the metadata.json of the run, and copies the junit files of any
+// sub-runs to the top of artifactsDir where TestGrid looks for them
+func writeTestGridFinished(artifactsDir string, end time.Time, runErr error) error {
+	finished := testGridFinished{
+		Timestamp: end.Unix(),
+		Passed:    runErr == nil,
+		Result:    "SUCCESS",
+	}
+	if runErr != nil {
+		finished.Result = "FAILURE"
+	}
+	data, err := os.ReadFile(filepath.Join(artifactsDir, "metadata.json"))
+	if err == nil {
+		if err := json.Unmarshal(data, &finished.Metadata); err != nil {
+			return fmt.Errorf("failed to parse metadata.json: %w", err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read metadata.json: %w", err)
+	}
+	if err := flattenJUnitFiles(artifactsDir); err != nil {
+		return err
+	}
+	return writeJSON(filepath.Join(testGridDir(artifactsDir), testGridFinishedName), finished)
+}
+
+// flattenJUnitFiles copies the junit files in subdirectories of artifactsDir to
+// its top, named after the subdirectory so they stay unique, e.g.
+// <label>/junit_01.xml is copied to junit_subrun_<label>_01.xml. The originals
+// are kept for the reports of each sub-run.
+func flattenJUnitFiles(artifactsDir string) error {
+	files, err := findResultFiles(artifactsDir, isJUnitResultsFile)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		rel, err := filepath.Rel(artifactsDir, filepath.Dir(file))
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			continue
+		}
+		label := strings.ReplaceAll(filepath.ToSlash(rel), "/", "_")
+		name := flattenedJUnitPrefix + label + strings.TrimPrefix(filepath.Base(file), "junit")
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		if err := os.WriteFile(filepath.Join(artifactsDir, name), data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// readJSON decodes the json file at path into a generic map so the test
+// asserts the field names TestGrid reads rather than the tester's structs
+func readJSON(t *testing.T, path string) map[string]interface{} {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+	decoded := map[string]interface{}{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to parse %s: %v", path, err)
+	}
+	return decoded
+}
+
+func TestTestGridOutput(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	testCases := []struct {
+		name             string
+		runErr           error
+		expectedFinished map[string]interface{}
+	}{
+		{
+			name: "passed",
+			expectedFinished: map[string]interface{}{
+				"timestamp": float64(end.Unix()),
+				"passed":    true,
+				"result":    "SUCCESS",
+				"metadata":  map[string]interface{}{"tester-version": "v1.2.3", "run-id": "run"},
+			},
+		},
+		{
+			name:   "failed",
+			runErr: errors.New("specs failed"),
+			expectedFinished: map[string]interface{}{
+				"timestamp": float64(end.Unix()),
+				"passed":    false,
+				"result":    "FAILURE",
+				"metadata":  map[string]interface{}{"tester-version": "v1.2.3", "run-id": "run"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			buildDir := t.TempDir()
+			artifactsDir := filepath.Join(buildDir, "artifacts")
+			writeArtifact(t, artifactsDir, "metadata.json", `{"tester-version":"v1.2.3","run-id":"run"}`)
+			writeArtifact(t, artifactsDir, "junit_01.xml", sampleJUnit)
+			writeArtifact(t, artifactsDir, "gates-a/junit_01.xml", sampleJUnit)
+			writeArtifact(t, artifactsDir, "gates-b/nested/junit_02.xml", sampleJUnit)
+			writeArtifact(t, artifactsDir, "warmup/junit_01.xml", sampleJUnit)
+
+			if err := writeTestGridStarted(artifactsDir, start); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := writeTestGridFinished(artifactsDir, end, tc.runErr); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			expectedStarted := map[string]interface{}{"timestamp": float64(start.Unix())}
+			if actual := readJSON(t, filepath.Join(buildDir, "started.json")); !reflect.DeepEqual(actual, expectedStarted) {
+				t.Errorf("expected started.json %v, but got %v", expectedStarted, actual)
+			}
+			if actual := readJSON(t, filepath.Join(buildDir, "finished.json")); !reflect.DeepEqual(actual, tc.expectedFinished) {
+				t.Errorf("expected finished.json %v, but got %v", tc.expectedFinished, actual)
+			}
+
+			topLevel, err := filepath.Glob(filepath.Join(artifactsDir, "junit*.xml"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var names []string
+			for _, path := range topLevel {
+				names = append(names, filepath.Base(path))
+			}
+			expectedNames := []string{"junit_01.xml", "junit_subrun_gates-a_01.xml", "junit_subrun_gates-b_nested_02.xml"}
+			if !reflect.DeepEqual(names, expectedNames) {
+				t.Errorf("expected junit files %v at the top of the artifacts, but got %v", expectedNames, names)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// uploadAttempts is how many times a file is copied to a new instance,
+	// which may not accept ssh connections right after it was created
+	uploadAttempts = 5
+	// uploadRetryInterval is the wait between the attempts to copy a file
+	uploadRetryInterval = 15 * time.Second
+)
+
+// fileUpload is a local file to copy to the remote path on the test instances
+type fileUpload struct {
+	local  string
+	remote string
+}
+
+// parseUploadFiles parses the local:remote entries of --upload-file, the
+// local files must exist and the remote paths be absolute
+func parseUploadFiles(entries []string) ([]fileUpload, error) {
+	var uploads []fileUpload
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		local, remote, found := strings.Cut(entry, ":")
+		if !found || local == "" || remote == "" {
+			return nil, fmt.Errorf("%q must be in the format local:remote", entry)
+		}
+		if !path.IsAbs(remote) {
+			return nil, fmt.Errorf("remote path %s of %q must be absolute", remote, entry)
+		}
+		if seen[remote] {
+			return nil, fmt.Errorf("more than one file is uploaded to %s", remote)
+		}
+		seen[remote] = true
+		abs, err := resolveFile(local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid local file of %q: %w", entry, err)
+		}
+		uploads = append(uploads, fileUpload{local: abs, remote: path.Clean(remote)})
+	}
+	return uploads, nil
+}
+
+// uploadFiles copies the files of --upload-file to every instance, through a
+// temporary file since the remote path may only be writable by root
+func (t *Tester) uploadFiles(ctx context.Context, transport SSHTransport, instances []string, stdout, stderr io.Writer) error {
+	var errs []error
+	for _, instance := range instances {
+		for _, upload := range t.fileUploads {
+			klog.V(1).Infof("uploading %s to %s:%s", upload.local, instance, upload.remote)
+			if err := t.uploadFile(ctx, transport, instance, upload, stdout, stderr); err != nil {
+				errs = append(errs, fmt.Errorf("failed to upload %s to %s:%s: %w", upload.local, instance, upload.remote, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (t *Tester) uploadFile(ctx context.Context, transport SSHTransport, instance string, upload fileUpload, stdout, stderr io.Writer) error {
+	tmp := "/tmp/kubetest2-upload-" + path.Base(upload.remote)
+	install := fmt.Sprintf("sudo mkdir -p %s && sudo mv %s %s", shellQuote(path.Dir(upload.remote)), shellQuote(tmp), shellQuote(upload.remote))
+	for attempt := 1; ; attempt++ {
+		err := transport.Copy(ctx, instance, upload.local, tmp, stdout, stderr)
+		if err == nil {
+			return transport.Exec(ctx, instance, install, stdout, stderr)
+		}
+		if attempt == uploadAttempts {
+			return err
+		}
+		klog.Warningf("copying %s to %s failed (attempt %d/%d), retrying in %s: %v", upload.local, instance, attempt, uploadAttempts, uploadRetryInterval, err)
+		select {
+		case <-t.clock.After(uploadRetryInterval):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
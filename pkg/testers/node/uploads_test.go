@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParseUploadFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, dir, "fixture.json", "{}")
+	writeArtifact(t, dir, "credential-provider.yaml", "kind: CredentialProviderConfig")
+	fixture := filepath.Join(dir, "fixture.json")
+	provider := filepath.Join(dir, "credential-provider.yaml")
+
+	testCases := []struct {
+		name        string
+		entries     []string
+		expected    []fileUpload
+		expectedErr string
+	}{
+		{
+			name:    "files",
+			entries: []string{fixture + ":/var/lib/fixtures/fixture.json", provider + ":/etc/kubernetes/credential-provider.yaml"},
+			expected: []fileUpload{
+				{local: fixture, remote: "/var/lib/fixtures/fixture.json"},
+				{local: provider, remote: "/etc/kubernetes/credential-provider.yaml"},
+			},
+		},
+		{
+			name:     "remote path is cleaned",
+			entries:  []string{fixture + ":/var/lib//fixtures/../fixture.json"},
+			expected: []fileUpload{{local: fixture, remote: "/var/lib/fixture.json"}},
+		},
+		{
+			name:        "missing remote path",
+			entries:     []string{fixture},
+			expectedErr: "local:remote",
+		},
+		{
+			name:        "relative remote path",
+			entries:     []string{fixture + ":fixtures/fixture.json"},
+			expectedErr: "must be absolute",
+		},
+		{
+			name:        "missing local file",
+			entries:     []string{filepath.Join(dir, "missing.json") + ":/var/lib/fixture.json"},
+			expectedErr: "invalid local file",
+		},
+		{
+			name:        "local directory",
+			entries:     []string{dir + ":/var/lib/fixtures"},
+			expectedErr: "is not a file",
+		},
+		{
+			name:        "duplicate remote path",
+			entries:     []string{fixture + ":/var/lib/fixture.json", provider + ":/var/lib/fixture.json"},
+			expectedErr: "more than one file",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			uploads, err := parseUploadFiles(tc.entries)
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Errorf("expected an error containing %q, but got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(uploads, tc.expected) {
+				t.Errorf("expected uploads %v, but got %v", tc.expected, uploads)
+			}
+		})
+	}
+}
+
+func TestUploadFiles(t *testing.T) {
+	var mu sync.Mutex
+	failures := 1
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if cmd.args[1] == "scp" && failures > 0 {
+			failures--
+			return errors.New("connection refused")
+		}
+		return nil
+	}}
+	clock := newFakeClock(0)
+	tester := NewDefaultTester()
+	tester.Provider = "gce"
+	tester.GCPProject = "p"
+	tester.GCPZone = "us-central1-a"
+	tester.sshUser = "prow"
+	tester.cmder = cmder
+	tester.clock = clock
+	tester.fileUploads = []fileUpload{{local: "/fixtures/fixture.json", remote: "/var/lib/fixtures/fixture.json"}}
+
+	done := make(chan error)
+	go func() {
+		done <- tester.uploadFiles(context.Background(), tester.sshTransport(), []string{"tmp-node-e2e-cos"}, io.Discard, io.Discard)
+	}()
+	waitFor(t, clock.HasWaiters)
+	clock.Advance(uploadRetryInterval)
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var commands []string
+	for _, cmd := range cmder.cmds {
+		commands = append(commands, fmt.Sprintf("%s %s", cmd.name, strings.Join(cmd.args, " ")))
+	}
+	scp := "gcloud compute scp --project=p --zone=us-central1-a /fixtures/fixture.json prow@tmp-node-e2e-cos:/tmp/kubetest2-upload-fixture.json"
+	expected := []string{
+		scp,
+		scp,
+		"gcloud compute ssh --project=p --zone=us-central1-a prow@tmp-node-e2e-cos --command=sudo mkdir -p '/var/lib/fixtures' && sudo mv '/tmp/kubetest2-upload-fixture.json' '/var/lib/fixtures/fixture.json'",
+	}
+	if !reflect.DeepEqual(commands, expected) {
+		t.Errorf("expected commands %q, but got %q", expected, commands)
+	}
+}
+
+func TestUploadFilesBeforeRun(t *testing.T) {
+	dir := t.TempDir()
+	writeArtifact(t, dir, "fixture.json", "{}")
+	var order []string
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		if cmd.name == "gcloud" && cmd.args[2] == "create" {
+			_, _ = fmt.Fprintf(cmd.stderr, "Created [https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/%s].\n", cmd.args[3])
+		}
+		if cmd.name == "gcloud" {
+			order = append(order, "gcloud "+cmd.args[1])
+		} else {
+			order = append(order, cmd.name)
+		}
+		return nil
+	}}
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.GCPProject = "p"
+	tester.GCPZone = "us-central1-a"
+	tester.Images = "cos-stable"
+	tester.UploadFile = []string{filepath.Join(dir, "fixture.json") + ":/var/lib/fixture.json"}
+	tester.cmder = cmder
+	if err := tester.validateFlags(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := tester.runOnce(filepath.Join(dir, "artifacts")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the instance is created, the file copied and moved into place, then
+	// the tests run and the instance is deleted and its deletion verified
+	expected := []string{"gcloud instances", "gcloud scp", "gcloud ssh", "make", "gcloud instances", "gcloud instances"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("expected the files to be uploaded after creating the instance and before the tests, but got %v", order)
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWarmup(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ARTIFACTS", dir)
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		if cmd.name == "gcloud" && cmd.args[2] == "create" {
+			_, _ = fmt.Fprintf(cmd.stderr, "Created [https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/%s].\n", cmd.args[3])
+		}
+		if cmd.name != "make" {
+			return nil
+		}
+		artifactsDir := argValue(t, cmd.env, "ARTIFACTS")
+		if argValue(t, cmd.args, "FOCUS") == "should be able to pull image" {
+			writeArtifact(t, artifactsDir, "junit_01.xml", `<testsuite><testcase name="warmup"><failure message="cold"/></testcase></testsuite>`)
+			return errors.New("warmup failed")
+		}
+		writeArtifact(t, artifactsDir, "junit_01.xml", `<testsuite><testcase name="real"/></testsuite>`)
+		return nil
+	}}
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.GCPProject = "p"
+	tester.GCPZone = "us-central1-a"
+	tester.Images = "cos-stable"
+	tester.DeleteInstances = true
+	tester.Warmup = true
+	tester.FocusRegex = `\[NodeConformance\]`
+	tester.runID = "8f14e45f-ceea-467f-a8b0-12c0a1b2c3d4"
+	tester.cmder = cmder
+	if err := tester.validateFlags(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := tester.Test(); err != nil {
+		t.Fatalf("expected the warmup failure to be ignored, but got %v", err)
+	}
+
+	type invocation struct {
+		focus     string
+		artifacts string
+		hosts     string
+		images    string
+	}
+	host := "tmp-node-e2e-8f14e45f-cos-stable"
+	expected := []invocation{
+		{focus: "should be able to pull image", artifacts: filepath.Join(dir, warmupDirName), hosts: host},
+		{focus: `\[NodeConformance\]`, artifacts: dir, hosts: host},
+	}
+	var actual []invocation
+	var created, deleted []string
+	for _, cmd := range cmder.cmds {
+		switch {
+		case cmd.name == "make":
+			if len(deleted) > 0 {
+				t.Errorf("expected the instances to be deleted after the tests, but %v were deleted before", deleted)
+			}
+			actual = append(actual, invocation{
+				focus:     argValue(t, cmd.args, "FOCUS"),
+				artifacts: argValue(t, cmd.env, "ARTIFACTS"),
+				hosts:     argValue(t, cmd.args, "HOSTS"),
+				images:    argValue(t, cmd.args, "IMAGES"),
+			})
+		case cmd.name == "gcloud" && cmd.args[2] == "create":
+			created = append(created, cmd.args[3])
+		case cmd.name == "gcloud" && cmd.args[2] == "delete":
+			deleted = append(deleted, cmd.args[6:]...)
+		}
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected invocations %+v, but got %+v", expected, actual)
+	}
+	if !reflect.DeepEqual(created, []string{host}) || !reflect.DeepEqual(deleted, []string{host}) {
+		t.Errorf("expected %s to be created and deleted once, but got created %v and deleted %v", host, created, deleted)
+	}
+
+	results, err := parseJUnitResults(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results.Specs) != 1 || results.Specs[0].Name != "real" {
+		t.Errorf("expected only the real run results, but got %+v", results.Specs)
+	}
+}
+
+func TestWarmupRequiresGCE(t *testing.T) {
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.Provider = "ec2"
+	tester.InstanceType = "m5.large"
+	tester.UserDataFile = "user-data.sh"
+	tester.Warmup = true
+	if err := tester.validateFlags(); err == nil || !strings.Contains(err.Error(), "--warmup") {
+		t.Errorf("expected --warmup to be rejected on ec2, but got %v", err)
+	}
+}
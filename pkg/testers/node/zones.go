@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"sort"
+)
+
+// gcpZones returns the zones to create the instances in, --gcp-zones or else --gcp-zone
+func (t *Tester) gcpZones() []string {
+	if len(t.GCPZones) > 0 {
+		return t.GCPZones
+	}
+	if t.GCPZone != "" {
+		return []string{t.GCPZone}
+	}
+	return nil
+}
+
+// validateGCPZones checks --gcp-zones against --gcp-zone and defaults the
+// latter, which the test process and the cleanup use, to the first zone
+func (t *Tester) validateGCPZones() error {
+	if len(t.gcpZones()) == 0 {
+		return fmt.Errorf("required --gcp-zone or --gcp-zones")
+	}
+	if len(t.GCPZones) == 0 {
+		return nil
+	}
+	seen := map[string]bool{}
+	for _, zone := range t.GCPZones {
+		if zone == "" {
+			return fmt.Errorf("--gcp-zones must not contain empty zones")
+		}
+		if seen[zone] {
+			return fmt.Errorf("duplicate zone %s in --gcp-zones", zone)
+		}
+		seen[zone] = true
+	}
+	if t.GCPZone == "" {
+		t.GCPZone = t.GCPZones[0]
+	} else if !seen[t.GCPZone] {
+		return fmt.Errorf("--gcp-zone=%s is not one of --gcp-zones", t.GCPZone)
+	}
+	if len(t.GCPZones) > 1 && t.Images == "" && !t.defaultsImages() {
+		return fmt.Errorf("more than one zone in --gcp-zones requires --images")
+	}
+	return nil
+}
+
+// instanceZones returns the zone of each instance the tester creates, the
+// instances are spread round-robin across the zones
+func (t *Tester) instanceZones() map[string]string {
+	zones := map[string]string{}
+	for _, instance := range t.plannedInstances() {
+		zones[instance.name] = instance.zone
+	}
+	return zones
+}
+
+// instanceZone returns the zone of instance, --gcp-zone for the instances
+// the tester did not create
+func (t *Tester) instanceZone(instance string) string {
+	if zone, ok := t.instanceZones()[instance]; ok && zone != "" {
+		return zone
+	}
+	return t.GCPZone
+}
+
+// groupByZone groups the instances by their zone, returning the sorted zones
+func (t *Tester) groupByZone(instances []string) ([]string, map[string][]string) {
+	grouped := map[string][]string{}
+	for _, instance := range instances {
+		zone := t.instanceZone(instance)
+		grouped[zone] = append(grouped[zone], instance)
+	}
+	zones := make([]string, 0, len(grouped))
+	for zone := range grouped {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	return zones, grouped
+}
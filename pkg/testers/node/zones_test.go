@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestValidateGCPZones(t *testing.T) {
+	testCases := []struct {
+		name         string
+		zone         string
+		zones        []string
+		images       string
+		imageConfig  string
+		expectedZone string
+		expectedErr  string
+	}{
+		{
+			name:         "single zone",
+			zone:         "us-central1-a",
+			expectedZone: "us-central1-a",
+		},
+		{
+			name:         "zone defaults to the first of the zones",
+			zones:        []string{"us-central1-a", "us-central1-b"},
+			images:       "cos-stable",
+			expectedZone: "us-central1-a",
+		},
+		{
+			name:         "zone is one of the zones",
+			zone:         "us-central1-b",
+			zones:        []string{"us-central1-a", "us-central1-b"},
+			images:       "cos-stable",
+			expectedZone: "us-central1-b",
+		},
+		{
+			name:        "no zone",
+			expectedErr: "required --gcp-zone or --gcp-zones",
+		},
+		{
+			name:        "conflicting zone",
+			zone:        "us-east1-b",
+			zones:       []string{"us-central1-a", "us-central1-b"},
+			images:      "cos-stable",
+			expectedErr: "--gcp-zone=us-east1-b is not one of --gcp-zones",
+		},
+		{
+			name:        "duplicate zone",
+			zones:       []string{"us-central1-a", "us-central1-a"},
+			images:      "cos-stable",
+			expectedErr: "duplicate zone us-central1-a",
+		},
+		{
+			name:        "several zones with an image config",
+			zones:       []string{"us-central1-a", "us-central1-b"},
+			imageConfig: "image-config.yaml",
+			expectedErr: "requires --images",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.RepoRoot = fakeRepoRoot(t)
+			tester.GCPZone = tc.zone
+			tester.GCPZones = tc.zones
+			tester.Images = tc.images
+			tester.ImageConfigFile = tc.imageConfig
+			err := tester.validateFlags()
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Errorf("expected an error containing %q, but got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tester.GCPZone != tc.expectedZone {
+				t.Errorf("expected --gcp-zone=%s, but got %s", tc.expectedZone, tester.GCPZone)
+			}
+			if actual := argValue(t, tester.constructArgs(), "ZONE"); actual != tc.expectedZone {
+				t.Errorf("expected ZONE=%s, but got %s", tc.expectedZone, actual)
+			}
+		})
+	}
+}
+
+func TestGCPZonesRoundRobin(t *testing.T) {
+	dir := t.TempDir()
+	cmder := &fakeCmder{run: func(cmd *fakeCmd) error {
+		if cmd.name == "gcloud" && cmd.args[2] == "create" {
+			_, _ = fmt.Fprintf(cmd.stderr, "Created [https://www.googleapis.com/compute/v1/projects/p/zones/z/instances/%s].\n", cmd.args[3])
+		}
+		return nil
+	}}
+	tester := NewDefaultTester()
+	tester.RepoRoot = fakeRepoRoot(t)
+	tester.GCPProject = "p"
+	tester.GCPZones = []string{"us-central1-a", "us-central1-b"}
+	tester.Images = "cos-stable,ubuntu-2204,fedora"
+	tester.DeleteInstances = true
+	tester.runID = "8f14e45f-ceea-467f-a8b0-12c0a1b2c3d4"
+	tester.cmder = cmder
+	if err := tester.validateFlags(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !tester.createsInstances() {
+		t.Fatalf("expected the tester to create the instances across the zones")
+	}
+	if err := tester.runOnce(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	created := map[string]string{}
+	deleted := map[string][]string{}
+	for _, cmd := range cmder.sortedCmds() {
+		if cmd.name != "gcloud" || cmd.args[2] == "list" {
+			continue
+		}
+		zone := argValue(t, cmd.args, "--zone")
+		switch cmd.args[2] {
+		case "create":
+			created[cmd.args[3]] = zone
+		case "delete":
+			deleted[zone] = append(deleted[zone], cmd.args[6:]...)
+		}
+	}
+	expectedCreated := map[string]string{
+		"tmp-node-e2e-8f14e45f-cos-stable":  "us-central1-a",
+		"tmp-node-e2e-8f14e45f-ubuntu-2204": "us-central1-b",
+		"tmp-node-e2e-8f14e45f-fedora":      "us-central1-a",
+	}
+	if !reflect.DeepEqual(created, expectedCreated) {
+		t.Errorf("expected instances created in zones %v, but got %v", expectedCreated, created)
+	}
+	for _, instances := range deleted {
+		sort.Strings(instances)
+	}
+	expectedDeleted := map[string][]string{
+		"us-central1-a": {"tmp-node-e2e-8f14e45f-cos-stable", "tmp-node-e2e-8f14e45f-fedora"},
+		"us-central1-b": {"tmp-node-e2e-8f14e45f-ubuntu-2204"},
+	}
+	if !reflect.DeepEqual(deleted, expectedDeleted) {
+		t.Errorf("expected instances deleted from zones %v, but got %v", expectedDeleted, deleted)
+	}
+	command := tester.sshTransport().Command("tmp-node-e2e-8f14e45f-ubuntu-2204")
+	if !containsString(command, "--zone=us-central1-b") {
+		t.Errorf("expected ssh into the zone of the instance, but got %v", command)
+	}
+}